@@ -0,0 +1,174 @@
+package application
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+// MaxAttachmentSize is the Pushover limit for attachments.
+const MaxAttachmentSize = 5 * 1024 * 1024
+
+var (
+	ErrAttachmentConflict     = errors.New("only one of attachment_path and attachment_base64 may be set")
+	ErrAttachmentTooLarge     = fmt.Errorf("attachment exceeds %d bytes", MaxAttachmentSize)
+	ErrAttachmentEmpty        = errors.New("attachment is empty")
+	ErrAttachmentTypeRequired = errors.New("attachment must be an image")
+	ErrAttachmentPathDisabled = errors.New("attachment_path is disabled; no attachment directory is configured")
+	ErrAttachmentOutsideDir   = errors.New("attachment_path must be inside the attachment directory")
+)
+
+// ResolveAttachment loads an attachment from a file path or a base64 payload.
+// It returns nil when neither is set. The payload may be a data URI, and
+// mimeType is sniffed from the content when empty. Paths are read only from
+// inside dir, relative ones resolved against it; an empty dir rejects them.
+func ResolveAttachment(dir, path, data, mimeType string) (*domain.Attachment, error) {
+	path = strings.TrimSpace(path)
+	data = strings.TrimSpace(data)
+
+	switch {
+	case path != "" && data != "":
+		return nil, ErrAttachmentConflict
+	case path != "":
+		return loadAttachmentFile(dir, path)
+	case data != "":
+		return decodeAttachment(data, mimeType)
+	default:
+		return nil, nil
+	}
+}
+
+func loadAttachmentFile(dir, path string) (*domain.Attachment, error) {
+	path, err := attachmentPath(dir, path)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open attachment: %w", err)
+	}
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	data, err := io.ReadAll(io.LimitReader(file, MaxAttachmentSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("read attachment: %w", err)
+	}
+
+	if len(data) > MaxAttachmentSize {
+		return nil, ErrAttachmentTooLarge
+	}
+
+	return &domain.Attachment{
+		Filename: filepath.Base(path),
+		MIMEType: http.DetectContentType(data),
+		Data:     data,
+	}, nil
+}
+
+// attachmentPath resolves path inside dir. Paths outside are rejected before the file system is
+// touched, so the error does not tell whether they exist; symlinks are resolved and checked again.
+func attachmentPath(dir, path string) (string, error) {
+	if dir == "" {
+		return "", ErrAttachmentPathDisabled
+	}
+
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+
+	path = filepath.Clean(path)
+	if !insideDir(dir, path) {
+		return "", ErrAttachmentOutsideDir
+	}
+
+	realDir, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return "", fmt.Errorf("resolve attachment directory: %w", err)
+	}
+
+	realPath, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", fmt.Errorf("open attachment: %w", err)
+	}
+
+	if !insideDir(realDir, realPath) {
+		return "", ErrAttachmentOutsideDir
+	}
+
+	return realPath, nil
+}
+
+func insideDir(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) && !filepath.IsAbs(rel)
+}
+
+func decodeAttachment(data, mimeType string) (*domain.Attachment, error) {
+	if rest, ok := strings.CutPrefix(data, "data:"); ok {
+		header, payload, found := strings.Cut(rest, ",")
+		if !found || !strings.HasSuffix(header, ";base64") {
+			return nil, errors.New("attachment data URI must be base64 encoded")
+		}
+
+		if mimeType == "" {
+			mimeType = strings.TrimSuffix(header, ";base64")
+		}
+
+		data = payload
+	}
+
+	if base64.StdEncoding.DecodedLen(len(data)) > MaxAttachmentSize+2 {
+		return nil, ErrAttachmentTooLarge
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return nil, fmt.Errorf("decode attachment: %w", err)
+	}
+
+	if strings.TrimSpace(mimeType) == "" {
+		mimeType = http.DetectContentType(decoded)
+	}
+
+	return &domain.Attachment{
+		MIMEType: strings.TrimSpace(mimeType),
+		Data:     decoded,
+	}, nil
+}
+
+func validateAttachment(attachment *domain.Attachment) error {
+	if attachment == nil {
+		return nil
+	}
+
+	if len(attachment.Data) == 0 {
+		return ErrAttachmentEmpty
+	}
+
+	if len(attachment.Data) > MaxAttachmentSize {
+		return ErrAttachmentTooLarge
+	}
+
+	if !strings.HasPrefix(attachment.MIMEType, "image/") {
+		return fmt.Errorf("%w, got %q", ErrAttachmentTypeRequired, attachment.MIMEType)
+	}
+
+	// The declared type must agree with the content, so non-images cannot be smuggled in.
+	if sniffed := http.DetectContentType(attachment.Data); !strings.HasPrefix(sniffed, "image/") {
+		return fmt.Errorf("%w, content looks like %q", ErrAttachmentTypeRequired, sniffed)
+	}
+
+	return nil
+}
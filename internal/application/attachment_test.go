@@ -0,0 +1,164 @@
+package application
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+var testPNG = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func TestResolveAttachment_None(t *testing.T) {
+	attachment, err := ResolveAttachment("", "", " ", "")
+	if err != nil || attachment != nil {
+		t.Fatalf("ResolveAttachment() = %v, %v, want nil, nil", attachment, err)
+	}
+}
+
+func TestResolveAttachment_Conflict(t *testing.T) {
+	_, err := ResolveAttachment("/tmp", "/tmp/a.png", "aGVsbG8=", "")
+	if !errors.Is(err, ErrAttachmentConflict) {
+		t.Fatalf("error = %v, want %v", err, ErrAttachmentConflict)
+	}
+}
+
+func TestResolveAttachment_File(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "chart.png"), testPNG, 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	attachment, err := ResolveAttachment(dir, "chart.png", "", "")
+	if err != nil {
+		t.Fatalf("ResolveAttachment() error = %v", err)
+	}
+
+	assertString(t, attachment.Filename, "chart.png", "filename")
+	assertString(t, attachment.MIMEType, "image/png", "mime type")
+}
+
+func TestResolveAttachment_FileTooLarge(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "big.png")
+	if err := os.WriteFile(path, make([]byte, MaxAttachmentSize+1), 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	_, err := ResolveAttachment(dir, path, "", "")
+	if !errors.Is(err, ErrAttachmentTooLarge) {
+		t.Fatalf("error = %v, want %v", err, ErrAttachmentTooLarge)
+	}
+}
+
+func TestResolveAttachment_PathDisabled(t *testing.T) {
+	_, err := ResolveAttachment("", "/etc/passwd", "", "")
+	if !errors.Is(err, ErrAttachmentPathDisabled) {
+		t.Fatalf("error = %v, want %v", err, ErrAttachmentPathDisabled)
+	}
+}
+
+func TestResolveAttachment_OutsideDir(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "attachments")
+	secret := filepath.Join(root, "secret.png")
+
+	if err := os.Mkdir(dir, 0o700); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	if err := os.WriteFile(secret, testPNG, 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	if err := os.Symlink(secret, filepath.Join(dir, "link.png")); err != nil {
+		t.Fatalf("symlink: %v", err)
+	}
+
+	for _, path := range []string{secret, "../secret.png", "../missing.png", "link.png"} {
+		_, err := ResolveAttachment(dir, path, "", "")
+		if !errors.Is(err, ErrAttachmentOutsideDir) {
+			t.Fatalf("ResolveAttachment(%q) error = %v, want %v", path, err, ErrAttachmentOutsideDir)
+		}
+	}
+}
+
+func TestResolveAttachment_Base64(t *testing.T) {
+	encoded := base64.StdEncoding.EncodeToString(testPNG)
+
+	tests := []struct {
+		name     string
+		data     string
+		mimeType string
+		want     string
+	}{
+		{name: "sniffed", data: encoded, want: "image/png"},
+		{name: "declared", data: encoded, mimeType: "image/x-png", want: "image/x-png"},
+		{name: "data uri", data: "data:image/png;base64," + encoded, want: "image/png"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			attachment, err := ResolveAttachment("", "", tc.data, tc.mimeType)
+			if err != nil {
+				t.Fatalf("ResolveAttachment() error = %v", err)
+			}
+
+			assertString(t, attachment.MIMEType, tc.want, "mime type")
+			assertString(t, attachment.Filename, "", "filename")
+		})
+	}
+}
+
+func TestResolveAttachment_InvalidBase64(t *testing.T) {
+	_, err := ResolveAttachment("", "", "not base64!", "")
+	if err == nil || !strings.Contains(err.Error(), "decode attachment") {
+		t.Fatalf("error = %v, want decode error", err)
+	}
+}
+
+func TestSendNotificationUseCase_Execute_AttachmentValidation(t *testing.T) {
+	tests := []struct {
+		name       string
+		attachment *domain.Attachment
+		want       error
+	}{
+		{
+			name:       "empty",
+			attachment: &domain.Attachment{MIMEType: "image/png"},
+			want:       ErrAttachmentEmpty,
+		},
+		{
+			name:       "too large",
+			attachment: &domain.Attachment{MIMEType: "image/png", Data: make([]byte, MaxAttachmentSize+1)},
+			want:       ErrAttachmentTooLarge,
+		},
+		{
+			name:       "declared non-image",
+			attachment: &domain.Attachment{MIMEType: "text/plain", Data: testPNG},
+			want:       ErrAttachmentTypeRequired,
+		},
+		{
+			name:       "content non-image",
+			attachment: &domain.Attachment{MIMEType: "image/png", Data: []byte("#!/bin/sh\necho hi\n")},
+			want:       ErrAttachmentTypeRequired,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			sender, useCase := newUseCaseWithFake()
+
+			_, err := useCase.Execute(context.Background(), domain.Notification{
+				Message:    testMessage,
+				Attachment: tc.attachment,
+			})
+			assertValidationError(t, sender, err, tc.want)
+		})
+	}
+}
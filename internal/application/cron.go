@@ -0,0 +1,198 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidCron = errors.New("invalid cron expression")
+
+// cronSearchYears bounds the search for the next run, so expressions such as "0 0 30 2 *" end.
+const cronSearchYears = 5
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var (
+	cronMonthNames = []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}
+	cronDayNames   = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+)
+
+// cronSchedule is a parsed five-field cron expression: minute, hour, day of month, month and day of week.
+// Each field is a bit set of the values it matches.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	// Like Vixie cron, a day matches either day field when both are restricted, and the other one when only one is.
+	domAny, dowAny bool
+}
+
+// parseCron accepts the standard syntax: *, lists, ranges, steps, month and weekday names
+// (0 and 7 are Sunday) and the @yearly, @monthly, @weekly, @daily and @hourly macros.
+func parseCron(expr string) (cronSchedule, error) {
+	expr = strings.TrimSpace(strings.ToLower(expr))
+	if macro, ok := cronMacros[expr]; ok {
+		expr = macro
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return cronSchedule{}, fmt.Errorf("%w: want 5 fields (minute hour day-of-month month day-of-week), got %d",
+			ErrInvalidCron, len(fields))
+	}
+
+	var (
+		c   cronSchedule
+		err error
+	)
+
+	if c.minute, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return cronSchedule{}, fmt.Errorf("%w: minute: %w", ErrInvalidCron, err)
+	}
+
+	if c.hour, err = parseCronField(fields[1], 0, 23, nil); err != nil {
+		return cronSchedule{}, fmt.Errorf("%w: hour: %w", ErrInvalidCron, err)
+	}
+
+	if c.dom, err = parseCronField(fields[2], 1, 31, nil); err != nil {
+		return cronSchedule{}, fmt.Errorf("%w: day of month: %w", ErrInvalidCron, err)
+	}
+
+	if c.month, err = parseCronField(fields[3], 1, 12, cronMonthNames); err != nil {
+		return cronSchedule{}, fmt.Errorf("%w: month: %w", ErrInvalidCron, err)
+	}
+
+	if c.dow, err = parseCronField(fields[4], 0, 7, cronDayNames); err != nil {
+		return cronSchedule{}, fmt.Errorf("%w: day of week: %w", ErrInvalidCron, err)
+	}
+
+	if c.dow&(1<<7) != 0 {
+		c.dow |= 1
+	}
+
+	c.domAny = strings.HasPrefix(fields[2], "*")
+	c.dowAny = strings.HasPrefix(fields[4], "*")
+
+	return c, nil
+}
+
+// parseCronField parses a comma-separated list of *, n, a-b, each optionally followed by /step.
+// names, when given, are accepted in place of numbers starting at min.
+func parseCronField(field string, low, high int, names []string) (uint64, error) {
+	var set uint64
+
+	for part := range strings.SplitSeq(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+
+		step := 1
+
+		if hasStep {
+			n, err := strconv.Atoi(stepPart)
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("invalid step %q", stepPart)
+			}
+
+			step = n
+		}
+
+		first, last := low, high
+
+		if rangePart != "*" {
+			from, to, isRange := strings.Cut(rangePart, "-")
+
+			var err error
+			if first, err = parseCronValue(from, low, high, names); err != nil {
+				return 0, err
+			}
+
+			last = first
+
+			switch {
+			case isRange:
+				if last, err = parseCronValue(to, low, high, names); err != nil {
+					return 0, err
+				}
+			case hasStep:
+				last = high
+			}
+
+			if first > last {
+				return 0, fmt.Errorf("range %q is reversed", rangePart)
+			}
+		}
+
+		for v := first; v <= last; v += step {
+			set |= 1 << v
+		}
+	}
+
+	return set, nil
+}
+
+func parseCronValue(value string, low, high int, names []string) (int, error) {
+	for i, name := range names {
+		if value == name {
+			return low + i, nil
+		}
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", value)
+	}
+
+	if n < low || n > high {
+		return 0, fmt.Errorf("value %d out of range %d-%d", n, low, high)
+	}
+
+	return n, nil
+}
+
+// next returns the first matching minute after t, in t's location; zero when there is none within cronSearchYears.
+// A wall time skipped by a daylight saving change does not run that day; a repeated one runs once.
+func (c cronSchedule) next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(cronSearchYears, 0, 0)
+
+	for t.Before(limit) {
+		switch {
+		case c.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !c.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case c.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case c.minute&(1<<uint(t.Minute())) == 0 && t.Minute() < 59:
+			t = t.Add(time.Minute)
+		case c.minute&(1<<uint(t.Minute())) == 0:
+			// Going through time.Date rather than adding a minute leaves a repeated hour only once.
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		default:
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+func (c cronSchedule) dayMatches(t time.Time) bool {
+	dom := c.dom&(1<<uint(t.Day())) != 0
+	dow := c.dow&(1<<uint(t.Weekday())) != 0
+
+	switch {
+	case c.domAny || c.dowAny:
+		return dom && dow
+	default:
+		return dom || dow
+	}
+}
@@ -0,0 +1,109 @@
+package application
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("time zone %s not available: %v", name, err)
+	}
+
+	return loc
+}
+
+func TestParseCron_Next(t *testing.T) {
+	from := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC) // a Friday
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{expr: "* * * * *", want: time.Date(2026, 5, 1, 12, 1, 0, 0, time.UTC)},
+		{expr: "30 9 * * mon-fri", want: time.Date(2026, 5, 4, 9, 30, 0, 0, time.UTC)},
+		{expr: "30 9 * * 1-5", want: time.Date(2026, 5, 4, 9, 30, 0, 0, time.UTC)},
+		{expr: "*/15 * * * *", want: time.Date(2026, 5, 1, 12, 15, 0, 0, time.UTC)},
+		{expr: "0 0 1 * *", want: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 8 * jul *", want: time.Date(2026, 7, 1, 8, 0, 0, 0, time.UTC)},
+		{expr: "0 10 * * 7", want: time.Date(2026, 5, 3, 10, 0, 0, 0, time.UTC)},
+		{expr: "0 12,18 * * *", want: time.Date(2026, 5, 1, 18, 0, 0, 0, time.UTC)},
+		{expr: "0 0 29 2 *", want: time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// Both day fields restricted: either matches.
+		{expr: "0 9 15 * mon", want: time.Date(2026, 5, 4, 9, 0, 0, 0, time.UTC)},
+		{expr: "@hourly", want: time.Date(2026, 5, 1, 13, 0, 0, 0, time.UTC)},
+		{expr: "@weekly", want: time.Date(2026, 5, 3, 0, 0, 0, 0, time.UTC)},
+		{expr: "5-20/5 12 * * *", want: time.Date(2026, 5, 1, 12, 5, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.expr, func(t *testing.T) {
+			c, err := parseCron(tc.expr)
+			if err != nil {
+				t.Fatalf("parseCron() error = %v", err)
+			}
+
+			if got := c.next(from); !got.Equal(tc.want) {
+				t.Fatalf("next() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseCron_NeverRuns(t *testing.T) {
+	c, err := parseCron("0 0 30 2 *")
+	if err != nil {
+		t.Fatalf("parseCron() error = %v", err)
+	}
+
+	if got := c.next(time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)); !got.IsZero() {
+		t.Fatalf("next() = %v, want zero", got)
+	}
+}
+
+func TestParseCron_Invalid(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *",
+		"* * * * 8", "*/0 * * * *", "5-1 * * * *", "a * * * *", "* * * * * *", "@sometimes"} {
+		t.Run(expr, func(t *testing.T) {
+			if _, err := parseCron(expr); !errors.Is(err, ErrInvalidCron) {
+				t.Fatalf("parseCron(%q) error = %v, want %v", expr, err, ErrInvalidCron)
+			}
+		})
+	}
+}
+
+func TestCronSchedule_DaylightSaving(t *testing.T) {
+	berlin := mustLoadLocation(t, "Europe/Berlin")
+
+	c, err := parseCron("30 2 * * *")
+	if err != nil {
+		t.Fatalf("parseCron() error = %v", err)
+	}
+
+	// 02:30 does not exist on 2026-03-29, so the job runs next on the 30th.
+	got := c.next(time.Date(2026, 3, 28, 12, 0, 0, 0, berlin))
+	if want := time.Date(2026, 3, 30, 2, 30, 0, 0, berlin); !got.Equal(want) {
+		t.Fatalf("next() = %v, want %v", got, want)
+	}
+
+	// 02:30 happens twice on 2026-10-25; the job runs once.
+	first := c.next(time.Date(2026, 10, 25, 0, 0, 0, 0, berlin))
+	second := c.next(first)
+
+	if first.Day() != 25 || second.Day() != 26 {
+		t.Fatalf("runs = %v, %v, want one on the 25th and one on the 26th", first, second)
+	}
+
+	inZone, err := parseCron("0 9 * * *")
+	if err != nil {
+		t.Fatalf("parseCron() error = %v", err)
+	}
+
+	if got := inZone.next(time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC).In(berlin)); !got.Equal(time.Date(2026, 7, 2, 7, 0, 0, 0, time.UTC)) {
+		t.Fatalf("next() = %v, want 09:00 Berlin time", got.UTC())
+	}
+}
@@ -0,0 +1,192 @@
+package application
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+// DedupSender is a domain.NotificationSender decorator that suppresses repeated sends. A notification
+// with an IdempotencyKey is sent once per key and content within keyTTL; any notification is sent once
+// per content within window. A repeat gets the first result, marked Duplicate; a repeat arriving while
+// the first send is still in flight waits for it. Failed sends are forgotten so that a retry goes out.
+type DedupSender struct {
+	next  domain.NotificationSender
+	cache *dedupCache
+}
+
+// NewDedupSender wraps next. A zero window disables content deduplication and a zero keyTTL
+// disables idempotency keys.
+func NewDedupSender(next domain.NotificationSender, window, keyTTL time.Duration) *DedupSender {
+	return &DedupSender{next: next, cache: newDedupCache(window, keyTTL)}
+}
+
+func (d *DedupSender) Send(ctx context.Context, notification domain.Notification) (domain.SendResult, error) {
+	return d.cache.do(ctx, notification, d.next.Send)
+}
+
+// DedupExecutor applies DedupSender's rules in front of the executors, so that a repeat also gets the
+// first outcome when that was not a send: a notification held for quiet hours or collected into a digest.
+// Sends to several recipients pass through; the DedupSender of each app handles them.
+type DedupExecutor struct {
+	next  FanOutExecutor
+	cache *dedupCache
+}
+
+// NewDedupExecutor wraps next with the same window and keyTTL as NewDedupSender.
+func NewDedupExecutor(next FanOutExecutor, window, keyTTL time.Duration) *DedupExecutor {
+	return &DedupExecutor{next: next, cache: newDedupCache(window, keyTTL)}
+}
+
+func (d *DedupExecutor) Check(ctx context.Context, notification domain.Notification) error {
+	return d.next.Check(ctx, notification)
+}
+
+func (d *DedupExecutor) Execute(ctx context.Context, notification domain.Notification) (domain.SendResult, error) {
+	return d.cache.do(ctx, notification, d.next.Execute)
+}
+
+func (d *DedupExecutor) ExecuteFanOut(
+	ctx context.Context,
+	notification domain.Notification,
+	recipients []string,
+) ([]RecipientResult, error) {
+	return d.next.ExecuteFanOut(ctx, notification, recipients)
+}
+
+// dedupCache remembers the outcome of recent calls by idempotency key and content.
+type dedupCache struct {
+	now     func() time.Time
+	records map[string]dedupRecord
+	window  time.Duration
+	keyTTL  time.Duration
+	mu      sync.Mutex
+}
+
+type dedupRecord struct {
+	expires time.Time
+	send    *dedupSend
+}
+
+// dedupSend is one call of the wrapped sender; done is closed once result and err are set.
+type dedupSend struct {
+	done   chan struct{}
+	err    error
+	result domain.SendResult
+}
+
+func newDedupCache(window, keyTTL time.Duration) *dedupCache {
+	return &dedupCache{
+		now:     time.Now,
+		records: map[string]dedupRecord{},
+		window:  window,
+		keyTTL:  keyTTL,
+	}
+}
+
+// do calls send unless the notification repeats a recent call, whose result it returns instead.
+func (d *dedupCache) do(
+	ctx context.Context,
+	notification domain.Notification,
+	send func(ctx context.Context, notification domain.Notification) (domain.SendResult, error),
+) (domain.SendResult, error) {
+	ids := d.ids(notification)
+	if len(ids) == 0 {
+		return send(ctx, notification)
+	}
+
+	d.mu.Lock()
+	now := d.now()
+	d.prune(now)
+
+	for id := range ids {
+		if record, ok := d.records[id]; ok {
+			d.mu.Unlock()
+			return record.send.wait(ctx)
+		}
+	}
+
+	call := &dedupSend{done: make(chan struct{})}
+	for id, ttl := range ids {
+		d.records[id] = dedupRecord{expires: now.Add(ttl), send: call}
+	}
+	d.mu.Unlock()
+
+	call.result, call.err = send(ctx, notification)
+	close(call.done)
+
+	if call.err != nil {
+		d.mu.Lock()
+		for id := range ids {
+			if d.records[id].send == call {
+				delete(d.records, id)
+			}
+		}
+		d.mu.Unlock()
+	}
+
+	return call.result, call.err
+}
+
+// ids returns the cache entries the notification is recorded under, with how long each is kept.
+func (d *dedupCache) ids(notification domain.Notification) map[string]time.Duration {
+	ids := make(map[string]time.Duration, 2)
+
+	if d.window <= 0 && (d.keyTTL <= 0 || notification.IdempotencyKey == "") {
+		return ids
+	}
+
+	hash := contentHash(notification)
+
+	if d.window > 0 {
+		ids["content\x00"+hash] = d.window
+	}
+
+	// The content is part of the key's entry, so the parts of a split message, which share the key, all go out.
+	if d.keyTTL > 0 && notification.IdempotencyKey != "" {
+		ids["key\x00"+notification.IdempotencyKey+"\x00"+hash] = d.keyTTL
+	}
+
+	return ids
+}
+
+func (d *dedupCache) prune(now time.Time) {
+	for id, record := range d.records {
+		if !now.Before(record.expires) {
+			delete(d.records, id)
+		}
+	}
+}
+
+func (s *dedupSend) wait(ctx context.Context) (domain.SendResult, error) {
+	select {
+	case <-ctx.Done():
+		return domain.SendResult{}, ctx.Err()
+	case <-s.done:
+	}
+
+	if s.err != nil {
+		return domain.SendResult{}, s.err
+	}
+
+	result := s.result
+	result.Duplicate = true
+
+	return result, nil
+}
+
+// contentHash identifies what the recipient would see, ignoring the idempotency key.
+func contentHash(notification domain.Notification) string {
+	notification.IdempotencyKey = ""
+
+	// Notification holds only plain values, so encoding cannot fail.
+	data, _ := json.Marshal(notification)
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,244 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+func newTestDedupSender(window, keyTTL time.Duration) (*partsSender, *DedupSender, *time.Time) {
+	sender := &partsSender{}
+	dedup := NewDedupSender(sender, window, keyTTL)
+	now := testNow
+	dedup.cache.now = func() time.Time { return now }
+
+	return sender, dedup, &now
+}
+
+func TestDedupSender_IdempotencyKey(t *testing.T) {
+	sender, dedup, now := newTestDedupSender(0, time.Hour)
+	notification := domain.Notification{Message: testMessage, IdempotencyKey: "deploy-42"}
+
+	first, err := dedup.Send(t.Context(), notification)
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	*now = now.Add(30 * time.Minute)
+
+	second, err := dedup.Send(t.Context(), notification)
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	if len(sender.sent) != 1 {
+		t.Fatalf("sent %d notifications, want 1", len(sender.sent))
+	}
+
+	if !second.Duplicate || second.Request != first.Request {
+		t.Fatalf("second result = %+v, want duplicate of %+v", second, first)
+	}
+
+	if first.Duplicate {
+		t.Fatal("first result marked duplicate")
+	}
+
+	*now = now.Add(time.Hour)
+
+	if _, err := dedup.Send(t.Context(), notification); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	if len(sender.sent) != 2 {
+		t.Fatalf("sent %d notifications after the key expired, want 2", len(sender.sent))
+	}
+}
+
+func TestDedupSender_KeyWithDifferentContentSends(t *testing.T) {
+	sender, dedup, _ := newTestDedupSender(0, time.Hour)
+
+	for _, message := range []string{"part 1", "part 2"} {
+		if _, err := dedup.Send(t.Context(), domain.Notification{Message: message, IdempotencyKey: "k"}); err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+	}
+
+	if len(sender.sent) != 2 {
+		t.Fatalf("sent %d notifications, want 2", len(sender.sent))
+	}
+}
+
+func TestDedupSender_ContentWindow(t *testing.T) {
+	sender, dedup, now := newTestDedupSender(time.Minute, 0)
+	notification := domain.Notification{Message: testMessage}
+
+	for range 2 {
+		if _, err := dedup.Send(t.Context(), notification); err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+	}
+
+	if len(sender.sent) != 1 {
+		t.Fatalf("sent %d notifications within the window, want 1", len(sender.sent))
+	}
+
+	// A different key does not make the same content new.
+	result, err := dedup.Send(t.Context(), domain.Notification{Message: testMessage, IdempotencyKey: "other"})
+	if err != nil || !result.Duplicate {
+		t.Fatalf("Send() = %+v, %v; want duplicate", result, err)
+	}
+
+	*now = now.Add(time.Minute)
+
+	if _, err := dedup.Send(t.Context(), notification); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	if len(sender.sent) != 2 {
+		t.Fatalf("sent %d notifications after the window, want 2", len(sender.sent))
+	}
+}
+
+func TestDedupSender_Disabled(t *testing.T) {
+	sender, dedup, _ := newTestDedupSender(0, 0)
+	notification := domain.Notification{Message: testMessage, IdempotencyKey: "k"}
+
+	for range 2 {
+		if _, err := dedup.Send(t.Context(), notification); err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+	}
+
+	if len(sender.sent) != 2 {
+		t.Fatalf("sent %d notifications, want 2", len(sender.sent))
+	}
+}
+
+func TestDedupSender_FailureIsForgotten(t *testing.T) {
+	errSend := errors.New("boom")
+	sender, dedup, _ := newTestDedupSender(time.Minute, time.Hour)
+	sender.err = errSend
+	sender.failCall = 1
+	notification := domain.Notification{Message: testMessage, IdempotencyKey: "k"}
+
+	if _, err := dedup.Send(t.Context(), notification); !errors.Is(err, errSend) {
+		t.Fatalf("Send() error = %v, want %v", err, errSend)
+	}
+
+	result, err := dedup.Send(t.Context(), notification)
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	if result.Duplicate || len(sender.sent) != 2 {
+		t.Fatalf("retry result = %+v after %d sends, want a fresh send", result, len(sender.sent))
+	}
+}
+
+// blockingSender holds every send until release is closed.
+type blockingSender struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (s blockingSender) Send(_ context.Context, _ domain.Notification) (domain.SendResult, error) {
+	s.started <- struct{}{}
+	<-s.release
+
+	return domain.SendResult{Request: "r"}, nil
+}
+
+func TestDedupSender_WaitsForInFlightSend(t *testing.T) {
+	sender := blockingSender{started: make(chan struct{}, 2), release: make(chan struct{})}
+	dedup := NewDedupSender(sender, time.Minute, 0)
+	notification := domain.Notification{Message: testMessage}
+
+	go func() {
+		_, _ = dedup.Send(context.Background(), notification)
+	}()
+
+	<-sender.started
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	if _, err := dedup.Send(ctx, notification); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Send() error = %v, want %v", err, context.Canceled)
+	}
+
+	done := make(chan domain.SendResult)
+
+	go func() {
+		result, _ := dedup.Send(context.Background(), notification)
+		done <- result
+	}()
+
+	close(sender.release)
+
+	if result := <-done; !result.Duplicate || result.Request != "r" {
+		t.Fatalf("waiting result = %+v, want duplicate of the in-flight send", result)
+	}
+
+	select {
+	case <-sender.started:
+		t.Fatal("duplicate was sent")
+	default:
+	}
+}
+
+func TestDedupSender_RetriedSplitSendsMissingParts(t *testing.T) {
+	sender := &partsSender{err: errors.New("boom"), failCall: 2}
+	useCase := NewSendNotificationUseCase(NewDedupSender(sender, 0, time.Hour), WithLengthPolicy(LengthSplit))
+	notification := domain.Notification{Message: strings.Repeat("x", 2*MaxMessageLength), IdempotencyKey: "k"}
+
+	if _, err := useCase.Execute(t.Context(), notification); err == nil {
+		t.Fatal("Execute() error = nil, want the failed part")
+	}
+
+	result, err := useCase.Execute(t.Context(), notification)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	// The first part is not sent again: 2 sends on the first call, the two missing parts on the retry.
+	if len(sender.sent) != 4 || result.Duplicate {
+		t.Fatalf("sent %d parts, result = %+v; want 4 sends and a fresh result", len(sender.sent), result)
+	}
+}
+
+func TestDedupExecutor_HeldRepeatIsNotHeldAgain(t *testing.T) {
+	sender, store, quiet := newTestQuietHours(t, QuietHold)
+	dedup := NewDedupExecutor(quiet, 0, time.Hour)
+	notification := domain.Notification{Message: testMessage, IdempotencyKey: "deploy-42"}
+
+	first, err := dedup.Execute(t.Context(), notification)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	second, err := dedup.Execute(t.Context(), notification)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if len(store.items) != 1 || sender.called {
+		t.Fatalf("store = %+v, sent = %v; want the notification held once", store.items, sender.called)
+	}
+
+	if !second.Duplicate || second.QuietHours == nil || second.QuietHours.ScheduledID != first.QuietHours.ScheduledID {
+		t.Fatalf("second result = %+v, want duplicate of %+v", second, first)
+	}
+
+	// Without a key, the same request is a new one.
+	if _, err := dedup.Execute(t.Context(), domain.Notification{Message: testMessage}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if len(store.items) != 2 {
+		t.Fatalf("store = %+v, want a second held notification", store.items)
+	}
+}
@@ -0,0 +1,422 @@
+package application
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+const (
+	// digestIdle is how long the runner sleeps while no digest is pending; a new one wakes it.
+	digestIdle = time.Hour
+	// digestItemLength caps the characters one notification takes up in a digest.
+	digestItemLength = 160
+	// digestShutdownTimeout bounds sending the pending digests once the runner is stopped.
+	digestShutdownTimeout = 30 * time.Second
+)
+
+var ErrInvalidDigest = errors.New("invalid digest policy")
+
+var htmlTag = regexp.MustCompile(`<[^>]*>`)
+
+// DigestPolicy collects notifications at or below Priority and sends them as one message once
+// the first has waited Window or MaxItems have come together.
+type DigestPolicy struct {
+	Window   time.Duration
+	Priority int
+	MaxItems int
+}
+
+// Validate rejects policies that would digest emergencies or never send.
+func (p DigestPolicy) Validate() error {
+	switch {
+	case p.Priority < -2 || p.Priority >= emergencyPriority:
+		return fmt.Errorf("%w: priority must be from -2 to 1, got %d", ErrInvalidDigest, p.Priority)
+	case p.Window <= 0:
+		return fmt.Errorf("%w: window must be positive, got %s", ErrInvalidDigest, p.Window)
+	case p.MaxItems < 2:
+		return fmt.Errorf("%w: max items must be at least 2, got %d", ErrInvalidDigest, p.MaxItems)
+	}
+
+	return nil
+}
+
+// FanOutExecutor is a NotificationExecutor that also sends to several recipients;
+// SendNotificationUseCase, QuietHoursUseCase and DigestUseCase implement it.
+type FanOutExecutor interface {
+	NotificationExecutor
+	ExecuteFanOut(ctx context.Context, notification domain.Notification, recipients []string) ([]RecipientResult, error)
+}
+
+// DigestUseCase buffers low-priority notifications per destination and sends each buffer as one
+// combined message. Anything else for a destination first flushes its buffer, so that the
+// recipient still gets everything in order.
+type DigestUseCase struct {
+	next    FanOutExecutor
+	send    *SendNotificationUseCase
+	now     func() time.Time
+	buffers map[digestKey]*digestBuffer
+	wake    chan struct{}
+	policy  DigestPolicy
+	mu      sync.Mutex
+}
+
+// digestKey is where a notification goes; only notifications for the same destination are combined.
+type digestKey struct {
+	app    string
+	user   string
+	device string
+}
+
+type digestBuffer struct {
+	started time.Time
+	items   []domain.Notification
+	// sending is held while the buffer is flushed and while other notifications to its destination go out.
+	sending sync.Mutex
+}
+
+// NewDigestUseCase wraps next; send resolves recipient aliases so that their default priority counts.
+func NewDigestUseCase(next FanOutExecutor, send *SendNotificationUseCase, policy DigestPolicy) (*DigestUseCase, error) {
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &DigestUseCase{
+		next:    next,
+		send:    send,
+		now:     time.Now,
+		buffers: map[digestKey]*digestBuffer{},
+		wake:    make(chan struct{}, 1),
+		policy:  policy,
+	}, nil
+}
+
+func (u *DigestUseCase) Check(ctx context.Context, notification domain.Notification) error {
+	return u.next.Check(ctx, notification)
+}
+
+// Execute collects a low-priority notification, or flushes its destination's digest and sends it.
+// A collected notification's result has no request; its Digest tells when it goes out.
+func (u *DigestUseCase) Execute(ctx context.Context, notification domain.Notification) (domain.SendResult, error) {
+	addressed := u.address(notification, notification.User)
+	buffer := u.buffer(digestKeyOf(addressed))
+
+	if !u.collects(addressed) {
+		buffer.sending.Lock()
+		defer buffer.sending.Unlock()
+
+		if _, _, err := u.flush(ctx, buffer); err != nil {
+			return domain.SendResult{}, err
+		}
+
+		return u.next.Execute(ctx, notification)
+	}
+
+	if err := u.next.Check(ctx, notification); err != nil {
+		return domain.SendResult{}, err
+	}
+
+	u.mu.Lock()
+	if len(buffer.items) == 0 {
+		buffer.started = u.now()
+		wakeUp(u.wake)
+	}
+
+	buffer.items = append(buffer.items, addressed)
+	items, flushAt := len(buffer.items), buffer.started.Add(u.policy.Window)
+	u.mu.Unlock()
+
+	if items < u.policy.MaxItems {
+		return domain.SendResult{Digest: &domain.DigestOutcome{FlushAt: flushAt, Items: items}}, nil
+	}
+
+	buffer.sending.Lock()
+	defer buffer.sending.Unlock()
+
+	result, sent, err := u.flush(ctx, buffer)
+	if err != nil {
+		if !domain.IsRetryable(err) {
+			return domain.SendResult{}, err
+		}
+
+		// The notification stays collected and goes out with the retry.
+		u.mu.Lock()
+		items, flushAt = len(buffer.items), buffer.started.Add(u.policy.Window)
+		u.mu.Unlock()
+
+		return domain.SendResult{Digest: &domain.DigestOutcome{FlushAt: flushAt, Items: items}}, nil
+	}
+
+	// The runner got to the buffer first and sent this notification along with the others.
+	if sent == 0 {
+		sent = items
+	}
+
+	result.Digest = &domain.DigestOutcome{Items: sent}
+
+	return result, nil
+}
+
+// ExecuteFanOut is never collected; it flushes the digests of its recipients before sending.
+func (u *DigestUseCase) ExecuteFanOut(
+	ctx context.Context,
+	notification domain.Notification,
+	recipients []string,
+) ([]RecipientResult, error) {
+	names, err := normalizeRecipients(recipients)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]digestKey, 0, len(names))
+	for _, name := range names {
+		keys = append(keys, digestKeyOf(u.address(notification, name)))
+	}
+
+	// A fixed locking order keeps concurrent fan-outs from deadlocking.
+	slices.SortFunc(keys, compareDigestKeys)
+
+	for _, key := range slices.Compact(keys) {
+		buffer := u.buffer(key)
+
+		buffer.sending.Lock()
+		defer buffer.sending.Unlock()
+
+		if _, _, err := u.flush(ctx, buffer); err != nil {
+			return nil, err
+		}
+	}
+
+	return u.next.ExecuteFanOut(ctx, notification, names)
+}
+
+// Run sends each digest when its window ends, until ctx is done. Then it sends every pending digest
+// right away, so that none is lost on shutdown. Failures are passed to report.
+func (u *DigestUseCase) Run(ctx context.Context, report func(error)) {
+	runUntilDone(ctx, u.wake, func() time.Duration { return u.flushDue(ctx, report) })
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), digestShutdownTimeout)
+	defer cancel()
+
+	u.flushAll(ctx, report)
+}
+
+// flushAll sends every pending digest, whether or not its window has ended.
+func (u *DigestUseCase) flushAll(ctx context.Context, report func(error)) {
+	u.mu.Lock()
+	buffers := slices.Collect(maps.Values(u.buffers))
+	u.mu.Unlock()
+
+	for _, buffer := range buffers {
+		buffer.sending.Lock()
+		_, _, err := u.flush(ctx, buffer)
+		buffer.sending.Unlock()
+
+		if err != nil {
+			report(err)
+		}
+	}
+}
+
+// flushDue sends every digest whose window has ended and returns how long until the next one does.
+func (u *DigestUseCase) flushDue(ctx context.Context, report func(error)) time.Duration {
+	u.mu.Lock()
+	buffers := make([]*digestBuffer, 0, len(u.buffers))
+
+	for _, buffer := range u.buffers {
+		buffers = append(buffers, buffer)
+	}
+	u.mu.Unlock()
+
+	next := digestIdle
+
+	for _, buffer := range buffers {
+		u.mu.Lock()
+		pending, flushAt := len(buffer.items), buffer.started.Add(u.policy.Window)
+		u.mu.Unlock()
+
+		if pending == 0 {
+			continue
+		}
+
+		if wait := flushAt.Sub(u.now()); wait > 0 {
+			next = min(next, wait)
+			continue
+		}
+
+		buffer.sending.Lock()
+		_, _, err := u.flush(ctx, buffer)
+		buffer.sending.Unlock()
+
+		if err != nil {
+			report(err)
+			// Retryable failures keep the items; try again after another window.
+			next = min(next, u.policy.Window)
+		}
+	}
+
+	return next
+}
+
+// flush sends the buffered notifications, a single one as it is, and returns how many went out.
+// The caller holds buffer.sending. When the send may succeed later the items stay buffered, and
+// the window starts over; otherwise they are dropped.
+func (u *DigestUseCase) flush(ctx context.Context, buffer *digestBuffer) (domain.SendResult, int, error) {
+	u.mu.Lock()
+	items := buffer.items
+	buffer.items = nil
+	u.mu.Unlock()
+
+	if len(items) == 0 {
+		return domain.SendResult{}, 0, nil
+	}
+
+	notification := items[0]
+	if len(items) > 1 {
+		notification = combine(items)
+	}
+
+	result, err := u.next.Execute(ctx, notification)
+	if err != nil {
+		if domain.IsRetryable(err) {
+			u.mu.Lock()
+			buffer.items = append(items, buffer.items...)
+			buffer.started = u.now()
+			u.mu.Unlock()
+		}
+
+		return domain.SendResult{}, 0, fmt.Errorf("send digest of %d notifications: %w", len(items), err)
+	}
+
+	return result, len(items), nil
+}
+
+func (u *DigestUseCase) buffer(key digestKey) *digestBuffer {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	buffer, ok := u.buffers[key]
+	if !ok {
+		buffer = &digestBuffer{}
+		u.buffers[key] = buffer
+	}
+
+	return buffer
+}
+
+// address applies the alias defaults of recipient, as the send would.
+func (u *DigestUseCase) address(notification domain.Notification, recipient string) domain.Notification {
+	if recipient == "" {
+		return notification
+	}
+
+	return u.send.addressTo(notification, recipient)
+}
+
+// collects reports whether a notification goes into a digest. Attachments cannot be combined.
+func (u *DigestUseCase) collects(notification domain.Notification) bool {
+	priority := 0
+	if notification.Priority != nil {
+		priority = *notification.Priority
+	}
+
+	return priority <= u.policy.Priority && notification.Attachment == nil
+}
+
+func compareDigestKeys(a, b digestKey) int {
+	return cmp.Or(cmp.Compare(a.app, b.app), cmp.Compare(a.user, b.user), cmp.Compare(a.device, b.device))
+}
+
+func digestKeyOf(notification domain.Notification) digestKey {
+	return digestKey{app: notification.App, user: notification.User, device: notification.Device}
+}
+
+// combine builds the digest message: a count per priority and the latest items, newest first,
+// as many as fit. It goes out with the highest priority among the items.
+func combine(items []domain.Notification) domain.Notification {
+	counts := map[int]int{}
+	highest := -2
+
+	for _, item := range items {
+		priority := 0
+		if item.Priority != nil {
+			priority = *item.Priority
+		}
+
+		counts[priority]++
+		highest = max(highest, priority)
+	}
+
+	summary := make([]string, 0, len(counts))
+	for _, priority := range slices.Backward(slices.Sorted(maps.Keys(counts))) {
+		summary = append(summary, fmt.Sprintf("%d at priority %d", counts[priority], priority))
+	}
+
+	header := fmt.Sprintf("%d notifications (%s). Latest first:", len(items), strings.Join(summary, ", "))
+	lines := []string{header}
+	length := utf8.RuneCountInString(header)
+
+	for i := len(items) - 1; i >= 0; i-- {
+		line := "• " + digestLine(items[i])
+		more := fmt.Sprintf("…and %d more.", i)
+
+		// Leave room for the "…and N more." line while older items remain.
+		need := length + 1 + utf8.RuneCountInString(line)
+		if i > 0 {
+			need += 1 + utf8.RuneCountInString(more)
+		}
+
+		if need > MaxMessageLength {
+			lines = append(lines, fmt.Sprintf("…and %d more.", i+1))
+			break
+		}
+
+		lines = append(lines, line)
+		length += 1 + utf8.RuneCountInString(line)
+	}
+
+	first := items[0]
+
+	return domain.Notification{
+		Message:  strings.Join(lines, "\n"),
+		Title:    fmt.Sprintf("Digest: %d notifications", len(items)),
+		Priority: &highest,
+		User:     first.User,
+		Device:   first.Device,
+		App:      first.App,
+	}
+}
+
+// digestLine is the item's title and message as one line of plain text.
+func digestLine(item domain.Notification) string {
+	message := item.Message
+	if item.Markdown {
+		message = markdownToHTML(message)
+	}
+
+	if item.HTML || item.Markdown {
+		message = html.UnescapeString(htmlTag.ReplaceAllString(message, ""))
+	}
+
+	line := strings.Join(strings.Fields(message), " ")
+	if title := strings.TrimSpace(item.Title); title != "" {
+		line = title + ": " + line
+	}
+
+	if utf8.RuneCountInString(line) > digestItemLength {
+		line, _ = truncate(line, digestItemLength)
+	}
+
+	return line
+}
@@ -0,0 +1,346 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+func newTestDigest(t *testing.T, sender domain.NotificationSender, opts ...Option) (*DigestUseCase, *time.Time) {
+	t.Helper()
+
+	send := NewSendNotificationUseCase(sender, opts...)
+
+	digest, err := NewDigestUseCase(send, send, DigestPolicy{Priority: 0, Window: 10 * time.Minute, MaxItems: 3})
+	if err != nil {
+		t.Fatalf("NewDigestUseCase() error = %v", err)
+	}
+
+	now := testNow
+	digest.now = func() time.Time { return now }
+
+	return digest, &now
+}
+
+func TestDigestPolicy_Validate(t *testing.T) {
+	for _, policy := range []DigestPolicy{
+		{Priority: 2, Window: time.Minute, MaxItems: 5},
+		{Priority: -3, Window: time.Minute, MaxItems: 5},
+		{Priority: 0, MaxItems: 5},
+		{Priority: 0, Window: time.Minute, MaxItems: 1},
+	} {
+		if err := policy.Validate(); !errors.Is(err, ErrInvalidDigest) {
+			t.Fatalf("Validate(%+v) error = %v, want %v", policy, err, ErrInvalidDigest)
+		}
+	}
+}
+
+func TestDigest_CollectsLowPriority(t *testing.T) {
+	low := -1
+	sender := &partsSender{}
+	digest, _ := newTestDigest(t, sender)
+
+	result, err := digest.Execute(t.Context(), domain.Notification{Message: testMessage, Priority: &low})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if len(sender.sent) != 0 {
+		t.Fatalf("sent %d notifications, want it collected", len(sender.sent))
+	}
+
+	want := domain.DigestOutcome{FlushAt: testNow.Add(10 * time.Minute), Items: 1}
+	if result.Digest == nil || *result.Digest != want || result.Request != "" {
+		t.Fatalf("result = %+v, want digest outcome %+v", result, want)
+	}
+}
+
+func TestDigest_RejectsInvalidBeforeCollecting(t *testing.T) {
+	sender := &partsSender{}
+	digest, _ := newTestDigest(t, sender)
+
+	if _, err := digest.Execute(t.Context(), domain.Notification{Message: " "}); !errors.Is(err, ErrMessageRequired) {
+		t.Fatalf("Execute() error = %v, want %v", err, ErrMessageRequired)
+	}
+
+	if items := len(digest.buffer(digestKey{}).items); items != 0 {
+		t.Fatalf("%d items collected, want 0", items)
+	}
+}
+
+func TestDigest_MaxItemsSendsCombined(t *testing.T) {
+	low := -1
+	sender := &partsSender{}
+	digest, _ := newTestDigest(t, sender)
+
+	messages := []domain.Notification{
+		{Message: "build started", Priority: &low},
+		{Message: "tests **passed**", Markdown: true},
+		{Message: "deployed", Title: "CI"},
+	}
+
+	var (
+		result domain.SendResult
+		err    error
+	)
+
+	for _, n := range messages {
+		if result, err = digest.Execute(t.Context(), n); err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+	}
+
+	if len(sender.sent) != 1 {
+		t.Fatalf("sent %d notifications, want one digest", len(sender.sent))
+	}
+
+	sent := sender.sent[0]
+	want := "3 notifications (2 at priority 0, 1 at priority -1). Latest first:\n" +
+		"• CI: deployed\n• tests passed\n• build started"
+
+	if sent.Message != want || sent.Title != "Digest: 3 notifications" {
+		t.Fatalf("digest = %q / %q, want %q", sent.Title, sent.Message, want)
+	}
+
+	if sent.Priority == nil || *sent.Priority != 0 {
+		t.Fatalf("digest priority = %v, want the highest of the items", sent.Priority)
+	}
+
+	if result.Request == "" || result.Digest == nil || result.Digest.Items != 3 || !result.Digest.FlushAt.IsZero() {
+		t.Fatalf("result = %+v, want the digest's request and 3 items", result)
+	}
+}
+
+func TestDigest_HigherPriorityFlushesFirst(t *testing.T) {
+	low, high := -1, 1
+	sender := &partsSender{}
+	digest, _ := newTestDigest(t, sender)
+
+	for _, n := range []domain.Notification{
+		{Message: "one", Priority: &low},
+		{Message: "two", Priority: &low},
+		{Message: "urgent", Priority: &high},
+	} {
+		if _, err := digest.Execute(t.Context(), n); err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+	}
+
+	if len(sender.sent) != 2 {
+		t.Fatalf("sent %d notifications, want the digest and the urgent one", len(sender.sent))
+	}
+
+	if !strings.HasPrefix(sender.sent[0].Message, "2 notifications") || sender.sent[1].Message != "urgent" {
+		t.Fatalf("sent %q then %q, want the digest first", sender.sent[0].Message, sender.sent[1].Message)
+	}
+}
+
+func TestDigest_DestinationsAreSeparate(t *testing.T) {
+	high := 1
+	sender := &partsSender{}
+	digest, _ := newTestDigest(t, sender, WithRecipientAliases(map[string]domain.RecipientAlias{
+		"oncall": {Key: "oncall-key", Priority: &high},
+	}))
+
+	if _, err := digest.Execute(t.Context(), domain.Notification{Message: "chatter"}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	// The alias raises the priority above the digest's, and another destination leaves the buffer alone.
+	if _, err := digest.Execute(t.Context(), domain.Notification{Message: "page", User: "oncall"}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if len(sender.sent) != 1 || sender.sent[0].User != "oncall-key" {
+		t.Fatalf("sent %+v, want only the page to oncall", sender.sent)
+	}
+
+	if _, err := digest.ExecuteFanOut(t.Context(), domain.Notification{Message: "all"}, []string{"oncall", "other"}); err != nil {
+		t.Fatalf("ExecuteFanOut() error = %v", err)
+	}
+
+	if items := len(digest.buffer(digestKey{}).items); items != 1 {
+		t.Fatalf("default destination holds %d items, want 1", items)
+	}
+}
+
+func TestDigest_FanOutFlushesRecipients(t *testing.T) {
+	sender := &partsSender{}
+	digest, _ := newTestDigest(t, sender)
+
+	if _, err := digest.Execute(t.Context(), domain.Notification{Message: "chatter", User: "a"}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if _, err := digest.ExecuteFanOut(t.Context(), domain.Notification{Message: "all"}, []string{"a", "b"}); err != nil {
+		t.Fatalf("ExecuteFanOut() error = %v", err)
+	}
+
+	if len(sender.sent) != 2 || sender.sent[0].Message != "chatter" || sender.sent[1].Message != "all" {
+		t.Fatalf("sent %+v, want the single collected notification as is, then the fan-out", sender.sent)
+	}
+}
+
+func TestDigest_LongDigestIsCut(t *testing.T) {
+	items := make([]domain.Notification, 20)
+	for i := range items {
+		items[i] = domain.Notification{Message: strings.Repeat("x", 200)}
+	}
+
+	combined := combine(items)
+	lines := strings.Split(combined.Message, "\n")
+
+	if got := len([]rune(combined.Message)); got > MaxMessageLength {
+		t.Fatalf("digest has %d characters, Pushover allows %d", got, MaxMessageLength)
+	}
+
+	if last := lines[len(lines)-1]; last != "…and 15 more." {
+		t.Fatalf("last line = %q, want the count of left out items", last)
+	}
+}
+
+// flakySender fails every send with a retryable error until fixed.
+type flakySender struct {
+	sent  []domain.Notification
+	fixed bool
+	mu    sync.Mutex
+}
+
+func (s *flakySender) Send(_ context.Context, notification domain.Notification) (domain.SendResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.fixed {
+		return domain.SendResult{}, &domain.APIError{Kind: domain.ErrUpstreamUnavailable, Status: "503 Service Unavailable"}
+	}
+
+	s.sent = append(s.sent, notification)
+
+	return domain.SendResult{Request: "r"}, nil
+}
+
+// liveContextSender fails sends whose context is already done, as a real request would.
+type liveContextSender struct {
+	flakySender
+}
+
+func (s *liveContextSender) Send(ctx context.Context, notification domain.Notification) (domain.SendResult, error) {
+	if err := ctx.Err(); err != nil {
+		return domain.SendResult{}, err
+	}
+
+	return s.flakySender.Send(ctx, notification)
+}
+
+func TestDigest_RunFlushesOnShutdown(t *testing.T) {
+	sender := &liveContextSender{flakySender{fixed: true}}
+	digest, _ := newTestDigest(t, sender)
+
+	for _, notification := range []domain.Notification{
+		{Message: "one"},
+		{Message: "two"},
+		{Message: "three", User: "u2"},
+	} {
+		if _, err := digest.Execute(t.Context(), notification); err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	// Both windows are still open; stopping the runner sends them anyway.
+	digest.Run(ctx, func(err error) { t.Errorf("report(%v)", err) })
+
+	if len(sender.sent) != 2 {
+		t.Fatalf("sent %+v, want both pending digests", sender.sent)
+	}
+
+	if pending := digest.flushDue(t.Context(), func(error) {}); pending != digestIdle {
+		t.Fatalf("flushDue() = %v, want nothing left pending", pending)
+	}
+}
+
+func TestDigest_RunFlushesAfterWindow(t *testing.T) {
+	sender := &flakySender{}
+	digest, now := newTestDigest(t, sender)
+
+	for _, message := range []string{"one", "two"} {
+		if _, err := digest.Execute(t.Context(), domain.Notification{Message: message}); err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+	}
+
+	var reported []error
+	report := func(err error) { reported = append(reported, err) }
+
+	if wait := digest.flushDue(t.Context(), report); wait != 10*time.Minute {
+		t.Fatalf("flushDue() = %v, want the rest of the window", wait)
+	}
+
+	*now = now.Add(10 * time.Minute)
+
+	if wait := digest.flushDue(t.Context(), report); wait != 10*time.Minute || len(reported) != 1 {
+		t.Fatalf("flushDue() = %v with %v reported, want a retry after another window", wait, reported)
+	}
+
+	sender.fixed = true
+	*now = now.Add(10 * time.Minute)
+
+	if wait := digest.flushDue(t.Context(), report); wait != digestIdle {
+		t.Fatalf("flushDue() = %v, want idle", wait)
+	}
+
+	if len(sender.sent) != 1 || !strings.HasPrefix(sender.sent[0].Message, "2 notifications") {
+		t.Fatalf("sent %+v, want the kept digest", sender.sent)
+	}
+}
+
+func TestDigest_RepeatIsCollectedOnce(t *testing.T) {
+	tests := []struct {
+		name   string
+		window time.Duration
+		keyTTL time.Duration
+		repeat domain.Notification
+	}{
+		{name: "idempotency key", keyTTL: time.Hour, repeat: domain.Notification{Message: "one", IdempotencyKey: "k"}},
+		{name: "content window", window: time.Minute, repeat: domain.Notification{Message: "one"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			sender := &flakySender{fixed: true}
+			digest, _ := newTestDigest(t, sender)
+			dedup := NewDedupExecutor(digest, tc.window, tc.keyTTL)
+
+			first, err := dedup.Execute(t.Context(), tc.repeat)
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+
+			second, err := dedup.Execute(t.Context(), tc.repeat)
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+
+			if !second.Duplicate || second.Digest == nil || second.Digest.Items != first.Digest.Items {
+				t.Fatalf("second result = %+v, want duplicate of %+v", second, first)
+			}
+
+			if _, err := dedup.Execute(t.Context(), domain.Notification{Message: "two"}); err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+
+			digest.flushAll(t.Context(), func(err error) { t.Errorf("report(%v)", err) })
+
+			if len(sender.sent) != 1 || !strings.HasPrefix(sender.sent[0].Title, "Digest: 2 notifications") {
+				t.Fatalf("sent %+v, want one digest of the two distinct notifications", sender.sent)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+// MaxRecipients is the most user keys Pushover accepts in one comma-separated list.
+const MaxRecipients = 50
+
+var (
+	ErrRecipientsRequired = errors.New("at least one recipient is required")
+	ErrTooManyRecipients  = fmt.Errorf("at most %d recipients are allowed", MaxRecipients)
+	ErrRecipientWithComma = errors.New("recipients must not contain commas; pass each key separately")
+)
+
+// RecipientResult is the outcome of a fan-out send for one recipient.
+type RecipientResult struct {
+	Err       error
+	Recipient string
+	Result    domain.SendResult
+}
+
+// batch is a set of recipients that receive exactly the same notification.
+type batch struct {
+	notification domain.Notification
+	recipients   []string
+	keys         []string
+}
+
+// ExecuteFanOut sends the notification to every recipient, a user or group key or an alias name.
+// Recipients whose alias defaults lead to the same notification go out as one comma-separated list;
+// when Pushover rejects a key in a list, each of its recipients is sent separately so that one bad
+// key does not hide the others. Validation errors are returned as the error, delivery failures per recipient.
+func (u *SendNotificationUseCase) ExecuteFanOut(
+	ctx context.Context,
+	notification domain.Notification,
+	recipients []string,
+) ([]RecipientResult, error) {
+	names, err := normalizeRecipients(recipients)
+	if err != nil {
+		return nil, err
+	}
+
+	notification, removed := sanitize(renderMarkdown(notification))
+	batches := u.batches(notification, names)
+
+	// Validate everything first so that an invalid argument does not leave a partial send behind.
+	for _, b := range batches {
+		if err := u.validate(ctx, b.notification); err != nil {
+			return nil, err
+		}
+	}
+
+	byRecipient := make(map[string]RecipientResult, len(names))
+
+	for _, b := range batches {
+		for _, result := range u.sendBatch(ctx, b) {
+			byRecipient[result.Recipient] = result
+		}
+	}
+
+	results := make([]RecipientResult, 0, len(names))
+
+	for _, name := range names {
+		result := byRecipient[name]
+		if result.Err == nil {
+			result.Result.Sanitized = removed
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// batches groups the recipients by the notification they get, keeping the order of first appearance.
+func (u *SendNotificationUseCase) batches(notification domain.Notification, names []string) []*batch {
+	var ordered []*batch
+
+	byKey := map[string]*batch{}
+
+	for _, name := range names {
+		addressed := u.addressTo(notification, name)
+		id := batchKey(addressed)
+
+		b, ok := byKey[id]
+		if !ok {
+			b = &batch{notification: addressed}
+			byKey[id] = b
+			ordered = append(ordered, b)
+		}
+
+		b.recipients = append(b.recipients, name)
+		b.keys = append(b.keys, addressed.User)
+	}
+
+	for _, b := range ordered {
+		b.notification.User = strings.Join(b.keys, ",")
+	}
+
+	return ordered
+}
+
+// batchKey identifies the fields alias defaults can change.
+func batchKey(notification domain.Notification) string {
+	priority := "none"
+	if notification.Priority != nil {
+		priority = strconv.Itoa(*notification.Priority)
+	}
+
+	return strings.Join([]string{notification.Device, notification.Sound, priority}, "\x00")
+}
+
+func (u *SendNotificationUseCase) sendBatch(ctx context.Context, b *batch) []RecipientResult {
+	result, err := u.deliver(ctx, b.notification)
+	if len(b.keys) > 1 && errors.Is(err, domain.ErrInvalidRecipient) {
+		return u.sendEach(ctx, b)
+	}
+
+	results := make([]RecipientResult, 0, len(b.recipients))
+	for _, recipient := range b.recipients {
+		results = append(results, newRecipientResult(recipient, result, err))
+	}
+
+	return results
+}
+
+func (u *SendNotificationUseCase) sendEach(ctx context.Context, b *batch) []RecipientResult {
+	notification := b.notification
+	results := make([]RecipientResult, 0, len(b.recipients))
+
+	for i, recipient := range b.recipients {
+		notification.User = b.keys[i]
+		result, err := u.deliver(ctx, notification)
+		results = append(results, newRecipientResult(recipient, result, err))
+	}
+
+	return results
+}
+
+func newRecipientResult(recipient string, result domain.SendResult, err error) RecipientResult {
+	if err != nil {
+		return RecipientResult{Recipient: recipient, Err: fmt.Errorf("send notification: %w", err)}
+	}
+
+	return RecipientResult{Recipient: recipient, Result: result}
+}
+
+// normalizeRecipients trims the recipients and drops blanks and duplicates, keeping the order.
+func normalizeRecipients(recipients []string) ([]string, error) {
+	names := make([]string, 0, len(recipients))
+	seen := make(map[string]bool, len(recipients))
+
+	for _, recipient := range recipients {
+		name := strings.TrimSpace(recipient)
+		if name == "" || seen[name] {
+			continue
+		}
+
+		if strings.Contains(name, ",") {
+			return nil, ErrRecipientWithComma
+		}
+
+		seen[name] = true
+		names = append(names, name)
+	}
+
+	switch {
+	case len(names) == 0:
+		return nil, ErrRecipientsRequired
+	case len(names) > MaxRecipients:
+		return nil, ErrTooManyRecipients
+	}
+
+	return names, nil
+}
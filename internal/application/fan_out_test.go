@@ -0,0 +1,228 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+// recipientSender fails for the keys in failing and records every user list it was asked to send to.
+type recipientSender struct {
+	failing map[string]error
+	users   []string
+}
+
+func (f *recipientSender) Send(_ context.Context, notification domain.Notification) (domain.SendResult, error) {
+	f.users = append(f.users, notification.User)
+
+	for key, err := range f.failing {
+		if slices.Contains(strings.Split(notification.User, ","), key) {
+			return domain.SendResult{}, err
+		}
+	}
+
+	return domain.SendResult{Request: "req-" + notification.User}, nil
+}
+
+var errInvalidUser = &domain.APIError{Kind: domain.ErrInvalidRecipient, Status: "400 Bad Request"}
+
+func TestExecuteFanOut_SendsOneCommaSeparatedRequest(t *testing.T) {
+	sender := &recipientSender{}
+	useCase := NewSendNotificationUseCase(sender)
+
+	results, err := useCase.ExecuteFanOut(context.Background(), domain.Notification{Message: testMessage},
+		[]string{" user-a ", "group-b", "user-a", ""})
+	if err != nil {
+		t.Fatalf("ExecuteFanOut() error = %v", err)
+	}
+
+	if len(sender.users) != 1 || sender.users[0] != "user-a,group-b" {
+		t.Fatalf("sent to %q, want one request to user-a,group-b", sender.users)
+	}
+
+	if len(results) != 2 || results[0].Recipient != "user-a" || results[1].Recipient != "group-b" {
+		t.Fatalf("results = %+v", results)
+	}
+
+	for _, result := range results {
+		if result.Err != nil || result.Result.Request != "req-user-a,group-b" {
+			t.Fatalf("result = %+v", result)
+		}
+	}
+}
+
+func TestExecuteFanOut_IsolatesInvalidRecipient(t *testing.T) {
+	sender := &recipientSender{failing: map[string]error{"bad": errInvalidUser}}
+	useCase := NewSendNotificationUseCase(sender)
+
+	results, err := useCase.ExecuteFanOut(context.Background(), domain.Notification{Message: testMessage},
+		[]string{"oncall", "bad", "lead"})
+	if err != nil {
+		t.Fatalf("ExecuteFanOut() error = %v", err)
+	}
+
+	want := []string{"oncall,bad,lead", "oncall", "bad", "lead"}
+	if len(sender.users) != len(want) {
+		t.Fatalf("sent to %q, want %q", sender.users, want)
+	}
+
+	for i := range want {
+		assertString(t, sender.users[i], want[i], "user")
+	}
+
+	if results[0].Err != nil || results[2].Err != nil {
+		t.Fatalf("valid recipients failed: %+v", results)
+	}
+
+	if !errors.Is(results[1].Err, domain.ErrInvalidRecipient) {
+		t.Fatalf("bad recipient error = %v, want %v", results[1].Err, domain.ErrInvalidRecipient)
+	}
+}
+
+func TestExecuteFanOut_SharedFailureReportedForAll(t *testing.T) {
+	outage := &domain.APIError{Kind: domain.ErrUpstreamUnavailable, Status: "503 Service Unavailable"}
+	sender := &recipientSender{failing: map[string]error{"user-a": outage}}
+	useCase := NewSendNotificationUseCase(sender)
+
+	results, err := useCase.ExecuteFanOut(context.Background(), domain.Notification{Message: testMessage},
+		[]string{"user-a", "user-b"})
+	if err != nil {
+		t.Fatalf("ExecuteFanOut() error = %v", err)
+	}
+
+	if len(sender.users) != 1 {
+		t.Fatalf("sent to %q, want a single request", sender.users)
+	}
+
+	for _, result := range results {
+		if !errors.Is(result.Err, domain.ErrUpstreamUnavailable) {
+			t.Fatalf("result = %+v, want outage error", result)
+		}
+	}
+}
+
+func TestExecuteFanOut_Validation(t *testing.T) {
+	tooMany := make([]string, MaxRecipients+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("user-%d", i)
+	}
+
+	valid := domain.Notification{Message: testMessage}
+
+	tests := []struct {
+		want         error
+		name         string
+		recipients   []string
+		notification domain.Notification
+	}{
+		{name: "no recipients", recipients: []string{" ", ""}, notification: valid, want: ErrRecipientsRequired},
+		{name: "comma", recipients: []string{"a,b"}, notification: valid, want: ErrRecipientWithComma},
+		{name: "too many", recipients: tooMany, notification: valid, want: ErrTooManyRecipients},
+		{name: "invalid notification", recipients: []string{"user-a"}, notification: domain.Notification{}, want: ErrMessageRequired},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			sender := &recipientSender{}
+			useCase := NewSendNotificationUseCase(sender)
+
+			_, err := useCase.ExecuteFanOut(context.Background(), tc.notification, tc.recipients)
+			if !errors.Is(err, tc.want) {
+				t.Fatalf("error = %v, want %v", err, tc.want)
+			}
+
+			if len(sender.users) != 0 {
+				t.Fatalf("sent to %q, want nothing sent", sender.users)
+			}
+		})
+	}
+}
+
+func TestExecuteFanOut_SkipsDeviceCatalogForOtherRecipients(t *testing.T) {
+	sender := &recipientSender{}
+	useCase := NewSendNotificationUseCase(sender, WithDeviceCatalog(&fakeDeviceCatalog{devices: []string{"phone"}}))
+
+	_, err := useCase.ExecuteFanOut(context.Background(), domain.Notification{Message: testMessage, Device: "tablet"},
+		[]string{"user-a"})
+	if err != nil {
+		t.Fatalf("ExecuteFanOut() error = %v", err)
+	}
+}
+
+func testAliases() map[string]domain.RecipientAlias {
+	urgent := 1
+
+	return map[string]domain.RecipientAlias{
+		"oncall": {Key: "user-oncall", Device: "phone", Sound: "siren", Priority: &urgent},
+		"me":     {Key: "user-me"},
+		"team":   {Key: "group-team"},
+	}
+}
+
+func TestExecute_RecipientAlias(t *testing.T) {
+	sender := &fakeSender{}
+	useCase := NewSendNotificationUseCase(sender, WithRecipientAliases(testAliases()))
+
+	_, err := useCase.Execute(context.Background(), domain.Notification{Message: testMessage, User: "oncall"})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	assertString(t, sender.notification.User, "user-oncall", "User")
+	assertString(t, sender.notification.Device, "phone", "Device")
+	assertString(t, sender.notification.Sound, "siren", "Sound")
+	assertIntPtr(t, sender.notification.Priority, 1, "Priority")
+}
+
+func TestExecute_RecipientAliasDefaultsDoNotOverride(t *testing.T) {
+	sender := &fakeSender{}
+	useCase := NewSendNotificationUseCase(sender, WithRecipientAliases(testAliases()))
+	low := -1
+
+	_, err := useCase.Execute(context.Background(), domain.Notification{
+		Message:  testMessage,
+		User:     "oncall",
+		Sound:    "none",
+		Device:   "tablet",
+		Priority: &low,
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	assertString(t, sender.notification.Sound, "none", "Sound")
+	assertString(t, sender.notification.Device, "tablet", "Device")
+	assertIntPtr(t, sender.notification.Priority, -1, "Priority")
+}
+
+func TestExecuteFanOut_BatchesByAliasDefaults(t *testing.T) {
+	sender := &recipientSender{}
+	useCase := NewSendNotificationUseCase(sender, WithRecipientAliases(testAliases()))
+
+	results, err := useCase.ExecuteFanOut(context.Background(), domain.Notification{Message: testMessage},
+		[]string{"me", "oncall", "team", "user-raw"})
+	if err != nil {
+		t.Fatalf("ExecuteFanOut() error = %v", err)
+	}
+
+	want := []string{"user-me,group-team,user-raw", "user-oncall"}
+	if !slices.Equal(sender.users, want) {
+		t.Fatalf("sent to %q, want %q", sender.users, want)
+	}
+
+	names := make([]string, 0, len(results))
+	for _, result := range results {
+		names = append(names, result.Recipient)
+	}
+
+	if !slices.Equal(names, []string{"me", "oncall", "team", "user-raw"}) {
+		t.Fatalf("results for %q, want input order", names)
+	}
+
+	assertString(t, results[1].Result.Request, "req-user-oncall", "oncall request")
+}
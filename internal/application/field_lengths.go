@@ -0,0 +1,444 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+// Pushover's field length limits, in characters.
+const (
+	MaxMessageLength  = 1024
+	MaxTitleLength    = 250
+	MaxURLLength      = 512
+	MaxURLTitleLength = 100
+)
+
+// LengthPolicy decides what happens to a message, title or URL title over Pushover's limits.
+// A URL over its limit is always rejected, since a shortened URL points somewhere else.
+type LengthPolicy string
+
+const (
+	// LengthReject fails the send with ErrFieldTooLong.
+	LengthReject LengthPolicy = "reject"
+	// LengthTruncate cuts the field and marks the cut with an ellipsis.
+	LengthTruncate LengthPolicy = "truncate"
+	// LengthSplit sends a long message as numbered parts in order; other fields are truncated.
+	LengthSplit LengthPolicy = "split"
+)
+
+const ellipsis = "…"
+
+var (
+	ErrFieldTooLong        = errors.New("field too long")
+	ErrInvalidLengthPolicy = errors.New("length policy must be reject, truncate or split")
+)
+
+// ParseLengthPolicy validates a configured policy name.
+func ParseLengthPolicy(value string) (LengthPolicy, error) {
+	switch policy := LengthPolicy(strings.ToLower(strings.TrimSpace(value))); policy {
+	case LengthReject, LengthTruncate, LengthSplit:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("%w, got %q", ErrInvalidLengthPolicy, value)
+	}
+}
+
+// shortens reports whether over-long fields are fixed up instead of rejected.
+func (p LengthPolicy) shortens() bool {
+	return p == LengthTruncate || p == LengthSplit
+}
+
+// WithLengthPolicy sets how fields over Pushover's limits are handled; the default is LengthReject.
+func WithLengthPolicy(policy LengthPolicy) Option {
+	return func(u *SendNotificationUseCase) {
+		u.lengths = policy
+	}
+}
+
+type fieldLimit struct {
+	name  string
+	value string
+	limit int
+}
+
+func (u *SendNotificationUseCase) validateLengths(notification domain.Notification) error {
+	if err := checkLength(fieldLimit{"url", notification.URL, MaxURLLength}); err != nil {
+		return err
+	}
+
+	if u.lengths.shortens() {
+		return nil
+	}
+
+	for _, field := range []fieldLimit{
+		{"message", notification.Message, MaxMessageLength},
+		{"title", notification.Title, MaxTitleLength},
+		{"url_title", notification.URLTitle, MaxURLTitleLength},
+	} {
+		if err := checkLength(field); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func checkLength(field fieldLimit) error {
+	if n := utf8.RuneCountInString(field.value); n > field.limit {
+		return fmt.Errorf("%w: %s has %d characters, Pushover allows %d", ErrFieldTooLong, field.name, n, field.limit)
+	}
+
+	return nil
+}
+
+// deliver sends a validated notification, shortening or splitting it according to the length policy.
+func (u *SendNotificationUseCase) deliver(ctx context.Context, notification domain.Notification) (domain.SendResult, error) {
+	sender := u.senderFor(notification)
+
+	var truncated []string
+
+	if u.lengths.shortens() {
+		shorten := func(name string, value *string, limit int, cut func(string, int) (string, bool)) {
+			if shortened, ok := cut(*value, limit); ok {
+				*value = shortened
+				truncated = append(truncated, name)
+			}
+		}
+
+		shorten("title", &notification.Title, MaxTitleLength, truncate)
+		shorten("url_title", &notification.URLTitle, MaxURLTitleLength, truncate)
+
+		if u.lengths == LengthTruncate {
+			cut := truncate
+			if notification.HTML {
+				cut = truncateHTML
+			}
+
+			shorten("message", &notification.Message, MaxMessageLength, cut)
+		}
+	}
+
+	parts, err := u.split(notification)
+	if err != nil {
+		return domain.SendResult{}, err
+	}
+
+	if err := chargeRate(ctx, len(parts)-1); err != nil {
+		return domain.SendResult{}, err
+	}
+
+	var first domain.SendResult
+
+	for i, part := range parts {
+		notification.Message = part
+
+		result, err := sender.Send(ctx, notification)
+		if err != nil {
+			if len(parts) > 1 {
+				return domain.SendResult{}, fmt.Errorf("send part %d/%d: %w", i+1, len(parts), err)
+			}
+
+			return domain.SendResult{}, err
+		}
+
+		if i == 0 {
+			first = result
+		}
+
+		// Only the first part carries the attachment.
+		notification.Attachment = nil
+		first.RateLimit = result.RateLimit
+		// A retried split message resends only the parts that did not go out before.
+		first.Duplicate = first.Duplicate && result.Duplicate
+	}
+
+	first.Truncated = truncated
+	if len(parts) > 1 {
+		first.Parts = len(parts)
+	}
+
+	return first, nil
+}
+
+// split returns the parts the message goes out as: more than one only under LengthSplit.
+func (u *SendNotificationUseCase) split(notification domain.Notification) ([]string, error) {
+	switch {
+	case u.lengths != LengthSplit:
+		return []string{notification.Message}, nil
+	case notification.HTML:
+		return splitHTML(notification.Message, MaxMessageLength)
+	default:
+		return splitMessage(notification.Message, MaxMessageLength), nil
+	}
+}
+
+// chargeParts takes the parts after the first of a notification that is sent later from the
+// charge set with WithRateCharge, so that scheduling it costs as much as sending it.
+func (u *SendNotificationUseCase) chargeParts(ctx context.Context, notification domain.Notification) error {
+	notification, _ = u.prepare(notification)
+
+	parts, err := u.split(notification)
+	if err != nil {
+		return err
+	}
+
+	return chargeRate(ctx, len(parts)-1)
+}
+
+// truncate cuts value to limit characters, the last of them being the ellipsis.
+func truncate(value string, limit int) (string, bool) {
+	if utf8.RuneCountInString(value) <= limit {
+		return value, false
+	}
+
+	runes := []rune(value)
+
+	return strings.TrimRightFunc(string(runes[:limit-1]), unicode.IsSpace) + ellipsis, true
+}
+
+// splitMessage cuts message into parts of at most limit characters, each prefixed with "(i/n) ".
+// Cuts prefer whitespace in the second half of a part over breaking a word.
+func splitMessage(message string, limit int) []string {
+	if utf8.RuneCountInString(message) <= limit {
+		return []string{message}
+	}
+
+	runes := []rune(message)
+
+	parts, _ := numberParts(limit, func(size int) ([]string, error) {
+		return chunkRunes(runes, size), nil
+	})
+
+	return parts
+}
+
+// splitHTML is splitMessage for an HTML message. It never cuts inside a tag or an entity, and
+// closes the elements open at the end of a part and opens them again at the start of the next.
+func splitHTML(message string, limit int) ([]string, error) {
+	if utf8.RuneCountInString(message) <= limit {
+		return []string{message}, nil
+	}
+
+	tokens := tokenizeHTML(message)
+
+	return numberParts(limit, func(size int) ([]string, error) {
+		return chunkHTML(tokens, size)
+	})
+}
+
+// numberParts chunks a message into parts that fit limit with their "(i/n) " prefix.
+func numberParts(limit int, chunk func(size int) ([]string, error)) ([]string, error) {
+	// The prefix grows with the number of parts, so retry until the digits fit.
+	for digits := 1; ; digits++ {
+		chunks, err := chunk(limit - len("(/) ") - 2*digits)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(strconv.Itoa(len(chunks))) > digits {
+			continue
+		}
+
+		parts := make([]string, 0, len(chunks))
+		for i, chunk := range chunks {
+			parts = append(parts, fmt.Sprintf("(%d/%d) %s", i+1, len(chunks), chunk))
+		}
+
+		return parts, nil
+	}
+}
+
+func chunkRunes(runes []rune, size int) []string {
+	var chunks []string
+
+	for len(runes) > 0 {
+		if len(runes) <= size {
+			chunks = append(chunks, string(runes))
+			break
+		}
+
+		cut := size
+		for i := size; i > size/2; i-- {
+			if unicode.IsSpace(runes[i]) {
+				cut = i
+				break
+			}
+		}
+
+		chunks = append(chunks, strings.TrimRightFunc(string(runes[:cut]), unicode.IsSpace))
+		runes = []rune(strings.TrimLeftFunc(string(runes[cut:]), unicode.IsSpace))
+	}
+
+	return chunks
+}
+
+// htmlTokenPattern matches the pieces of a sanitized HTML message that a cut must keep whole.
+var htmlTokenPattern = regexp.MustCompile(`^(?:<(/?)([a-zA-Z][a-zA-Z0-9]*)[^>]*>|&#?[0-9a-zA-Z]+;)`)
+
+// htmlToken is a tag, an entity or a single character of an HTML message.
+type htmlToken struct {
+	text string
+	// name is the element a tag opens or closes; empty for text.
+	name    string
+	closing bool
+}
+
+func tokenizeHTML(message string) []htmlToken {
+	var tokens []htmlToken
+
+	for message != "" {
+		if m := htmlTokenPattern.FindStringSubmatch(message); m != nil {
+			tokens = append(tokens, htmlToken{text: m[0], name: strings.ToLower(m[2]), closing: m[1] == "/"})
+			message = message[len(m[0]):]
+
+			continue
+		}
+
+		_, size := utf8.DecodeRuneInString(message)
+		tokens = append(tokens, htmlToken{text: message[:size]})
+		message = message[size:]
+	}
+
+	return tokens
+}
+
+func (t htmlToken) length() int {
+	return utf8.RuneCountInString(t.text)
+}
+
+func (t htmlToken) isSpace() bool {
+	r, _ := utf8.DecodeRuneInString(t.text)
+
+	return t.name == "" && unicode.IsSpace(r)
+}
+
+// apply returns the elements still open after the token; open itself is left as it is.
+// A closing tag without a matching opening one changes nothing.
+func (t htmlToken) apply(open []htmlToken) []htmlToken {
+	switch {
+	case t.name == "":
+		return open
+	case !t.closing:
+		return append(slices.Clip(open), t)
+	}
+
+	for i := len(open) - 1; i >= 0; i-- {
+		if open[i].name == t.name {
+			return slices.Delete(slices.Clone(open), i, i+1)
+		}
+	}
+
+	return open
+}
+
+func joinTokens(tokens []htmlToken) string {
+	var b strings.Builder
+
+	for _, token := range tokens {
+		b.WriteString(token.text)
+	}
+
+	return b.String()
+}
+
+// closingTags closes the open elements, innermost first.
+func closingTags(open []htmlToken) string {
+	var b strings.Builder
+
+	for _, tag := range slices.Backward(open) {
+		b.WriteString("</" + tag.name + ">")
+	}
+
+	return b.String()
+}
+
+// truncateHTML is truncate for an HTML message: it cuts between tags and entities and closes
+// the elements still open after the ellipsis.
+func truncateHTML(value string, limit int) (string, bool) {
+	if utf8.RuneCountInString(value) <= limit {
+		return value, false
+	}
+
+	var (
+		open   []htmlToken
+		kept   strings.Builder
+		length int
+	)
+
+	for _, token := range tokenizeHTML(value) {
+		next := token.apply(open)
+		if length+token.length()+utf8.RuneCountInString(ellipsis)+utf8.RuneCountInString(closingTags(next)) > limit {
+			break
+		}
+
+		kept.WriteString(token.text)
+		length += token.length()
+		open = next
+	}
+
+	return strings.TrimRightFunc(kept.String(), unicode.IsSpace) + ellipsis + closingTags(open), true
+}
+
+// chunkHTML is chunkRunes for an HTML message. Each chunk opens the elements left open by the
+// one before and closes those open at its end; a tag too long for any chunk fails the split.
+func chunkHTML(tokens []htmlToken, size int) ([]string, error) {
+	var (
+		chunks []string
+		open   []htmlToken
+	)
+
+	for len(tokens) > 0 {
+		start := joinTokens(open)
+		length := utf8.RuneCountInString(start)
+		stack := open
+		end, cut := 0, -1
+
+		var cutOpen []htmlToken
+
+		for ; end < len(tokens); end++ {
+			next := tokens[end].apply(stack)
+			if length+tokens[end].length()+utf8.RuneCountInString(closingTags(next)) > size {
+				break
+			}
+
+			if end > 0 && tokens[end].isSpace() && length > size/2 {
+				cut, cutOpen = end, stack
+			}
+
+			length += tokens[end].length()
+			stack = next
+		}
+
+		if end == len(tokens) {
+			chunks = append(chunks, start+joinTokens(tokens)+closingTags(stack))
+			break
+		}
+
+		if end == 0 {
+			return nil, fmt.Errorf("%w: message has HTML that does not fit in a part of %d characters", ErrFieldTooLong, size)
+		}
+
+		if cut < 0 {
+			cut, cutOpen = end, stack
+		}
+
+		text := strings.TrimRightFunc(joinTokens(tokens[:cut]), unicode.IsSpace)
+		chunks = append(chunks, start+text+closingTags(cutOpen))
+
+		tokens, open = tokens[cut:], cutOpen
+		for len(tokens) > 0 && tokens[0].isSpace() {
+			tokens = tokens[1:]
+		}
+	}
+
+	return chunks, nil
+}
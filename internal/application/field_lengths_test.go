@@ -0,0 +1,334 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+// partsSender records every message it sends and fails on the configured call.
+type partsSender struct {
+	err      error
+	sent     []domain.Notification
+	failCall int
+}
+
+func (f *partsSender) Send(_ context.Context, notification domain.Notification) (domain.SendResult, error) {
+	f.sent = append(f.sent, notification)
+	if len(f.sent) == f.failCall {
+		return domain.SendResult{}, f.err
+	}
+
+	return domain.SendResult{Request: strings.Repeat("r", len(f.sent))}, nil
+}
+
+func TestExecute_LengthReject(t *testing.T) {
+	tests := []struct {
+		name         string
+		notification domain.Notification
+		want         string
+	}{
+		{
+			name:         "message",
+			notification: domain.Notification{Message: strings.Repeat("a", MaxMessageLength+1)},
+			want:         "message has 1025 characters, Pushover allows 1024",
+		},
+		{
+			name:         "title",
+			notification: domain.Notification{Message: testMessage, Title: strings.Repeat("t", MaxTitleLength+1)},
+			want:         "title has 251 characters",
+		},
+		{
+			name:         "url title",
+			notification: domain.Notification{Message: testMessage, URLTitle: strings.Repeat("u", MaxURLTitleLength+1)},
+			want:         "url_title has 101 characters",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			sender, useCase := newUseCaseWithFake()
+
+			_, err := useCase.Execute(context.Background(), tc.notification)
+			assertValidationError(t, sender, err, ErrFieldTooLong)
+
+			if !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("error = %v, want %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestExecute_LengthCountsCharactersNotBytes(t *testing.T) {
+	sender, useCase := newUseCaseWithFake()
+
+	_, err := useCase.Execute(context.Background(), domain.Notification{Message: strings.Repeat("ä", MaxMessageLength)})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if !sender.called {
+		t.Fatal("sender was not called")
+	}
+}
+
+func TestExecute_LongURLAlwaysRejected(t *testing.T) {
+	for _, policy := range []LengthPolicy{LengthReject, LengthTruncate, LengthSplit} {
+		sender := &fakeSender{}
+		useCase := NewSendNotificationUseCase(sender, WithLengthPolicy(policy))
+
+		_, err := useCase.Execute(context.Background(), domain.Notification{
+			Message: testMessage,
+			URL:     "https://example.com/" + strings.Repeat("p", MaxURLLength),
+		})
+		assertValidationError(t, sender, err, ErrFieldTooLong)
+	}
+}
+
+func TestExecute_LengthTruncate(t *testing.T) {
+	sender := &fakeSender{}
+	useCase := NewSendNotificationUseCase(sender, WithLengthPolicy(LengthTruncate))
+
+	result, err := useCase.Execute(context.Background(), domain.Notification{
+		Message:  strings.Repeat("m", MaxMessageLength+10),
+		Title:    strings.Repeat("t", MaxTitleLength+10),
+		URLTitle: "short",
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	sent := sender.notification
+	if utf8.RuneCountInString(sent.Message) != MaxMessageLength || !strings.HasSuffix(sent.Message, ellipsis) {
+		t.Fatalf("message has %d characters, want %d ending in %q",
+			utf8.RuneCountInString(sent.Message), MaxMessageLength, ellipsis)
+	}
+
+	if utf8.RuneCountInString(sent.Title) != MaxTitleLength {
+		t.Fatalf("title has %d characters, want %d", utf8.RuneCountInString(sent.Title), MaxTitleLength)
+	}
+
+	assertString(t, sent.URLTitle, "short", "URLTitle")
+
+	if strings.Join(result.Truncated, ",") != "title,message" {
+		t.Fatalf("Truncated = %v, want [title message]", result.Truncated)
+	}
+}
+
+func TestExecute_LengthSplit(t *testing.T) {
+	sender := &partsSender{}
+	useCase := NewSendNotificationUseCase(sender, WithLengthPolicy(LengthSplit))
+	words := strings.Repeat("lorem ipsum dolor ", 150)
+
+	result, err := useCase.Execute(context.Background(), domain.Notification{
+		Message:    words,
+		Title:      "Build log",
+		Attachment: &domain.Attachment{Filename: "a.png", MIMEType: "image/png", Data: testPNG},
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if len(sender.sent) != 3 || result.Parts != 3 || result.Request != "r" {
+		t.Fatalf("sent %d parts, result = %+v, want 3 parts and the first request", len(sender.sent), result)
+	}
+
+	var joined []string
+
+	for i, part := range sender.sent {
+		prefix := "(" + string(rune('1'+i)) + "/3) "
+		if !strings.HasPrefix(part.Message, prefix) {
+			t.Fatalf("part %d = %q, want prefix %q", i+1, part.Message[:10], prefix)
+		}
+
+		if n := utf8.RuneCountInString(part.Message); n > MaxMessageLength {
+			t.Fatalf("part %d has %d characters", i+1, n)
+		}
+
+		if (part.Attachment != nil) != (i == 0) {
+			t.Fatalf("part %d attachment = %v, want only on the first part", i+1, part.Attachment)
+		}
+
+		assertString(t, part.Title, "Build log", "Title")
+		joined = append(joined, strings.TrimPrefix(part.Message, prefix))
+	}
+
+	if strings.TrimSpace(strings.Join(joined, " ")) != strings.TrimSpace(words) {
+		t.Fatal("parts do not add up to the message")
+	}
+}
+
+func TestExecute_LengthSplitChargesParts(t *testing.T) {
+	sender := &partsSender{}
+	useCase := NewSendNotificationUseCase(sender, WithLengthPolicy(LengthSplit))
+	message := strings.Repeat("x", 2*MaxMessageLength)
+
+	var charged []int
+
+	ctx := WithRateCharge(t.Context(), func(n int) error {
+		charged = append(charged, n)
+		return nil
+	})
+
+	if _, err := useCase.Execute(ctx, domain.Notification{Message: message}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if len(sender.sent) != 3 || len(charged) != 1 || charged[0] != 2 {
+		t.Fatalf("sent %d parts, charged %v; want 3 parts and the 2 after the first charged", len(sender.sent), charged)
+	}
+
+	sender.sent = nil
+	ctx = WithRateCharge(t.Context(), func(int) error { return ErrSendRateLimited })
+
+	if _, err := useCase.Execute(ctx, domain.Notification{Message: message}); !errors.Is(err, ErrSendRateLimited) {
+		t.Fatalf("error = %v, want %v", err, ErrSendRateLimited)
+	}
+
+	if len(sender.sent) != 0 {
+		t.Fatalf("sent %d parts, want none when the parts are not allowed", len(sender.sent))
+	}
+}
+
+func TestExecute_LengthSplitPartFails(t *testing.T) {
+	sender := &partsSender{failCall: 2, err: errors.New("boom")}
+	useCase := NewSendNotificationUseCase(sender, WithLengthPolicy(LengthSplit))
+
+	_, err := useCase.Execute(context.Background(), domain.Notification{Message: strings.Repeat("x", 2*MaxMessageLength)})
+	if err == nil || !strings.Contains(err.Error(), "send part 2/3: boom") {
+		t.Fatalf("error = %v, want part 2/3 failure", err)
+	}
+
+	if len(sender.sent) != 2 {
+		t.Fatalf("sent %d parts, want to stop after the failure", len(sender.sent))
+	}
+}
+
+func TestExecute_LengthTruncateHTML(t *testing.T) {
+	sender := &fakeSender{}
+	useCase := NewSendNotificationUseCase(sender, WithLengthPolicy(LengthTruncate))
+
+	_, err := useCase.Execute(context.Background(), domain.Notification{
+		Message: "<b>" + strings.Repeat("m", MaxMessageLength) + "</b>",
+		HTML:    true,
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	sent := sender.notification.Message
+	if utf8.RuneCountInString(sent) > MaxMessageLength || !strings.HasPrefix(sent, "<b>m") || !strings.HasSuffix(sent, "m"+ellipsis+"</b>") {
+		t.Fatalf("message = %q...%q, want the bold text cut and closed", sent[:5], sent[len(sent)-10:])
+	}
+}
+
+func TestTruncateHTML_KeepsTagsAndEntitiesWhole(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{
+			name:  "tag",
+			value: strings.Repeat("x", 1020) + `<a href="https://example.com">link</a>`,
+			want:  strings.Repeat("x", 1020) + ellipsis,
+		},
+		{
+			name:  "entity",
+			value: strings.Repeat("x", 1022) + "&amp;&amp;",
+			want:  strings.Repeat("x", 1022) + ellipsis,
+		},
+		{
+			name:  "nested",
+			value: `<font color="red"><i>` + strings.Repeat("y", MaxMessageLength) + "</i></font>",
+			want:  `<font color="red"><i>` + strings.Repeat("y", 991) + ellipsis + "</i></font>",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := truncateHTML(tc.value, MaxMessageLength)
+			if !ok || got != tc.want {
+				t.Fatalf("truncateHTML() = %q, %v, want %q", got, ok, tc.want)
+			}
+		})
+	}
+}
+
+func TestExecute_LengthSplitHTML(t *testing.T) {
+	sender := &partsSender{}
+	useCase := NewSendNotificationUseCase(sender, WithLengthPolicy(LengthSplit))
+	words := strings.Repeat("lorem <i>ipsum</i> &amp; dolor ", 60)
+
+	result, err := useCase.Execute(context.Background(), domain.Notification{
+		Message: `<font color="#ff0000">` + words + `<a href="https://example.com/log">full log</a></font>`,
+		HTML:    true,
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if result.Parts < 2 {
+		t.Fatalf("Parts = %d, want the message split", result.Parts)
+	}
+
+	for i, part := range sender.sent {
+		if n := utf8.RuneCountInString(part.Message); n > MaxMessageLength {
+			t.Fatalf("part %d has %d characters", i+1, n)
+		}
+
+		for _, tag := range []string{"font", "i", "a"} {
+			opened := strings.Count(part.Message, "<"+tag+" ") + strings.Count(part.Message, "<"+tag+">")
+			if closed := strings.Count(part.Message, "</"+tag+">"); opened != closed {
+				t.Fatalf("part %d opens <%s> %d times and closes it %d times: %q", i+1, tag, opened, closed, part.Message)
+			}
+		}
+
+		if !strings.Contains(part.Message, `) <font color="#ff0000">`) {
+			t.Fatalf("part %d = %q, want the font reopened after the prefix", i+1, part.Message[:30])
+		}
+
+		if strings.Contains(part.Message, "&amp </") || strings.HasSuffix(strings.TrimSuffix(part.Message, "</font>"), "&am") {
+			t.Fatalf("part %d cuts an entity", i+1)
+		}
+	}
+}
+
+func TestSplitHTML_TagTooLong(t *testing.T) {
+	message := `<a href="https://example.com/` + strings.Repeat("p", MaxMessageLength) + `">log</a>`
+
+	_, err := splitHTML(message, MaxMessageLength)
+	if !errors.Is(err, ErrFieldTooLong) {
+		t.Fatalf("error = %v, want %v", err, ErrFieldTooLong)
+	}
+}
+
+func TestSplitMessage_PrefixGrowsWithPartCount(t *testing.T) {
+	parts := splitMessage(strings.Repeat("x", 20*MaxMessageLength), MaxMessageLength)
+
+	if len(parts) < 10 {
+		t.Fatalf("got %d parts, want at least 10", len(parts))
+	}
+
+	for _, part := range parts {
+		if n := utf8.RuneCountInString(part); n > MaxMessageLength {
+			t.Fatalf("part %q... has %d characters", part[:12], n)
+		}
+	}
+}
+
+func TestParseLengthPolicy(t *testing.T) {
+	for _, value := range []string{"reject", "Truncate", " split "} {
+		if _, err := ParseLengthPolicy(value); err != nil {
+			t.Fatalf("ParseLengthPolicy(%q) error = %v", value, err)
+		}
+	}
+
+	if _, err := ParseLengthPolicy("drop"); !errors.Is(err, ErrInvalidLengthPolicy) {
+		t.Fatalf("ParseLengthPolicy(drop) error = %v, want %v", err, ErrInvalidLengthPolicy)
+	}
+}
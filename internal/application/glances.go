@@ -0,0 +1,57 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+// maxGlanceTextLength is the Pushover limit for title, text and subtext.
+const maxGlanceTextLength = 100
+
+var (
+	ErrGlanceEmpty          = errors.New("glance needs at least one of title, text, subtext, count or percent")
+	ErrGlanceTextTooLong    = fmt.Errorf("glance title, text and subtext must be at most %d characters", maxGlanceTextLength)
+	ErrGlancePercentInvalid = errors.New("glance percent must be between 0 and 100")
+)
+
+type GlanceUseCase struct {
+	updater domain.GlanceUpdater
+}
+
+func NewGlanceUseCase(updater domain.GlanceUpdater) *GlanceUseCase {
+	return &GlanceUseCase{updater: updater}
+}
+
+func (u *GlanceUseCase) Update(ctx context.Context, glance domain.Glance) error {
+	texts := []string{glance.Title, glance.Text, glance.Subtext}
+
+	empty := glance.Count == nil && glance.Percent == nil
+	for _, text := range texts {
+		if strings.TrimSpace(text) != "" {
+			empty = false
+		}
+
+		if utf8.RuneCountInString(text) > maxGlanceTextLength {
+			return ErrGlanceTextTooLong
+		}
+	}
+
+	if empty {
+		return ErrGlanceEmpty
+	}
+
+	if glance.Percent != nil && (*glance.Percent < 0 || *glance.Percent > 100) {
+		return ErrGlancePercentInvalid
+	}
+
+	if err := u.updater.UpdateGlance(ctx, glance); err != nil {
+		return fmt.Errorf("update glance: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+type fakeGlanceUpdater struct {
+	err    error
+	glance domain.Glance
+	called bool
+}
+
+func (f *fakeGlanceUpdater) UpdateGlance(_ context.Context, glance domain.Glance) error {
+	f.called = true
+	f.glance = glance
+
+	return f.err
+}
+
+func TestGlanceUseCase_Update(t *testing.T) {
+	count := 0
+	updater := &fakeGlanceUpdater{}
+
+	if err := NewGlanceUseCase(updater).Update(context.Background(), domain.Glance{Count: &count}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	if !updater.called || updater.glance.Count == nil {
+		t.Fatalf("glance = %+v, want count passed through", updater.glance)
+	}
+}
+
+func TestGlanceUseCase_Update_Validation(t *testing.T) {
+	over := 101
+
+	tests := []struct {
+		name   string
+		glance domain.Glance
+		want   error
+	}{
+		{name: "empty", glance: domain.Glance{Title: " ", Device: "watch"}, want: ErrGlanceEmpty},
+		{name: "text too long", glance: domain.Glance{Text: strings.Repeat("é", 101)}, want: ErrGlanceTextTooLong},
+		{name: "percent out of range", glance: domain.Glance{Percent: &over}, want: ErrGlancePercentInvalid},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			updater := &fakeGlanceUpdater{}
+
+			err := NewGlanceUseCase(updater).Update(context.Background(), tc.glance)
+			if !errors.Is(err, tc.want) {
+				t.Fatalf("error = %v, want %v", err, tc.want)
+			}
+
+			if updater.called {
+				t.Fatal("updater.UpdateGlance was called, want not called")
+			}
+		})
+	}
+}
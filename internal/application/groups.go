@@ -0,0 +1,88 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+var (
+	ErrUserRequired      = errors.New("user is required")
+	ErrGroupNameRequired = errors.New("group name is required")
+)
+
+type GroupUseCase struct {
+	manager domain.GroupManager
+}
+
+func NewGroupUseCase(manager domain.GroupManager) *GroupUseCase {
+	return &GroupUseCase{manager: manager}
+}
+
+func (u *GroupUseCase) Get(ctx context.Context) (domain.Group, error) {
+	group, err := u.manager.Group(ctx)
+	if err != nil {
+		return domain.Group{}, fmt.Errorf("get group: %w", err)
+	}
+
+	return group, nil
+}
+
+func (u *GroupUseCase) AddUser(ctx context.Context, member domain.GroupMember) error {
+	member.User = strings.TrimSpace(member.User)
+	if member.User == "" {
+		return ErrUserRequired
+	}
+
+	if err := u.manager.AddUser(ctx, member); err != nil {
+		return fmt.Errorf("add group user: %w", err)
+	}
+
+	return nil
+}
+
+func (u *GroupUseCase) RemoveUser(ctx context.Context, user, device string) error {
+	return u.changeUser(ctx, "remove group user", u.manager.RemoveUser, user, device)
+}
+
+func (u *GroupUseCase) DisableUser(ctx context.Context, user, device string) error {
+	return u.changeUser(ctx, "disable group user", u.manager.DisableUser, user, device)
+}
+
+func (u *GroupUseCase) EnableUser(ctx context.Context, user, device string) error {
+	return u.changeUser(ctx, "enable group user", u.manager.EnableUser, user, device)
+}
+
+func (u *GroupUseCase) Rename(ctx context.Context, name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ErrGroupNameRequired
+	}
+
+	if err := u.manager.Rename(ctx, name); err != nil {
+		return fmt.Errorf("rename group: %w", err)
+	}
+
+	return nil
+}
+
+func (u *GroupUseCase) changeUser(
+	ctx context.Context,
+	action string,
+	change func(ctx context.Context, user, device string) error,
+	user, device string,
+) error {
+	user = strings.TrimSpace(user)
+	if user == "" {
+		return ErrUserRequired
+	}
+
+	if err := change(ctx, user, strings.TrimSpace(device)); err != nil {
+		return fmt.Errorf("%s: %w", action, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+type fakeGroupManager struct {
+	err    error
+	calls  []string
+	member domain.GroupMember
+}
+
+func (f *fakeGroupManager) Group(_ context.Context) (domain.Group, error) {
+	f.calls = append(f.calls, "group")
+
+	return domain.Group{Name: "On-call"}, f.err
+}
+
+func (f *fakeGroupManager) AddUser(_ context.Context, member domain.GroupMember) error {
+	f.calls = append(f.calls, "add")
+	f.member = member
+
+	return f.err
+}
+
+func (f *fakeGroupManager) RemoveUser(_ context.Context, user, device string) error {
+	f.calls = append(f.calls, "remove:"+user+":"+device)
+
+	return f.err
+}
+
+func (f *fakeGroupManager) DisableUser(_ context.Context, user, device string) error {
+	f.calls = append(f.calls, "disable:"+user+":"+device)
+
+	return f.err
+}
+
+func (f *fakeGroupManager) EnableUser(_ context.Context, user, device string) error {
+	f.calls = append(f.calls, "enable:"+user+":"+device)
+
+	return f.err
+}
+
+func (f *fakeGroupManager) Rename(_ context.Context, name string) error {
+	f.calls = append(f.calls, "rename:"+name)
+
+	return f.err
+}
+
+func TestGroupUseCase_Changes(t *testing.T) {
+	manager := &fakeGroupManager{}
+	useCase := NewGroupUseCase(manager)
+	ctx := context.Background()
+
+	if err := useCase.AddUser(ctx, domain.GroupMember{User: " user-1 ", Memo: "backup"}); err != nil {
+		t.Fatalf("AddUser() error = %v", err)
+	}
+
+	assertString(t, manager.member.User, "user-1", "user")
+
+	if err := useCase.RemoveUser(ctx, "user-1", " iphone "); err != nil {
+		t.Fatalf("RemoveUser() error = %v", err)
+	}
+
+	if err := useCase.DisableUser(ctx, "user-1", ""); err != nil {
+		t.Fatalf("DisableUser() error = %v", err)
+	}
+
+	if err := useCase.EnableUser(ctx, "user-1", ""); err != nil {
+		t.Fatalf("EnableUser() error = %v", err)
+	}
+
+	if err := useCase.Rename(ctx, " On-call "); err != nil {
+		t.Fatalf("Rename() error = %v", err)
+	}
+
+	want := "add,remove:user-1:iphone,disable:user-1:,enable:user-1:,rename:On-call"
+	if got := strings.Join(manager.calls, ","); got != want {
+		t.Fatalf("calls = %q, want %q", got, want)
+	}
+}
+
+func TestGroupUseCase_Validation(t *testing.T) {
+	manager := &fakeGroupManager{}
+	useCase := NewGroupUseCase(manager)
+	ctx := context.Background()
+
+	if err := useCase.AddUser(ctx, domain.GroupMember{}); !errors.Is(err, ErrUserRequired) {
+		t.Fatalf("AddUser() error = %v, want %v", err, ErrUserRequired)
+	}
+
+	if err := useCase.DisableUser(ctx, " ", ""); !errors.Is(err, ErrUserRequired) {
+		t.Fatalf("DisableUser() error = %v, want %v", err, ErrUserRequired)
+	}
+
+	if err := useCase.Rename(ctx, ""); !errors.Is(err, ErrGroupNameRequired) {
+		t.Fatalf("Rename() error = %v, want %v", err, ErrGroupNameRequired)
+	}
+
+	if len(manager.calls) != 0 {
+		t.Fatalf("calls = %v, want none", manager.calls)
+	}
+}
+
+func TestGroupUseCase_ManagerError(t *testing.T) {
+	useCase := NewGroupUseCase(&fakeGroupManager{err: errors.New("boom")})
+
+	if _, err := useCase.Get(context.Background()); err == nil || !strings.Contains(err.Error(), "get group") {
+		t.Fatalf("Get() error = %v, want wrapped error", err)
+	}
+
+	if err := useCase.RemoveUser(context.Background(), "user-1", ""); err == nil || !strings.Contains(err.Error(), "remove group user") {
+		t.Fatalf("RemoveUser() error = %v, want wrapped error", err)
+	}
+}
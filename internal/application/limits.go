@@ -0,0 +1,39 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+// LimitsUseCase reads the quota of an application profile; each application has its own.
+type LimitsUseCase struct {
+	reader domain.RateLimitReader
+	apps   map[string]domain.RateLimitReader
+}
+
+// NewLimitsUseCase uses reader for the default application and apps for the named profiles.
+func NewLimitsUseCase(reader domain.RateLimitReader, apps map[string]domain.RateLimitReader) *LimitsUseCase {
+	return &LimitsUseCase{reader: reader, apps: apps}
+}
+
+// Get returns the current quota of app, the default application when empty. When Pushover cannot
+// be reached it falls back to the quota reported with the last message; ObservedAt tells how old that is.
+func (u *LimitsUseCase) Get(ctx context.Context, app string) (domain.RateLimit, error) {
+	reader, err := appFor(u.apps, app, u.reader)
+	if err != nil {
+		return domain.RateLimit{}, err
+	}
+
+	limit, err := reader.RateLimit(ctx)
+	if err == nil {
+		return limit, nil
+	}
+
+	if last, ok := reader.LastRateLimit(); ok {
+		return last, nil
+	}
+
+	return domain.RateLimit{}, fmt.Errorf("get limits: %w", err)
+}
@@ -0,0 +1,89 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+type fakeRateLimitReader struct {
+	err     error
+	live    domain.RateLimit
+	last    domain.RateLimit
+	hasLast bool
+}
+
+func (f *fakeRateLimitReader) RateLimit(_ context.Context) (domain.RateLimit, error) {
+	return f.live, f.err
+}
+
+func (f *fakeRateLimitReader) LastRateLimit() (domain.RateLimit, bool) {
+	return f.last, f.hasLast
+}
+
+func TestLimitsUseCase_Get(t *testing.T) {
+	tests := []struct {
+		name          string
+		reader        *fakeRateLimitReader
+		wantRemaining int
+		wantErr       bool
+	}{
+		{
+			name:          "live",
+			reader:        &fakeRateLimitReader{live: domain.RateLimit{Remaining: 10}, last: domain.RateLimit{Remaining: 20}, hasLast: true},
+			wantRemaining: 10,
+		},
+		{
+			name:          "fallback to last seen",
+			reader:        &fakeRateLimitReader{err: errors.New("offline"), last: domain.RateLimit{Remaining: 20}, hasLast: true},
+			wantRemaining: 20,
+		},
+		{
+			name:    "nothing known",
+			reader:  &fakeRateLimitReader{err: errors.New("offline")},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			limit, err := NewLimitsUseCase(tc.reader, nil).Get(context.Background(), "")
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("Get() error = nil, want non-nil")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+
+			if limit.Remaining != tc.wantRemaining {
+				t.Fatalf("remaining = %d, want %d", limit.Remaining, tc.wantRemaining)
+			}
+		})
+	}
+}
+
+func TestLimitsUseCase_Get_App(t *testing.T) {
+	primary := &fakeRateLimitReader{live: domain.RateLimit{Remaining: 10}}
+	alerts := &fakeRateLimitReader{live: domain.RateLimit{Remaining: 3}}
+	useCase := NewLimitsUseCase(primary, map[string]domain.RateLimitReader{"primary": primary, "alerts": alerts})
+
+	limit, err := useCase.Get(context.Background(), "alerts")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	if limit.Remaining != 3 {
+		t.Fatalf("remaining = %d, want 3", limit.Remaining)
+	}
+
+	if _, err := useCase.Get(context.Background(), "ops"); !errors.Is(err, ErrUnknownApp) {
+		t.Fatalf("error = %v, want %v", err, ErrUnknownApp)
+	}
+}
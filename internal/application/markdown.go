@@ -0,0 +1,185 @@
+package application
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+// Placeholders keep already rendered fragments away from later inline rules.
+// They use private-use runes; any in the input are held as fragments first, so they cannot collide.
+const (
+	placeholderOpen  = "\uE000"
+	placeholderClose = "\uE001"
+)
+
+var (
+	fencePattern     = regexp.MustCompile("^\\s*(```|~~~)")
+	headingPattern   = regexp.MustCompile(`^\s{0,3}#{1,6}\s+(.*?)\s*#*\s*$`)
+	rulePattern      = regexp.MustCompile(`^\s{0,3}([-*_])(\s*[-*_]){2,}\s*$`)
+	bulletPattern    = regexp.MustCompile(`^(\s*)[-*+]\s+(?:\[([ xX])\]\s+)?(.*)$`)
+	quotePattern     = regexp.MustCompile(`^\s{0,3}>\s?(.*)$`)
+	tableRulePattern = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+
+	escapePattern   = regexp.MustCompile("\\\\([\\\\`*_{}\\[\\]()#+\\-.!|~>])")
+	codeSpanPattern = regexp.MustCompile("(`+)(.+?)(`+)")
+	linkPattern     = regexp.MustCompile(`!?\[([^\]]*)\]\((\S+?)(?:\s+"[^"]*")?\)`)
+	autoLinkPattern = regexp.MustCompile(`&lt;((?:https?://|mailto:)[^\s&]+)&gt;`)
+	strongPattern   = regexp.MustCompile(`\*\*\*(\S(?:.*?\S)?)\*\*\*`)
+	boldPattern     = regexp.MustCompile(`\*\*(\S(?:.*?\S)?)\*\*|__(\S(?:.*?\S)?)__`)
+	italicPattern   = regexp.MustCompile(`\*(\S(?:[^*]*?\S)?)\*|\b_(\S(?:[^_]*?\S)?)_\b`)
+	strikePattern   = regexp.MustCompile(`~~(\S(?:.*?\S)?)~~`)
+	placeholderRef  = regexp.MustCompile(placeholderOpen + `(\d+)` + placeholderClose)
+	placeholderRune = regexp.MustCompile("[" + placeholderOpen + placeholderClose + "]")
+)
+
+// renderMarkdown converts a Markdown message to Pushover HTML and turns on HTML rendering.
+func renderMarkdown(notification domain.Notification) domain.Notification {
+	if !notification.Markdown {
+		return notification
+	}
+
+	notification.Message = markdownToHTML(notification.Message)
+	notification.HTML = true
+
+	return notification
+}
+
+// markdownToHTML renders Markdown with the tags Pushover supports: <b>, <i>, <u>, <font color> and <a href>.
+// Constructs Pushover cannot show degrade to plain text: headings become bold lines, code loses its
+// backticks, list items get bullets, table rows keep their cells separated by " | ".
+func markdownToHTML(markdown string) string {
+	lines := strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n")
+	out := make([]string, 0, len(lines))
+	inFence := false
+
+	for _, line := range lines {
+		if fencePattern.MatchString(line) {
+			inFence = !inFence
+			continue
+		}
+
+		if inFence {
+			out = append(out, html.EscapeString(line))
+			continue
+		}
+
+		out = append(out, renderBlock(line))
+	}
+
+	return strings.TrimSpace(strings.Join(out, "\n"))
+}
+
+func renderBlock(line string) string {
+	if m := headingPattern.FindStringSubmatch(line); m != nil {
+		return "<b>" + renderInline(m[1]) + "</b>"
+	}
+
+	if rulePattern.MatchString(line) {
+		return "──────────"
+	}
+
+	if tableRulePattern.MatchString(line) && strings.Contains(line, "-") && strings.Contains(line, "|") {
+		return ""
+	}
+
+	if m := bulletPattern.FindStringSubmatch(line); m != nil {
+		marker := "•"
+
+		switch m[2] {
+		case " ":
+			marker = "☐"
+		case "x", "X":
+			marker = "☑"
+		}
+
+		return m[1] + marker + " " + renderInline(m[3])
+	}
+
+	if m := quotePattern.FindStringSubmatch(line); m != nil {
+		return "<i>" + renderInline(m[1]) + "</i>"
+	}
+
+	if trimmed := strings.TrimSpace(line); strings.HasPrefix(trimmed, "|") && strings.HasSuffix(trimmed, "|") {
+		cells := strings.Split(strings.Trim(trimmed, "|"), "|")
+		for i, cell := range cells {
+			cells[i] = renderInline(strings.TrimSpace(cell))
+		}
+
+		return strings.Join(cells, " | ")
+	}
+
+	return renderInline(line)
+}
+
+func renderInline(text string) string {
+	var fragments []string
+
+	hold := func(fragment string) string {
+		fragments = append(fragments, fragment)
+		return placeholderOpen + strconv.Itoa(len(fragments)-1) + placeholderClose
+	}
+
+	text = placeholderRune.ReplaceAllStringFunc(text, hold)
+
+	text = escapePattern.ReplaceAllStringFunc(text, func(m string) string {
+		return hold(html.EscapeString(m[1:]))
+	})
+
+	text = codeSpanPattern.ReplaceAllStringFunc(text, func(m string) string {
+		parts := codeSpanPattern.FindStringSubmatch(m)
+		if parts[1] != parts[3] {
+			return m
+		}
+
+		return hold(html.EscapeString(strings.TrimSpace(parts[2])))
+	})
+
+	text = html.EscapeString(text)
+
+	text = linkPattern.ReplaceAllStringFunc(text, func(m string) string {
+		parts := linkPattern.FindStringSubmatch(m)
+
+		label := parts[1]
+		if label == "" {
+			label = parts[2]
+		}
+
+		return hold(fmt.Sprintf(`<a href="%s">%s</a>`, parts[2], renderEmphasis(label)))
+	})
+
+	text = autoLinkPattern.ReplaceAllStringFunc(text, func(m string) string {
+		url := autoLinkPattern.FindStringSubmatch(m)[1]
+		return hold(fmt.Sprintf(`<a href="%s">%s</a>`, url, url))
+	})
+
+	text = renderEmphasis(text)
+
+	return expandFragments(text, fragments)
+}
+
+// expandFragments replaces the placeholders in text with their fragments in a single pass.
+// Fragments may nest, e.g. an escaped character inside a link label, but only refer to fragments
+// held before them, which bounds the recursion.
+func expandFragments(text string, fragments []string) string {
+	return placeholderRef.ReplaceAllStringFunc(text, func(m string) string {
+		index, err := strconv.Atoi(placeholderRef.FindStringSubmatch(m)[1])
+		if err != nil || index >= len(fragments) {
+			return m
+		}
+
+		return expandFragments(fragments[index], fragments[:index])
+	})
+}
+
+func renderEmphasis(text string) string {
+	text = strongPattern.ReplaceAllString(text, "<b><i>$1</i></b>")
+	text = boldPattern.ReplaceAllString(text, "<b>$1$2</b>")
+	text = italicPattern.ReplaceAllString(text, "<i>$1$2</i>")
+
+	return strikePattern.ReplaceAllString(text, "$1")
+}
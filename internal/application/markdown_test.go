@@ -0,0 +1,81 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+func TestMarkdownToHTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		want     string
+	}{
+		{name: "plain", markdown: "Deploy finished", want: "Deploy finished"},
+		{name: "bold", markdown: "**done** and __ok__", want: "<b>done</b> and <b>ok</b>"},
+		{name: "italic", markdown: "*soon* and _later_", want: "<i>soon</i> and <i>later</i>"},
+		{name: "bold italic", markdown: "***both***", want: "<b><i>both</i></b>"},
+		{name: "snake case untouched", markdown: "run snake_case_name now", want: "run snake_case_name now"},
+		{name: "lone asterisk", markdown: "2 * 3 = 6", want: "2 * 3 = 6"},
+		{name: "strikethrough", markdown: "~~old~~ new", want: "old new"},
+		{name: "inline code", markdown: "run `make **test**`", want: "run make **test**"},
+		{name: "escaped", markdown: `\*not italic\*`, want: "*not italic*"},
+		{name: "html escaped", markdown: "a < b & c > d", want: "a &lt; b &amp; c &gt; d"},
+		{name: "placeholder runes", markdown: "x \uE0007\uE001 *y*", want: "x \uE0007\uE001 <i>y</i>"},
+		{name: "placeholder runes in code", markdown: "`\uE0000\uE001` \uE001", want: "\uE0000\uE001 \uE001"},
+		{
+			name:     "link",
+			markdown: "see [the **build**](https://ci.example.com/b/1?a=1&b=2)",
+			want:     `see <a href="https://ci.example.com/b/1?a=1&amp;b=2">the <b>build</b></a>`,
+		},
+		{name: "image", markdown: "![graph](https://x.example/g.png)", want: `<a href="https://x.example/g.png">graph</a>`},
+		{name: "autolink", markdown: "<https://example.com>", want: `<a href="https://example.com">https://example.com</a>`},
+		{name: "underscore in link", markdown: "[x](https://e.com/a_b_c)", want: `<a href="https://e.com/a_b_c">x</a>`},
+		{name: "heading", markdown: "## Build *42* ##", want: "<b>Build <i>42</i></b>"},
+		{name: "bullets", markdown: "- one\n  * two", want: "• one\n  • two"},
+		{name: "task list", markdown: "- [x] done\n- [ ] todo", want: "☑ done\n☐ todo"},
+		{name: "ordered list", markdown: "1. first\n2. second", want: "1. first\n2. second"},
+		{name: "quote", markdown: "> careful", want: "<i>careful</i>"},
+		{name: "rule", markdown: "a\n\n---\n\nb", want: "a\n\n──────────\n\nb"},
+		{
+			name:     "code block",
+			markdown: "Failed:\n```go\nif a < b && *p {\n```\nend",
+			want:     "Failed:\nif a &lt; b &amp;&amp; *p {\nend",
+		},
+		{
+			name:     "table",
+			markdown: "| Job | State |\n|:----|------:|\n| build | **failed** |",
+			want:     "Job | State\n\nbuild | <b>failed</b>",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assertString(t, markdownToHTML(tc.markdown), tc.want, "html")
+		})
+	}
+}
+
+func TestExecute_Markdown(t *testing.T) {
+	sender, useCase := newUseCaseWithFake()
+
+	_, err := useCase.Execute(context.Background(), domain.Notification{Message: "**Deploy** done", Markdown: true})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	assertString(t, sender.notification.Message, "<b>Deploy</b> done", "Message")
+
+	if !sender.notification.HTML {
+		t.Fatal("HTML = false, want true for markdown")
+	}
+}
+
+func TestExecute_MarkdownAndMonospace(t *testing.T) {
+	sender, useCase := newUseCaseWithFake()
+
+	_, err := useCase.Execute(context.Background(), domain.Notification{Message: testMessage, Markdown: true, Monospace: true})
+	assertValidationError(t, sender, err, ErrMarkdownMonospace)
+}
@@ -0,0 +1,339 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+// QuietAction decides what happens to a non-emergency notification during quiet hours.
+// Emergency priority (2) always goes through.
+type QuietAction string
+
+const (
+	// QuietDowngrade lowers the priority to QuietHours.Priority, so the notification arrives silently.
+	QuietDowngrade QuietAction = "downgrade"
+	// QuietHold schedules the notification for the end of the quiet hours.
+	QuietHold QuietAction = "hold"
+	// QuietEmergencyOnly does not send the notification at all.
+	QuietEmergencyOnly QuietAction = "emergency_only"
+)
+
+const (
+	emergencyPriority = 2
+	// quietChainLimit bounds how many back-to-back windows are merged when looking for the end of quiet hours.
+	quietChainLimit = 14
+)
+
+var (
+	ErrInvalidQuietAction   = errors.New("quiet hours action must be downgrade, hold or emergency_only")
+	ErrQuietHoldUnavailable = errors.New("holding notifications during quiet hours needs the scheduler")
+)
+
+// ParseQuietAction validates a configured action name.
+func ParseQuietAction(value string) (QuietAction, error) {
+	switch action := QuietAction(strings.ToLower(strings.TrimSpace(value))); action {
+	case QuietDowngrade, QuietHold, QuietEmergencyOnly:
+		return action, nil
+	default:
+		return "", fmt.Errorf("%w, got %q", ErrInvalidQuietAction, value)
+	}
+}
+
+// QuietWindow is a daily quiet period from Start to End, in minutes after midnight. A window
+// whose end is not after its start runs past midnight. Days are the days it starts on; all when empty.
+type QuietWindow struct {
+	Days  []time.Weekday
+	Start int
+	End   int
+}
+
+type QuietHours struct {
+	Location *time.Location
+	Action   QuietAction
+	Windows  []QuietWindow
+	Priority int // Priority QuietDowngrade lowers to, -1 or -2
+}
+
+// Until reports whether now falls in quiet hours and when they end; back-to-back windows count as one.
+func (q QuietHours) Until(now time.Time) (time.Time, bool) {
+	end, quiet := q.windowEnd(now)
+	if !quiet {
+		return time.Time{}, false
+	}
+
+	for range quietChainLimit {
+		next, ok := q.windowEnd(end)
+		if !ok || !next.After(end) {
+			break
+		}
+
+		end = next
+	}
+
+	return end, true
+}
+
+// windowEnd returns the latest end of the windows containing t.
+func (q QuietHours) windowEnd(t time.Time) (time.Time, bool) {
+	t = t.In(q.Location)
+
+	var (
+		end   time.Time
+		quiet bool
+	)
+
+	// A window containing t started today or, when it runs past midnight, yesterday.
+	for _, daysAgo := range []int{0, 1} {
+		day := time.Date(t.Year(), t.Month(), t.Day()-daysAgo, 0, 0, 0, 0, q.Location)
+
+		for _, w := range q.Windows {
+			if len(w.Days) > 0 && !slices.Contains(w.Days, day.Weekday()) {
+				continue
+			}
+
+			start := atMinute(day, w.Start)
+
+			stop := atMinute(day, w.End)
+			if w.End <= w.Start {
+				stop = atMinute(day.AddDate(0, 0, 1), w.End)
+			}
+
+			if !t.Before(start) && t.Before(stop) && stop.After(end) {
+				end, quiet = stop, true
+			}
+		}
+	}
+
+	return end, quiet
+}
+
+func atMinute(day time.Time, minute int) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), minute/60, minute%60, 0, 0, day.Location())
+}
+
+// QuietHoursUseCase applies a quiet-hours policy in front of SendNotificationUseCase. The
+// SendResult of an affected notification reports what the policy did in QuietHours.
+type QuietHoursUseCase struct {
+	send   *SendNotificationUseCase
+	hold   *SchedulerUseCase
+	now    func() time.Time
+	policy QuietHours
+}
+
+// NewQuietHoursUseCase wraps send; hold queues held notifications and is required for QuietHold.
+func NewQuietHoursUseCase(send *SendNotificationUseCase, policy QuietHours, hold *SchedulerUseCase) (*QuietHoursUseCase, error) {
+	if policy.Action == QuietHold && hold == nil {
+		return nil, ErrQuietHoldUnavailable
+	}
+
+	return &QuietHoursUseCase{send: send, hold: hold, now: time.Now, policy: policy}, nil
+}
+
+func (u *QuietHoursUseCase) Check(ctx context.Context, notification domain.Notification) error {
+	return u.send.Check(ctx, notification)
+}
+
+func (u *QuietHoursUseCase) Execute(ctx context.Context, notification domain.Notification) (domain.SendResult, error) {
+	until, quiet := u.policy.Until(u.now())
+	if !quiet {
+		return u.send.Execute(ctx, notification)
+	}
+
+	priority := u.priorityFor(notification, notification.User)
+	if !u.affects(priority) {
+		return u.send.Execute(ctx, notification)
+	}
+
+	outcome := &domain.QuietHoursOutcome{Until: until}
+
+	switch u.policy.Action {
+	case QuietDowngrade:
+		lowered := u.policy.Priority
+		notification.Priority = &lowered
+
+		result, err := u.send.Execute(ctx, notification)
+		if err != nil {
+			return domain.SendResult{}, err
+		}
+
+		outcome.Action, outcome.From, outcome.To = domain.QuietDowngraded, priority, u.policy.Priority
+		result.QuietHours = outcome
+
+		return result, nil
+	case QuietHold:
+		scheduled, err := u.hold.Schedule(ctx, notification, until)
+		if err != nil {
+			return domain.SendResult{}, fmt.Errorf("hold notification: %w", err)
+		}
+
+		outcome.Action, outcome.ScheduledID = domain.QuietHeld, scheduled.ID
+	default:
+		if err := u.send.Check(ctx, notification); err != nil {
+			return domain.SendResult{}, err
+		}
+
+		outcome.Action = domain.QuietSuppressed
+	}
+
+	return domain.SendResult{QuietHours: outcome}, nil
+}
+
+// ExecuteFanOut applies the policy per recipient, since alias defaults can give recipients different priorities.
+// Every recipient is validated before anything is sent or held.
+func (u *QuietHoursUseCase) ExecuteFanOut(
+	ctx context.Context,
+	notification domain.Notification,
+	recipients []string,
+) ([]RecipientResult, error) {
+	until, quiet := u.policy.Until(u.now())
+	if !quiet {
+		return u.send.ExecuteFanOut(ctx, notification, recipients)
+	}
+
+	names, err := normalizeRecipients(recipients)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		unaffected []string
+		affected   []string
+	)
+
+	priorities := make(map[string]int, len(names))
+
+	for _, name := range names {
+		priorities[name] = u.priorityFor(notification, name)
+
+		if u.affects(priorities[name]) {
+			affected = append(affected, name)
+		} else {
+			unaffected = append(unaffected, name)
+		}
+	}
+
+	if len(affected) == 0 {
+		return u.send.ExecuteFanOut(ctx, notification, names)
+	}
+
+	for _, name := range names {
+		addressed := notification
+		addressed.User = name
+
+		if err := u.send.Check(ctx, addressed); err != nil {
+			return nil, err
+		}
+	}
+
+	byRecipient := make(map[string]RecipientResult, len(names))
+
+	if len(unaffected) > 0 {
+		results, err := u.send.ExecuteFanOut(ctx, notification, unaffected)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, result := range results {
+			byRecipient[result.Recipient] = result
+		}
+	}
+
+	held, err := u.applyToRecipients(ctx, notification, affected, priorities, until)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, result := range held {
+		byRecipient[result.Recipient] = result
+	}
+
+	results := make([]RecipientResult, 0, len(names))
+	for _, name := range names {
+		results = append(results, byRecipient[name])
+	}
+
+	return results, nil
+}
+
+// applyToRecipients carries out the policy's action for the recipients it affects.
+func (u *QuietHoursUseCase) applyToRecipients(
+	ctx context.Context,
+	notification domain.Notification,
+	names []string,
+	priorities map[string]int,
+	until time.Time,
+) ([]RecipientResult, error) {
+	if u.policy.Action == QuietDowngrade {
+		lowered := u.policy.Priority
+		notification.Priority = &lowered
+
+		results, err := u.send.ExecuteFanOut(ctx, notification, names)
+		if err != nil {
+			return nil, err
+		}
+
+		for i, result := range results {
+			if result.Err == nil {
+				results[i].Result.QuietHours = &domain.QuietHoursOutcome{
+					Until:  until,
+					Action: domain.QuietDowngraded,
+					From:   priorities[result.Recipient],
+					To:     u.policy.Priority,
+				}
+			}
+		}
+
+		return results, nil
+	}
+
+	results := make([]RecipientResult, 0, len(names))
+
+	for _, name := range names {
+		outcome := &domain.QuietHoursOutcome{Until: until, Action: domain.QuietSuppressed}
+
+		if u.policy.Action == QuietHold {
+			addressed := notification
+			addressed.User = name
+
+			scheduled, err := u.hold.Schedule(ctx, addressed, until)
+			if err != nil {
+				results = append(results, RecipientResult{Recipient: name, Err: fmt.Errorf("hold notification: %w", err)})
+				continue
+			}
+
+			outcome.Action, outcome.ScheduledID = domain.QuietHeld, scheduled.ID
+		}
+
+		results = append(results, RecipientResult{Recipient: name, Result: domain.SendResult{QuietHours: outcome}})
+	}
+
+	return results, nil
+}
+
+// priorityFor is the priority the notification goes out with to recipient, after alias defaults.
+func (u *QuietHoursUseCase) priorityFor(notification domain.Notification, recipient string) int {
+	if recipient != "" {
+		notification = u.send.addressTo(notification, recipient)
+	}
+
+	if notification.Priority == nil {
+		return 0
+	}
+
+	return *notification.Priority
+}
+
+// affects reports whether the policy acts on a notification of this priority during quiet hours.
+func (u *QuietHoursUseCase) affects(priority int) bool {
+	if priority == emergencyPriority {
+		return false
+	}
+
+	return u.policy.Action != QuietDowngrade || priority > u.policy.Priority
+}
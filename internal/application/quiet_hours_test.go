@@ -0,0 +1,258 @@
+package application
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+// nightly is quiet 22:00-07:00 on nights starting Monday to Friday.
+func nightly(t *testing.T, action QuietAction) QuietHours {
+	t.Helper()
+
+	return QuietHours{
+		Location: mustLoadLocation(t, "Europe/Berlin"),
+		Action:   action,
+		Priority: -1,
+		Windows: []QuietWindow{{
+			Days:  []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+			Start: 22 * 60,
+			End:   7 * 60,
+		}},
+	}
+}
+
+func TestParseQuietAction(t *testing.T) {
+	for _, value := range []string{"downgrade", "HOLD", " emergency_only "} {
+		if _, err := ParseQuietAction(value); err != nil {
+			t.Fatalf("ParseQuietAction(%q) error = %v", value, err)
+		}
+	}
+
+	if _, err := ParseQuietAction("mute"); !errors.Is(err, ErrInvalidQuietAction) {
+		t.Fatalf("ParseQuietAction(mute) error = %v, want %v", err, ErrInvalidQuietAction)
+	}
+}
+
+func TestQuietHours_Until(t *testing.T) {
+	q := nightly(t, QuietDowngrade)
+	berlin := q.Location
+
+	weekend := q
+	weekend.Windows = append(weekend.Windows, QuietWindow{Days: []time.Weekday{time.Saturday}, Start: 0, End: 10 * 60})
+
+	tests := []struct {
+		name  string
+		quiet QuietHours
+		now   time.Time
+		until time.Time
+	}{
+		{name: "friday evening", quiet: q, now: time.Date(2026, 5, 1, 21, 59, 0, 0, berlin)},
+		{name: "friday night", quiet: q, now: time.Date(2026, 5, 1, 23, 0, 0, 0, berlin), until: time.Date(2026, 5, 2, 7, 0, 0, 0, berlin)},
+		{name: "after midnight", quiet: q, now: time.Date(2026, 5, 2, 3, 0, 0, 0, berlin), until: time.Date(2026, 5, 2, 7, 0, 0, 0, berlin)},
+		{name: "window end", quiet: q, now: time.Date(2026, 5, 2, 7, 0, 0, 0, berlin)},
+		{name: "saturday night", quiet: q, now: time.Date(2026, 5, 2, 23, 0, 0, 0, berlin)},
+		{name: "other time zone", quiet: q, now: time.Date(2026, 5, 1, 20, 30, 0, 0, time.UTC), until: time.Date(2026, 5, 2, 7, 0, 0, 0, berlin)},
+		{name: "back to back", quiet: weekend, now: time.Date(2026, 5, 2, 3, 0, 0, 0, berlin), until: time.Date(2026, 5, 2, 10, 0, 0, 0, berlin)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			until, quiet := tc.quiet.Until(tc.now)
+			if quiet != !tc.until.IsZero() || !until.Equal(tc.until) {
+				t.Fatalf("Until() = %v, %v, want %v", until, quiet, tc.until)
+			}
+		})
+	}
+}
+
+func newTestQuietHours(t *testing.T, action QuietAction, opts ...Option) (*fakeSender, *memScheduleStore, *QuietHoursUseCase) {
+	t.Helper()
+
+	sender := &fakeSender{result: domain.SendResult{Request: "req-1"}}
+	send := NewSendNotificationUseCase(sender, opts...)
+	store := &memScheduleStore{}
+	scheduler := NewSchedulerUseCase(store, send)
+	scheduler.now = func() time.Time { return testNow }
+
+	quiet, err := NewQuietHoursUseCase(send, nightly(t, action), scheduler)
+	if err != nil {
+		t.Fatalf("NewQuietHoursUseCase() error = %v", err)
+	}
+
+	// Friday 23:00 in Berlin; quiet until Saturday 07:00.
+	quiet.now = func() time.Time { return time.Date(2026, 5, 1, 21, 0, 0, 0, time.UTC) }
+
+	return sender, store, quiet
+}
+
+var quietUntil = time.Date(2026, 5, 2, 5, 0, 0, 0, time.UTC)
+
+func TestQuietHoursUseCase_Downgrade(t *testing.T) {
+	sender, _, quiet := newTestQuietHours(t, QuietDowngrade)
+	priority := 1
+
+	result, err := quiet.Execute(t.Context(), domain.Notification{Message: testMessage, Priority: &priority})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	assertIntPtr(t, sender.notification.Priority, -1, "priority")
+
+	outcome := result.QuietHours
+	if outcome == nil || outcome.Action != domain.QuietDowngraded || outcome.From != 1 || outcome.To != -1 ||
+		!outcome.Until.Equal(quietUntil) || result.Request != "req-1" {
+		t.Fatalf("result = %+v, outcome = %+v", result, outcome)
+	}
+}
+
+func TestQuietHoursUseCase_PassesThrough(t *testing.T) {
+	tests := []struct {
+		name     string
+		action   QuietAction
+		priority int
+	}{
+		{name: "emergency", action: QuietEmergencyOnly, priority: 2},
+		{name: "already quiet", action: QuietDowngrade, priority: -2},
+		{name: "at target", action: QuietDowngrade, priority: -1},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			sender, _, quiet := newTestQuietHours(t, tc.action)
+
+			retry, expire := 60, 3600
+
+			result, err := quiet.Execute(t.Context(), domain.Notification{
+				Message: testMessage, Priority: &tc.priority, Retry: &retry, Expire: &expire,
+			})
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+
+			if result.QuietHours != nil || !sender.called {
+				t.Fatalf("result = %+v, sent = %v, want an unchanged send", result, sender.called)
+			}
+
+			assertIntPtr(t, sender.notification.Priority, tc.priority, "priority")
+		})
+	}
+
+	sender, _, quiet := newTestQuietHours(t, QuietEmergencyOnly)
+	quiet.now = func() time.Time { return testNow }
+
+	if result, err := quiet.Execute(t.Context(), domain.Notification{Message: testMessage}); err != nil || result.QuietHours != nil || !sender.called {
+		t.Fatalf("outside quiet hours: result = %+v, err = %v, sent = %v", result, err, sender.called)
+	}
+}
+
+func TestQuietHoursUseCase_AliasPriority(t *testing.T) {
+	urgent := 1
+	sender, _, quiet := newTestQuietHours(t, QuietDowngrade, WithRecipientAliases(map[string]domain.RecipientAlias{
+		"oncall": {Key: "uoncall", Priority: &urgent},
+	}))
+
+	result, err := quiet.Execute(t.Context(), domain.Notification{Message: testMessage, User: "oncall"})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	assertIntPtr(t, sender.notification.Priority, -1, "priority")
+
+	if result.QuietHours == nil || result.QuietHours.From != 1 {
+		t.Fatalf("outcome = %+v, want a downgrade from the alias priority", result.QuietHours)
+	}
+}
+
+func TestQuietHoursUseCase_Hold(t *testing.T) {
+	sender, store, quiet := newTestQuietHours(t, QuietHold)
+
+	result, err := quiet.Execute(t.Context(), domain.Notification{Message: testMessage})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if sender.called {
+		t.Fatal("held notification was sent")
+	}
+
+	if len(store.items) != 1 || !store.items[0].SendAt.Equal(quietUntil) {
+		t.Fatalf("store = %+v, want one notification at %v", store.items, quietUntil)
+	}
+
+	if outcome := result.QuietHours; outcome == nil || outcome.Action != domain.QuietHeld || outcome.ScheduledID != store.items[0].ID {
+		t.Fatalf("outcome = %+v", result.QuietHours)
+	}
+}
+
+func TestQuietHoursUseCase_EmergencyOnly(t *testing.T) {
+	sender, _, quiet := newTestQuietHours(t, QuietEmergencyOnly)
+	priority := 1
+
+	result, err := quiet.Execute(t.Context(), domain.Notification{Message: testMessage, Priority: &priority})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if sender.called || result.QuietHours == nil || result.QuietHours.Action != domain.QuietSuppressed {
+		t.Fatalf("result = %+v, sent = %v", result, sender.called)
+	}
+
+	// An invalid notification is reported even though it would not be sent.
+	if _, err := quiet.Execute(t.Context(), domain.Notification{}); !errors.Is(err, ErrMessageRequired) {
+		t.Fatalf("Execute() error = %v, want %v", err, ErrMessageRequired)
+	}
+}
+
+func TestQuietHoursUseCase_FanOut(t *testing.T) {
+	emergency, retry, expire := 2, 60, 3600
+	sender, store, quiet := newTestQuietHours(t, QuietHold, WithRecipientAliases(map[string]domain.RecipientAlias{
+		"pager": {Key: "upager", Priority: &emergency},
+	}))
+
+	results, err := quiet.ExecuteFanOut(t.Context(), domain.Notification{Message: testMessage, Retry: &retry, Expire: &expire},
+		[]string{"ualice", "pager", "ubob"})
+	if err != nil {
+		t.Fatalf("ExecuteFanOut() error = %v", err)
+	}
+
+	if len(results) != 3 || results[0].Recipient != "ualice" || results[1].Recipient != "pager" || results[2].Recipient != "ubob" {
+		t.Fatalf("results = %+v, want input order", results)
+	}
+
+	if results[1].Result.QuietHours != nil || results[1].Result.Request != "req-1" || sender.notification.User != "upager" {
+		t.Fatalf("pager = %+v, want the emergency recipient sent to", results[1])
+	}
+
+	for _, i := range []int{0, 2} {
+		if outcome := results[i].Result.QuietHours; outcome == nil || outcome.Action != domain.QuietHeld {
+			t.Fatalf("%s = %+v, want held", results[i].Recipient, results[i])
+		}
+	}
+
+	if len(store.items) != 2 || store.items[0].Notification.User != "ualice" {
+		t.Fatalf("store = %+v, want one held notification per recipient", store.items)
+	}
+}
+
+func TestQuietHoursUseCase_FanOutValidatesFirst(t *testing.T) {
+	sender, store, quiet := newTestQuietHours(t, QuietHold)
+
+	if _, err := quiet.ExecuteFanOut(t.Context(), domain.Notification{}, []string{"ualice"}); !errors.Is(err, ErrMessageRequired) {
+		t.Fatalf("ExecuteFanOut() error = %v, want %v", err, ErrMessageRequired)
+	}
+
+	if sender.called || len(store.items) != 0 {
+		t.Fatal("invalid notification was sent or held")
+	}
+}
+
+func TestNewQuietHoursUseCase_HoldNeedsScheduler(t *testing.T) {
+	_, send := newUseCaseWithFake()
+
+	if _, err := NewQuietHoursUseCase(send, nightly(t, QuietHold), nil); !errors.Is(err, ErrQuietHoldUnavailable) {
+		t.Fatalf("NewQuietHoursUseCase() error = %v, want %v", err, ErrQuietHoldUnavailable)
+	}
+}
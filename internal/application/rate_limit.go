@@ -0,0 +1,233 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Scopes a send rate limit applies to.
+const (
+	RateScopeGlobal  = "global"
+	RateScopeSession = "session"
+)
+
+var (
+	ErrSendRateLimited  = errors.New("send rate limit exceeded")
+	ErrRateLimitBurst   = errors.New("more notifications than the send rate limit ever allows at once")
+	ErrInvalidRateLimit = errors.New(`rate limit must look like "30/10m": a positive count, a slash and a Go duration`)
+)
+
+// RateLimit is a token bucket that holds Burst notifications and refills Burst of them every Per.
+// The zero value disables the limit.
+type RateLimit struct {
+	Per   time.Duration
+	Burst int
+}
+
+// ParseRateLimit reads a limit like "30/10m". An empty value is the disabled zero limit.
+func ParseRateLimit(value string) (RateLimit, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return RateLimit{}, nil
+	}
+
+	count, period, ok := strings.Cut(value, "/")
+	if !ok {
+		return RateLimit{}, fmt.Errorf("%w, got %q", ErrInvalidRateLimit, value)
+	}
+
+	burst, err := strconv.Atoi(strings.TrimSpace(count))
+	if err != nil {
+		return RateLimit{}, fmt.Errorf("%w, got %q", ErrInvalidRateLimit, value)
+	}
+
+	per, err := time.ParseDuration(strings.TrimSpace(period))
+	if err != nil {
+		return RateLimit{}, fmt.Errorf("%w, got %q", ErrInvalidRateLimit, value)
+	}
+
+	if burst < 1 || per <= 0 {
+		return RateLimit{}, fmt.Errorf("%w, got %q", ErrInvalidRateLimit, value)
+	}
+
+	return RateLimit{Burst: burst, Per: per}, nil
+}
+
+func (l RateLimit) enabled() bool {
+	return l.Burst > 0
+}
+
+// RateLimitError is a send the limiter turned down; RetryAt is when the same send is allowed.
+type RateLimitError struct {
+	RetryAt time.Time
+	Scope   string
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("%s (%s), sending is allowed again at %s", ErrSendRateLimited, e.Scope, e.RetryAt.Format(time.RFC3339))
+}
+
+func (e *RateLimitError) Unwrap() error {
+	return ErrSendRateLimited
+}
+
+type rateChargeKey struct{}
+
+// WithRateCharge returns a context in which a send takes every part of a split message after the
+// first from charge, before any part goes out. Whoever charged for the send itself sets it, and gives
+// that back when charge fails, as the notification is then not sent at all.
+func WithRateCharge(ctx context.Context, charge func(n int) error) context.Context {
+	return context.WithValue(ctx, rateChargeKey{}, charge)
+}
+
+// chargeRate takes n notifications from the charge set with WithRateCharge, if any.
+func chargeRate(ctx context.Context, n int) error {
+	charge, ok := ctx.Value(rateChargeKey{}).(func(n int) error)
+	if !ok || n <= 0 {
+		return nil
+	}
+
+	return charge(n)
+}
+
+// RateLimiter enforces a global limit and a limit per client session on the notifications agents send.
+// A session's bucket is dropped once it has refilled, so idle sessions cost nothing.
+type RateLimiter struct {
+	now      func() time.Time
+	global   *tokenBucket
+	sessions map[string]*tokenBucket
+	session  RateLimit
+	mu       sync.Mutex
+}
+
+// NewRateLimiter returns a limiter for the enabled limits; disabled ones do not restrict anything.
+func NewRateLimiter(global, session RateLimit) *RateLimiter {
+	l := &RateLimiter{now: time.Now, sessions: map[string]*tokenBucket{}, session: session}
+
+	if global.enabled() {
+		l.global = newTokenBucket(global, time.Time{})
+	}
+
+	return l
+}
+
+// Allow takes n notifications from the global bucket and from session's. Either both allow them or
+// nothing is taken and the error says when they would.
+func (l *RateLimiter) Allow(session string, n int) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+
+	buckets := make(map[string]*tokenBucket, 2)
+	if l.global != nil {
+		buckets[RateScopeGlobal] = l.global
+	}
+
+	if l.session.enabled() {
+		l.prune(now)
+
+		bucket, ok := l.sessions[session]
+		if !ok {
+			bucket = newTokenBucket(l.session, now)
+		}
+
+		buckets[RateScopeSession] = bucket
+		l.sessions[session] = bucket
+	}
+
+	var denied *RateLimitError
+
+	for scope, bucket := range buckets {
+		if n > bucket.limit.Burst {
+			return fmt.Errorf("%w: %d requested, the %s limit is %d per %s",
+				ErrRateLimitBurst, n, scope, bucket.limit.Burst, bucket.limit.Per)
+		}
+
+		if wait := bucket.wait(now, n); wait > 0 && (denied == nil || now.Add(wait).After(denied.RetryAt)) {
+			denied = &RateLimitError{RetryAt: now.Add(wait), Scope: scope}
+		}
+	}
+
+	if denied != nil {
+		return denied
+	}
+
+	for _, bucket := range buckets {
+		bucket.tokens -= float64(n)
+	}
+
+	return nil
+}
+
+// Refund gives back n notifications Allow took for session, for a send that did not go out.
+func (l *RateLimiter) Refund(session string, n int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+
+	if l.global != nil {
+		l.global.give(now, n)
+	}
+
+	// A session without a bucket has refilled already.
+	if bucket, ok := l.sessions[session]; ok {
+		bucket.give(now, n)
+	}
+}
+
+func (l *RateLimiter) prune(now time.Time) {
+	for session, bucket := range l.sessions {
+		if bucket.refill(now); bucket.tokens >= float64(bucket.limit.Burst) {
+			delete(l.sessions, session)
+		}
+	}
+}
+
+type tokenBucket struct {
+	updated time.Time
+	limit   RateLimit
+	tokens  float64
+}
+
+func newTokenBucket(limit RateLimit, now time.Time) *tokenBucket {
+	return &tokenBucket{updated: now, limit: limit, tokens: float64(limit.Burst)}
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	if now.After(b.updated) {
+		b.tokens = min(float64(b.limit.Burst), b.tokens+now.Sub(b.updated).Seconds()*b.rate())
+	}
+
+	b.updated = now
+}
+
+func (b *tokenBucket) give(now time.Time, n int) {
+	b.refill(now)
+	b.tokens = min(float64(b.limit.Burst), b.tokens+float64(n))
+}
+
+// wait is how long until the bucket holds n tokens.
+func (b *tokenBucket) wait(now time.Time, n int) time.Duration {
+	b.refill(now)
+
+	missing := float64(n) - b.tokens
+	if missing <= 0 {
+		return 0
+	}
+
+	// Whole seconds, rounded up, so that retrying at the reported time is never a moment too early.
+	return time.Duration(math.Ceil(missing/b.rate())) * time.Second
+}
+
+// rate is the refill speed in tokens per second.
+func (b *tokenBucket) rate() float64 {
+	return float64(b.limit.Burst) / b.limit.Per.Seconds()
+}
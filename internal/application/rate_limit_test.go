@@ -0,0 +1,160 @@
+package application
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	limit, err := ParseRateLimit(" 30 / 10m ")
+	if err != nil {
+		t.Fatalf("ParseRateLimit() error = %v", err)
+	}
+
+	if limit.Burst != 30 || limit.Per != 10*time.Minute {
+		t.Fatalf("limit = %+v, want 30 per 10m", limit)
+	}
+
+	if limit, err := ParseRateLimit(""); err != nil || limit.enabled() {
+		t.Fatalf("ParseRateLimit(\"\") = %+v, %v; want disabled", limit, err)
+	}
+
+	for _, value := range []string{"30", "x/1m", "30/soon", "0/1m", "5/0s", "-1/1m"} {
+		if _, err := ParseRateLimit(value); !errors.Is(err, ErrInvalidRateLimit) {
+			t.Fatalf("ParseRateLimit(%q) error = %v, want %v", value, err, ErrInvalidRateLimit)
+		}
+	}
+}
+
+func newTestRateLimiter(global, session RateLimit) (*RateLimiter, *time.Time) {
+	limiter := NewRateLimiter(global, session)
+	now := testNow
+	limiter.now = func() time.Time { return now }
+
+	return limiter, &now
+}
+
+func assertRateLimited(t *testing.T, err error, scope string, retryAt time.Time) {
+	t.Helper()
+
+	var limitErr *RateLimitError
+	if !errors.As(err, &limitErr) || !errors.Is(err, ErrSendRateLimited) {
+		t.Fatalf("error = %v, want %v", err, ErrSendRateLimited)
+	}
+
+	if limitErr.Scope != scope || !limitErr.RetryAt.Equal(retryAt) {
+		t.Fatalf("rejected by %s until %v, want %s until %v", limitErr.Scope, limitErr.RetryAt, scope, retryAt)
+	}
+}
+
+func TestRateLimiter_Session(t *testing.T) {
+	limiter, now := newTestRateLimiter(RateLimit{}, RateLimit{Burst: 2, Per: time.Minute})
+
+	for range 2 {
+		if err := limiter.Allow("a", 1); err != nil {
+			t.Fatalf("Allow() error = %v", err)
+		}
+	}
+
+	// One notification comes back every 30 seconds.
+	assertRateLimited(t, limiter.Allow("a", 1), RateScopeSession, testNow.Add(30*time.Second))
+
+	if err := limiter.Allow("b", 2); err != nil {
+		t.Fatalf("other session: Allow() error = %v", err)
+	}
+
+	*now = now.Add(30 * time.Second)
+
+	if err := limiter.Allow("a", 1); err != nil {
+		t.Fatalf("after refill: Allow() error = %v", err)
+	}
+}
+
+func TestRateLimiter_GlobalAcrossSessions(t *testing.T) {
+	limiter, _ := newTestRateLimiter(RateLimit{Burst: 3, Per: time.Hour}, RateLimit{Burst: 2, Per: time.Minute})
+
+	if err := limiter.Allow("a", 2); err != nil {
+		t.Fatalf("Allow() error = %v", err)
+	}
+
+	assertRateLimited(t, limiter.Allow("b", 2), RateScopeGlobal, testNow.Add(20*time.Minute))
+
+	// The rejected call took nothing, from neither bucket.
+	if err := limiter.Allow("b", 1); err != nil {
+		t.Fatalf("Allow() error = %v", err)
+	}
+}
+
+func TestRateLimiter_LaterScopeWins(t *testing.T) {
+	limiter, _ := newTestRateLimiter(RateLimit{Burst: 2, Per: time.Minute}, RateLimit{Burst: 2, Per: time.Hour})
+
+	if err := limiter.Allow("a", 2); err != nil {
+		t.Fatalf("Allow() error = %v", err)
+	}
+
+	assertRateLimited(t, limiter.Allow("a", 1), RateScopeSession, testNow.Add(30*time.Minute))
+}
+
+func TestRateLimiter_OverBurst(t *testing.T) {
+	limiter, _ := newTestRateLimiter(RateLimit{Burst: 5, Per: time.Minute}, RateLimit{})
+
+	if err := limiter.Allow("a", 6); !errors.Is(err, ErrRateLimitBurst) {
+		t.Fatalf("Allow() error = %v, want %v", err, ErrRateLimitBurst)
+	}
+
+	if err := limiter.Allow("a", 5); err != nil {
+		t.Fatalf("Allow() error = %v", err)
+	}
+}
+
+func TestRateLimiter_DropsRefilledSessions(t *testing.T) {
+	limiter, now := newTestRateLimiter(RateLimit{}, RateLimit{Burst: 2, Per: time.Minute})
+
+	for _, session := range []string{"a", "b"} {
+		if err := limiter.Allow(session, 1); err != nil {
+			t.Fatalf("Allow() error = %v", err)
+		}
+	}
+
+	*now = now.Add(time.Minute)
+
+	if err := limiter.Allow("c", 1); err != nil {
+		t.Fatalf("Allow() error = %v", err)
+	}
+
+	if len(limiter.sessions) != 1 {
+		t.Fatalf("%d session buckets kept, want 1", len(limiter.sessions))
+	}
+}
+
+func TestRateLimiter_Refund(t *testing.T) {
+	limiter, _ := newTestRateLimiter(RateLimit{Burst: 5, Per: time.Hour}, RateLimit{})
+
+	// A message split into three parts takes the call's notification, then the two further parts.
+	for _, n := range []int{1, 2, 1} {
+		if err := limiter.Allow("a", n); err != nil {
+			t.Fatalf("Allow(%d) error = %v", n, err)
+		}
+	}
+
+	// The next three-part message gets its first notification but not the other two, and is not sent.
+	if err := limiter.Allow("a", 2); !errors.Is(err, ErrSendRateLimited) {
+		t.Fatalf("Allow(2) error = %v, want %v", err, ErrSendRateLimited)
+	}
+
+	limiter.Refund("a", 1)
+
+	if err := limiter.Allow("a", 2); err != nil {
+		t.Fatalf("after refund: Allow(2) error = %v", err)
+	}
+
+	// Giving back never fills a bucket past its burst.
+	limiter.Refund("a", 10)
+
+	if err := limiter.Allow("a", 5); err != nil {
+		t.Fatalf("Allow(5) error = %v", err)
+	}
+
+	assertRateLimited(t, limiter.Allow("a", 1), RateScopeGlobal, testNow.Add(12*time.Minute))
+}
@@ -0,0 +1,83 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+var (
+	ErrReceiptRequired = errors.New("receipt is required")
+	ErrTagRequired     = errors.New("tag is required")
+)
+
+// ReceiptUseCase polls and cancels emergency notifications. A receipt belongs to the application
+// that sent it, so every call names the application profile; empty is the default one.
+type ReceiptUseCase struct {
+	service domain.ReceiptService
+	apps    map[string]domain.ReceiptService
+}
+
+// NewReceiptUseCase uses service for the default application and apps for the named profiles.
+func NewReceiptUseCase(service domain.ReceiptService, apps map[string]domain.ReceiptService) *ReceiptUseCase {
+	return &ReceiptUseCase{service: service, apps: apps}
+}
+
+func (u *ReceiptUseCase) Get(ctx context.Context, app, receipt string) (domain.Receipt, error) {
+	receipt = strings.TrimSpace(receipt)
+	if receipt == "" {
+		return domain.Receipt{}, ErrReceiptRequired
+	}
+
+	service, err := appFor(u.apps, app, u.service)
+	if err != nil {
+		return domain.Receipt{}, err
+	}
+
+	result, err := service.GetReceipt(ctx, receipt)
+	if err != nil {
+		return domain.Receipt{}, fmt.Errorf("get receipt: %w", err)
+	}
+
+	return result, nil
+}
+
+func (u *ReceiptUseCase) Cancel(ctx context.Context, app, receipt string) error {
+	receipt = strings.TrimSpace(receipt)
+	if receipt == "" {
+		return ErrReceiptRequired
+	}
+
+	service, err := appFor(u.apps, app, u.service)
+	if err != nil {
+		return err
+	}
+
+	if err := service.CancelReceipt(ctx, receipt); err != nil {
+		return fmt.Errorf("cancel receipt: %w", err)
+	}
+
+	return nil
+}
+
+func (u *ReceiptUseCase) CancelByTag(ctx context.Context, app, tag string) (int, error) {
+	tag = strings.TrimSpace(tag)
+	if tag == "" {
+		return 0, ErrTagRequired
+	}
+
+	service, err := appFor(u.apps, app, u.service)
+	if err != nil {
+		return 0, err
+	}
+
+	canceled, err := service.CancelByTag(ctx, tag)
+	if err != nil {
+		return 0, fmt.Errorf("cancel by tag: %w", err)
+	}
+
+	return canceled, nil
+}
@@ -0,0 +1,147 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+type fakeReceiptService struct {
+	err      error
+	receipt  domain.Receipt
+	got      string
+	canceled int
+	called   bool
+}
+
+func (f *fakeReceiptService) GetReceipt(_ context.Context, receipt string) (domain.Receipt, error) {
+	f.called = true
+	f.got = receipt
+
+	return f.receipt, f.err
+}
+
+func (f *fakeReceiptService) CancelReceipt(_ context.Context, receipt string) error {
+	f.called = true
+	f.got = receipt
+
+	return f.err
+}
+
+func (f *fakeReceiptService) CancelByTag(_ context.Context, tag string) (int, error) {
+	f.called = true
+	f.got = tag
+
+	return f.canceled, f.err
+}
+
+func TestReceiptUseCase_Get_Success(t *testing.T) {
+	service := &fakeReceiptService{receipt: domain.Receipt{Acknowledged: true}}
+	useCase := NewReceiptUseCase(service, nil)
+
+	receipt, err := useCase.Get(context.Background(), "", "  rcpt-1 ")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	assertString(t, service.got, "rcpt-1", "receipt")
+
+	if !receipt.Acknowledged {
+		t.Fatal("receipt.Acknowledged = false, want true")
+	}
+}
+
+func TestReceiptUseCase_Get_ReceiptRequired(t *testing.T) {
+	service := &fakeReceiptService{}
+	useCase := NewReceiptUseCase(service, nil)
+
+	_, err := useCase.Get(context.Background(), "", " ")
+	if !errors.Is(err, ErrReceiptRequired) {
+		t.Fatalf("error = %v, want %v", err, ErrReceiptRequired)
+	}
+
+	if service.called {
+		t.Fatal("service.GetReceipt was called, want not called")
+	}
+}
+
+func TestReceiptUseCase_Get_ServiceError(t *testing.T) {
+	useCase := NewReceiptUseCase(&fakeReceiptService{err: errors.New("boom")}, nil)
+
+	_, err := useCase.Get(context.Background(), "", "rcpt-1")
+	if err == nil {
+		t.Fatal("Get() error = nil, want non-nil")
+	}
+}
+
+func TestReceiptUseCase_Cancel(t *testing.T) {
+	service := &fakeReceiptService{}
+	useCase := NewReceiptUseCase(service, nil)
+
+	if err := useCase.Cancel(context.Background(), "", "rcpt-1"); err != nil {
+		t.Fatalf("Cancel() error = %v", err)
+	}
+
+	assertString(t, service.got, "rcpt-1", "receipt")
+
+	service.called = false
+	if err := useCase.Cancel(context.Background(), "", ""); !errors.Is(err, ErrReceiptRequired) {
+		t.Fatalf("error = %v, want %v", err, ErrReceiptRequired)
+	}
+
+	if service.called {
+		t.Fatal("service.CancelReceipt was called, want not called")
+	}
+}
+
+func TestReceiptUseCase_CancelByTag(t *testing.T) {
+	service := &fakeReceiptService{canceled: 3}
+	useCase := NewReceiptUseCase(service, nil)
+
+	canceled, err := useCase.CancelByTag(context.Background(), "", " deploy-42 ")
+	if err != nil {
+		t.Fatalf("CancelByTag() error = %v", err)
+	}
+
+	assertString(t, service.got, "deploy-42", "tag")
+
+	if canceled != 3 {
+		t.Fatalf("canceled = %d, want 3", canceled)
+	}
+
+	if _, err := useCase.CancelByTag(context.Background(), "", " "); !errors.Is(err, ErrTagRequired) {
+		t.Fatalf("error = %v, want %v", err, ErrTagRequired)
+	}
+}
+
+func TestReceiptUseCase_App(t *testing.T) {
+	primary := &fakeReceiptService{}
+	alerts := &fakeReceiptService{canceled: 1}
+	useCase := NewReceiptUseCase(primary, map[string]domain.ReceiptService{"primary": primary, "alerts": alerts})
+
+	if _, err := useCase.Get(context.Background(), "alerts", "rcpt-1"); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	if err := useCase.Cancel(context.Background(), "alerts", "rcpt-2"); err != nil {
+		t.Fatalf("Cancel() error = %v", err)
+	}
+
+	if _, err := useCase.CancelByTag(context.Background(), "alerts", "deploy-42"); err != nil {
+		t.Fatalf("CancelByTag() error = %v", err)
+	}
+
+	assertString(t, alerts.got, "deploy-42", "tag")
+
+	if primary.called {
+		t.Fatal("primary service was called, want the alerts app")
+	}
+
+	_, err := useCase.Get(context.Background(), "ops", "rcpt-1")
+	if !errors.Is(err, ErrUnknownApp) || !strings.Contains(err.Error(), "alerts, primary") {
+		t.Fatalf("error = %v, want %v listing the apps", err, ErrUnknownApp)
+	}
+}
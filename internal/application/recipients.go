@@ -0,0 +1,27 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+type RecipientUseCase struct {
+	validator domain.RecipientValidator
+}
+
+func NewRecipientUseCase(validator domain.RecipientValidator) *RecipientUseCase {
+	return &RecipientUseCase{validator: validator}
+}
+
+// Validate checks a user or group key, and the device when set. An empty user checks the configured key.
+func (u *RecipientUseCase) Validate(ctx context.Context, user, device string) (domain.Recipient, error) {
+	recipient, err := u.validator.ValidateRecipient(ctx, strings.TrimSpace(user), strings.TrimSpace(device))
+	if err != nil {
+		return domain.Recipient{}, fmt.Errorf("validate recipient: %w", err)
+	}
+
+	return recipient, nil
+}
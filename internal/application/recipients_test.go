@@ -0,0 +1,87 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+type fakeRecipientValidator struct {
+	err       error
+	recipient domain.Recipient
+	user      string
+	device    string
+}
+
+func (f *fakeRecipientValidator) ValidateRecipient(_ context.Context, user, device string) (domain.Recipient, error) {
+	f.user = user
+	f.device = device
+
+	return f.recipient, f.err
+}
+
+type fakeDeviceCatalog struct {
+	err     error
+	devices []string
+}
+
+func (f *fakeDeviceCatalog) Devices(_ context.Context) ([]string, error) {
+	return f.devices, f.err
+}
+
+func TestRecipientUseCase_Validate(t *testing.T) {
+	validator := &fakeRecipientValidator{recipient: domain.Recipient{Devices: []string{"iphone"}}}
+
+	recipient, err := NewRecipientUseCase(validator).Validate(context.Background(), " user-1 ", " iphone ")
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	assertString(t, validator.user, "user-1", "user")
+	assertString(t, validator.device, "iphone", "device")
+
+	if len(recipient.Devices) != 1 {
+		t.Fatalf("devices = %v", recipient.Devices)
+	}
+
+	validator.err = errors.New("user key is invalid")
+	if _, err := NewRecipientUseCase(validator).Validate(context.Background(), "", ""); err == nil || !strings.Contains(err.Error(), "validate recipient") {
+		t.Fatalf("Validate() error = %v, want wrapped error", err)
+	}
+}
+
+func TestSendNotificationUseCase_Execute_Device(t *testing.T) {
+	tests := []struct {
+		name    string
+		catalog *fakeDeviceCatalog
+		device  string
+		wantErr bool
+	}{
+		{name: "known", catalog: &fakeDeviceCatalog{devices: []string{"iphone", "desktop"}}, device: "iphone"},
+		{name: "known list", catalog: &fakeDeviceCatalog{devices: []string{"iphone", "desktop"}}, device: "iphone, desktop"},
+		{name: "unknown in list", catalog: &fakeDeviceCatalog{devices: []string{"iphone"}}, device: "iphone,ipad", wantErr: true},
+		{name: "group without devices", catalog: &fakeDeviceCatalog{devices: []string{}}, device: "ipad"},
+		{name: "catalog unavailable", catalog: &fakeDeviceCatalog{err: errors.New("offline")}, device: "ipad"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			sender := &fakeSender{}
+			useCase := NewSendNotificationUseCase(sender, WithDeviceCatalog(tc.catalog))
+
+			_, err := useCase.Execute(context.Background(), domain.Notification{Message: testMessage, Device: tc.device})
+			if tc.wantErr {
+				assertValidationError(t, sender, err, ErrUnknownDevice)
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,380 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+const (
+	// recurringMisfireGrace is how late a run may still fire, e.g. after a restart; older runs are skipped.
+	recurringMisfireGrace = time.Hour
+	// recurringSession is the rate limit session recurring runs are charged to.
+	recurringSession = "recurring"
+)
+
+var (
+	ErrInvalidTimeZone     = errors.New("invalid time zone")
+	ErrCronNeverRuns       = errors.New("cron expression never matches")
+	ErrRecurringIDRequired = errors.New("recurring notification id is required")
+	ErrRecurringNotFound   = errors.New("recurring notification not found")
+	ErrTooManyRecurring    = errors.New("too many recurring notifications")
+
+	// errRunClaimed stops a run that was paused, or taken by another server, since the job was read.
+	errRunClaimed = errors.New("recurring run already claimed")
+)
+
+// RecurringLimits bounds what one call to create a job can cost; the zero value leaves jobs unlimited.
+type RecurringLimits struct {
+	// Runs, when set, is charged a notification for every run and for every further part of a split
+	// one; a run it turns down is not sent.
+	Runs *RateLimiter
+	// MaxJobs caps the stored jobs, paused ones included.
+	MaxJobs int
+}
+
+// RecurringUseCase stores cron-scheduled notifications and sends them through send when they come due.
+type RecurringUseCase struct {
+	store  domain.RecurringStore
+	send   NotificationExecutor
+	now    func() time.Time
+	wake   chan struct{}
+	limits RecurringLimits
+}
+
+func NewRecurringUseCase(store domain.RecurringStore, send NotificationExecutor, limits RecurringLimits) *RecurringUseCase {
+	return &RecurringUseCase{
+		store:  store,
+		send:   send,
+		now:    time.Now,
+		wake:   make(chan struct{}, 1),
+		limits: limits,
+	}
+}
+
+// Create validates the notification and stores a job running it on the cron expression,
+// evaluated in timeZone (an IANA name; empty means UTC).
+func (u *RecurringUseCase) Create(
+	ctx context.Context,
+	notification domain.Notification,
+	expr, timeZone string,
+) (domain.RecurringNotification, error) {
+	recurring := domain.RecurringNotification{
+		Cron:         strings.TrimSpace(expr),
+		TimeZone:     strings.TrimSpace(timeZone),
+		Notification: notification,
+	}
+
+	schedule, loc, err := parseRecurring(recurring)
+	if err != nil {
+		return domain.RecurringNotification{}, err
+	}
+
+	if err := u.send.Check(ctx, notification); err != nil {
+		return domain.RecurringNotification{}, err
+	}
+
+	now := u.now()
+
+	recurring.NextRun = schedule.next(now.In(loc))
+	if recurring.NextRun.IsZero() {
+		return domain.RecurringNotification{}, fmt.Errorf("%w: %s", ErrCronNeverRuns, recurring.Cron)
+	}
+
+	if u.limits.MaxJobs > 0 {
+		list, err := u.store.ListRecurring(ctx)
+		if err != nil {
+			return domain.RecurringNotification{}, fmt.Errorf("list recurring notifications: %w", err)
+		}
+
+		if len(list) >= u.limits.MaxJobs {
+			return domain.RecurringNotification{}, fmt.Errorf("%w: %d exist, the limit is %d; delete one first",
+				ErrTooManyRecurring, len(list), u.limits.MaxJobs)
+		}
+	}
+
+	recurring.ID = newScheduleID()
+	recurring.TimeZone = loc.String()
+	recurring.CreatedAt = now.UTC()
+
+	if err := u.store.SaveRecurring(ctx, recurring); err != nil {
+		return domain.RecurringNotification{}, fmt.Errorf("save recurring notification: %w", err)
+	}
+
+	wakeUp(u.wake)
+
+	return recurring, nil
+}
+
+// List returns the jobs by next run; paused ones come last.
+func (u *RecurringUseCase) List(ctx context.Context) ([]domain.RecurringNotification, error) {
+	list, err := u.store.ListRecurring(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list recurring notifications: %w", err)
+	}
+
+	slices.SortStableFunc(list, func(a, b domain.RecurringNotification) int {
+		switch {
+		case a.Paused != b.Paused && a.Paused:
+			return 1
+		case a.Paused != b.Paused:
+			return -1
+		default:
+			return a.NextRun.Compare(b.NextRun)
+		}
+	})
+
+	return list, nil
+}
+
+// Pause stops a job from running until it is resumed.
+func (u *RecurringUseCase) Pause(ctx context.Context, id string) (domain.RecurringNotification, error) {
+	return u.update(ctx, id, func(recurring *domain.RecurringNotification) error {
+		recurring.Paused = true
+		recurring.NextRun = time.Time{}
+
+		return nil
+	})
+}
+
+// Resume restarts a paused job from now on; runs missed while paused are not sent.
+func (u *RecurringUseCase) Resume(ctx context.Context, id string) (domain.RecurringNotification, error) {
+	recurring, err := u.update(ctx, id, func(recurring *domain.RecurringNotification) error {
+		schedule, loc, err := parseRecurring(*recurring)
+		if err != nil {
+			return err
+		}
+
+		next := schedule.next(u.now().In(loc))
+		if next.IsZero() {
+			return fmt.Errorf("%w: %s", ErrCronNeverRuns, recurring.Cron)
+		}
+
+		recurring.Paused = false
+		recurring.NextRun = next
+
+		return nil
+	})
+	if err != nil {
+		return domain.RecurringNotification{}, err
+	}
+
+	wakeUp(u.wake)
+
+	return recurring, nil
+}
+
+func (u *RecurringUseCase) Delete(ctx context.Context, id string) error {
+	if id == "" {
+		return ErrRecurringIDRequired
+	}
+
+	found, err := u.store.DeleteRecurring(ctx, id)
+	if err != nil {
+		return fmt.Errorf("delete recurring notification: %w", err)
+	}
+
+	if !found {
+		return fmt.Errorf("%w: %s", ErrRecurringNotFound, id)
+	}
+
+	return nil
+}
+
+// update applies change to the stored job and saves it in one step of the store, so a run
+// finishing late cannot undo a pause or delete.
+func (u *RecurringUseCase) update(
+	ctx context.Context,
+	id string,
+	change func(recurring *domain.RecurringNotification) error,
+) (domain.RecurringNotification, error) {
+	if id == "" {
+		return domain.RecurringNotification{}, ErrRecurringIDRequired
+	}
+
+	var changeErr error
+
+	recurring, found, err := u.store.UpdateRecurring(ctx, id, func(recurring *domain.RecurringNotification) error {
+		changeErr = change(recurring)
+		return changeErr
+	})
+
+	switch {
+	case changeErr != nil:
+		return domain.RecurringNotification{}, changeErr
+	case err != nil:
+		return domain.RecurringNotification{}, fmt.Errorf("save recurring notification: %w", err)
+	case !found:
+		return domain.RecurringNotification{}, fmt.Errorf("%w: %s", ErrRecurringNotFound, id)
+	}
+
+	return recurring, nil
+}
+
+// Run sends jobs as they come due until ctx is done. A run missed while the server was
+// stopped is sent on start if it is at most recurringMisfireGrace late, and skipped otherwise.
+// report receives send and store errors.
+func (u *RecurringUseCase) Run(ctx context.Context, report func(error)) {
+	runUntilDone(ctx, u.wake, func() time.Duration { return u.runDue(ctx, report) })
+}
+
+// runDue runs every due job and returns how long to wait for the next one.
+func (u *RecurringUseCase) runDue(ctx context.Context, report func(error)) time.Duration {
+	list, err := u.store.ListRecurring(ctx)
+	if err != nil {
+		report(fmt.Errorf("list recurring notifications: %w", err))
+		return schedulerIdle
+	}
+
+	next := schedulerIdle
+
+	for _, recurring := range list {
+		if recurring.Paused || recurring.NextRun.IsZero() {
+			continue
+		}
+
+		at := recurring.NextRun
+		if !u.now().Before(at) {
+			at = u.run(ctx, recurring, report)
+		}
+
+		if !at.IsZero() {
+			next = min(next, at.Sub(u.now()))
+		}
+	}
+
+	return next
+}
+
+// run sends one due job and moves it to its next run, which it returns.
+func (u *RecurringUseCase) run(ctx context.Context, recurring domain.RecurringNotification, report func(error)) time.Time {
+	now := u.now()
+
+	schedule, loc, err := parseRecurring(recurring)
+	if err != nil {
+		// Only a hand-edited state file gets here; pause the job rather than retry it every pass.
+		report(fmt.Errorf("recurring notification %s: %w", recurring.ID, err))
+		_, _ = u.Pause(ctx, recurring.ID)
+
+		return time.Time{}
+	}
+
+	next := schedule.next(now.In(loc))
+
+	// Moving the job on claims the run, so that servers sharing the store send it once.
+	_, err = u.update(ctx, recurring.ID, func(current *domain.RecurringNotification) error {
+		if current.Paused || !current.NextRun.Equal(recurring.NextRun) {
+			return errRunClaimed
+		}
+
+		current.NextRun = next
+		current.Paused = next.IsZero()
+
+		return nil
+	})
+
+	switch {
+	case errors.Is(err, errRunClaimed), errors.Is(err, ErrRecurringNotFound):
+		// Paused, deleted or run elsewhere since it was listed.
+		return time.Time{}
+	case err != nil:
+		report(err)
+		return time.Time{}
+	}
+
+	var sendErr error
+
+	sent := now.Sub(recurring.NextRun) <= recurringMisfireGrace
+	if sent {
+		sendErr = u.execute(ctx, recurring.Notification)
+	} else {
+		report(fmt.Errorf("skipped run of recurring notification %s due at %s", recurring.ID, recurring.NextRun.Format(time.RFC3339)))
+	}
+
+	// Shutting down is not a failed run; hand it back, so that the next start sends it if it is still
+	// within the grace period.
+	if ctx.Err() != nil {
+		_, _ = u.update(context.WithoutCancel(ctx), recurring.ID, func(current *domain.RecurringNotification) error {
+			if !current.Paused && current.NextRun.Equal(next) {
+				current.NextRun = recurring.NextRun
+			}
+
+			return nil
+		})
+
+		return time.Time{}
+	}
+
+	if sendErr != nil {
+		report(fmt.Errorf("send recurring notification %s: %w", recurring.ID, sendErr))
+	}
+
+	updated, err := u.update(ctx, recurring.ID, func(current *domain.RecurringNotification) error {
+		if sent {
+			current.LastRun = now.UTC()
+			current.LastError = ""
+		}
+
+		if sendErr != nil {
+			current.LastError = sendErr.Error()
+		}
+
+		if next.IsZero() {
+			current.LastError = ErrCronNeverRuns.Error()
+		}
+
+		return nil
+	})
+
+	switch {
+	case errors.Is(err, ErrRecurringNotFound):
+		// Deleted while it was running.
+		return time.Time{}
+	case err != nil:
+		report(err)
+		return time.Time{}
+	}
+
+	return updated.NextRun
+}
+
+// execute sends one run, charged to the runs limiter when there is one.
+func (u *RecurringUseCase) execute(ctx context.Context, notification domain.Notification) error {
+	if u.limits.Runs != nil {
+		if err := u.limits.Runs.Allow(recurringSession, 1); err != nil {
+			return err
+		}
+
+		ctx = WithRateCharge(ctx, func(n int) error {
+			err := u.limits.Runs.Allow(recurringSession, n)
+			if err != nil {
+				u.limits.Runs.Refund(recurringSession, 1)
+			}
+
+			return err
+		})
+	}
+
+	_, err := u.send.Execute(ctx, notification)
+
+	return err
+}
+
+func parseRecurring(recurring domain.RecurringNotification) (cronSchedule, *time.Location, error) {
+	schedule, err := parseCron(recurring.Cron)
+	if err != nil {
+		return cronSchedule{}, nil, err
+	}
+
+	loc, err := time.LoadLocation(recurring.TimeZone)
+	if err != nil {
+		return cronSchedule{}, nil, fmt.Errorf("%w %q: %w", ErrInvalidTimeZone, recurring.TimeZone, err)
+	}
+
+	return schedule, loc, nil
+}
@@ -0,0 +1,348 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+type memRecurringStore struct {
+	items []domain.RecurringNotification
+	mu    sync.Mutex
+}
+
+func (m *memRecurringStore) SaveRecurring(_ context.Context, recurring domain.RecurringNotification) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	index := slices.IndexFunc(m.items, func(item domain.RecurringNotification) bool { return item.ID == recurring.ID })
+	if index >= 0 {
+		m.items[index] = recurring
+	} else {
+		m.items = append(m.items, recurring)
+	}
+
+	return nil
+}
+
+func (m *memRecurringStore) DeleteRecurring(_ context.Context, id string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	before := len(m.items)
+	m.items = slices.DeleteFunc(m.items, func(item domain.RecurringNotification) bool { return item.ID == id })
+
+	return len(m.items) != before, nil
+}
+
+func (m *memRecurringStore) ListRecurring(_ context.Context) ([]domain.RecurringNotification, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return slices.Clone(m.items), nil
+}
+
+func (m *memRecurringStore) UpdateRecurring(
+	_ context.Context,
+	id string,
+	change func(recurring *domain.RecurringNotification) error,
+) (domain.RecurringNotification, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	index := slices.IndexFunc(m.items, func(item domain.RecurringNotification) bool { return item.ID == id })
+	if index < 0 {
+		return domain.RecurringNotification{}, false, nil
+	}
+
+	recurring := m.items[index]
+	if err := change(&recurring); err != nil {
+		return domain.RecurringNotification{}, true, err
+	}
+
+	m.items[index] = recurring
+
+	return recurring, true, nil
+}
+
+func newTestRecurring() (*fakeSender, *memRecurringStore, *RecurringUseCase, *time.Time) {
+	sender, send := newUseCaseWithFake()
+	store := &memRecurringStore{}
+	recurring := NewRecurringUseCase(store, send, RecurringLimits{})
+	now := testNow
+	recurring.now = func() time.Time { return now }
+
+	return sender, store, recurring, &now
+}
+
+func TestRecurringUseCase_Create(t *testing.T) {
+	sender, store, recurring, _ := newTestRecurring()
+	berlin := mustLoadLocation(t, "Europe/Berlin")
+
+	// testNow is Friday 12:00 UTC, so the next weekday 09:30 in Berlin is Monday 07:30 UTC.
+	created, err := recurring.Create(t.Context(), domain.Notification{Message: testMessage}, " 30 9 * * 1-5 ", "Europe/Berlin")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if created.ID == "" || created.Cron != "30 9 * * 1-5" || created.TimeZone != "Europe/Berlin" {
+		t.Fatalf("created = %+v", created)
+	}
+
+	if want := time.Date(2026, 5, 4, 9, 30, 0, 0, berlin); !created.NextRun.Equal(want) {
+		t.Fatalf("NextRun = %v, want %v", created.NextRun, want)
+	}
+
+	if len(store.items) != 1 || sender.called {
+		t.Fatalf("store = %+v, sender called = %v", store.items, sender.called)
+	}
+}
+
+func TestRecurringUseCase_CreateRejects(t *testing.T) {
+	tests := []struct {
+		name         string
+		notification domain.Notification
+		cron         string
+		timeZone     string
+		want         error
+	}{
+		{name: "bad cron", notification: domain.Notification{Message: testMessage}, cron: "every day", want: ErrInvalidCron},
+		{name: "bad zone", notification: domain.Notification{Message: testMessage}, cron: "@daily", timeZone: "Mars/Olympus", want: ErrInvalidTimeZone},
+		{name: "never", notification: domain.Notification{Message: testMessage}, cron: "0 0 31 2 *", want: ErrCronNeverRuns},
+		{name: "invalid notification", cron: "@daily", want: ErrMessageRequired},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, store, recurring, _ := newTestRecurring()
+
+			if _, err := recurring.Create(t.Context(), tc.notification, tc.cron, tc.timeZone); !errors.Is(err, tc.want) {
+				t.Fatalf("Create() error = %v, want %v", err, tc.want)
+			}
+
+			if len(store.items) != 0 {
+				t.Fatalf("store = %+v, want empty", store.items)
+			}
+		})
+	}
+}
+
+func TestRecurringUseCase_PauseResumeDelete(t *testing.T) {
+	_, store, recurring, now := newTestRecurring()
+
+	created, err := recurring.Create(t.Context(), domain.Notification{Message: testMessage}, "0 * * * *", "")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	paused, err := recurring.Pause(t.Context(), created.ID)
+	if err != nil || !paused.Paused || !paused.NextRun.IsZero() {
+		t.Fatalf("Pause() = %+v, %v", paused, err)
+	}
+
+	*now = now.Add(3 * time.Hour)
+
+	resumed, err := recurring.Resume(t.Context(), created.ID)
+	if err != nil || resumed.Paused || !resumed.NextRun.Equal(testNow.Add(4*time.Hour)) {
+		t.Fatalf("Resume() = %+v, %v, want next run at %v", resumed, err, testNow.Add(4*time.Hour))
+	}
+
+	if err := recurring.Delete(t.Context(), created.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	if len(store.items) != 0 {
+		t.Fatalf("store = %+v, want empty", store.items)
+	}
+
+	if _, err := recurring.Pause(t.Context(), created.ID); !errors.Is(err, ErrRecurringNotFound) {
+		t.Fatalf("Pause() error = %v, want %v", err, ErrRecurringNotFound)
+	}
+
+	if err := recurring.Delete(t.Context(), ""); !errors.Is(err, ErrRecurringIDRequired) {
+		t.Fatalf("Delete() error = %v, want %v", err, ErrRecurringIDRequired)
+	}
+}
+
+func TestRecurringUseCase_ListPausedLast(t *testing.T) {
+	_, store, recurring, _ := newTestRecurring()
+	store.items = []domain.RecurringNotification{
+		{ID: "paused", Paused: true},
+		{ID: "later", NextRun: testNow.Add(2 * time.Hour)},
+		{ID: "sooner", NextRun: testNow.Add(time.Hour)},
+	}
+
+	list, err := recurring.List(t.Context())
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	ids := make([]string, 0, len(list))
+	for _, item := range list {
+		ids = append(ids, item.ID)
+	}
+
+	if !slices.Equal(ids, []string{"sooner", "later", "paused"}) {
+		t.Fatalf("List() order = %v", ids)
+	}
+}
+
+func TestRecurringUseCase_RunDue(t *testing.T) {
+	sender, store, recurring, now := newTestRecurring()
+	store.items = []domain.RecurringNotification{
+		{ID: "due", Cron: "0 12 * * *", TimeZone: "UTC", NextRun: testNow, Notification: domain.Notification{Message: "daily"}},
+		{ID: "paused", Cron: "* * * * *", TimeZone: "UTC", Paused: true, Notification: domain.Notification{Message: "paused"}},
+	}
+
+	next := recurring.runDue(t.Context(), func(err error) { t.Errorf("report(%v)", err) })
+
+	if sender.notification.Message != "daily" {
+		t.Fatalf("sent = %+v, want daily", sender.notification)
+	}
+
+	got := store.items[0]
+	if !got.LastRun.Equal(testNow) || !got.NextRun.Equal(testNow.Add(24*time.Hour)) || got.LastError != "" {
+		t.Fatalf("job = %+v, want the next run tomorrow", got)
+	}
+
+	if next != schedulerIdle {
+		t.Fatalf("next = %v, want %v", next, schedulerIdle)
+	}
+
+	// A run more than the grace period late, e.g. after a long shutdown, is skipped.
+	sender.called = false
+	*now = testNow.Add(24*time.Hour + 2*recurringMisfireGrace)
+
+	var reported []error
+
+	recurring.runDue(t.Context(), func(err error) { reported = append(reported, err) })
+
+	if sender.called || len(reported) != 1 || !store.items[0].NextRun.Equal(testNow.Add(48*time.Hour)) {
+		t.Fatalf("sent = %v, reported = %v, job = %+v", sender.called, reported, store.items[0])
+	}
+}
+
+func TestRecurringUseCase_RunRecordsFailure(t *testing.T) {
+	sender, store, recurring, _ := newTestRecurring()
+	sender.err = fmt.Errorf("send: %w", domain.ErrInvalidRecipient)
+	store.items = []domain.RecurringNotification{
+		{ID: "due", Cron: "@hourly", TimeZone: "UTC", NextRun: testNow, Notification: domain.Notification{Message: testMessage}},
+	}
+
+	var reported []error
+
+	recurring.runDue(t.Context(), func(err error) { reported = append(reported, err) })
+
+	got := store.items[0]
+	if got.LastError == "" || got.Paused || !got.NextRun.Equal(testNow.Add(time.Hour)) {
+		t.Fatalf("job = %+v, want the error recorded and the next run scheduled", got)
+	}
+
+	if len(reported) != 1 || !errors.Is(reported[0], domain.ErrInvalidRecipient) {
+		t.Fatalf("reported = %v", reported)
+	}
+}
+
+func TestRecurringUseCase_RunClaimsOnce(t *testing.T) {
+	sender, store, first, _ := newTestRecurring()
+	second := NewRecurringUseCase(store, first.send, RecurringLimits{})
+	second.now = first.now
+	store.items = []domain.RecurringNotification{
+		{ID: "due", Cron: "@hourly", TimeZone: "UTC", NextRun: testNow, Notification: domain.Notification{Message: testMessage}},
+	}
+
+	// Both servers read the jobs before either runs them.
+	due, _ := store.ListRecurring(t.Context())
+
+	if next := first.run(t.Context(), due[0], func(err error) { t.Errorf("report(%v)", err) }); !next.Equal(testNow.Add(time.Hour)) {
+		t.Fatalf("next = %v, want in an hour", next)
+	}
+
+	sender.called = false
+	second.run(t.Context(), due[0], func(err error) { t.Errorf("report(%v)", err) })
+
+	if sender.called {
+		t.Fatal("second server sent the run again")
+	}
+}
+
+func TestRecurringUseCase_RunHandsBackOnShutdown(t *testing.T) {
+	_, store, recurring, _ := newTestRecurring()
+	store.items = []domain.RecurringNotification{
+		{ID: "due", Cron: "@hourly", TimeZone: "UTC", NextRun: testNow, Notification: domain.Notification{Message: testMessage}},
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	recurring.run(ctx, store.items[0], func(error) {})
+
+	if got := store.items[0]; !got.NextRun.Equal(testNow) || !got.LastRun.IsZero() {
+		t.Fatalf("job = %+v, want the run left due", got)
+	}
+}
+
+func TestRecurringUseCase_CreateMaxJobs(t *testing.T) {
+	_, store, recurring, _ := newTestRecurring()
+	recurring.limits.MaxJobs = 1
+
+	if _, err := recurring.Create(t.Context(), domain.Notification{Message: testMessage}, "@daily", ""); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	_, err := recurring.Create(t.Context(), domain.Notification{Message: testMessage}, "@hourly", "")
+	if !errors.Is(err, ErrTooManyRecurring) || len(store.items) != 1 {
+		t.Fatalf("error = %v, jobs = %d, want %v and one job", err, len(store.items), ErrTooManyRecurring)
+	}
+}
+
+func TestRecurringUseCase_RunRateLimited(t *testing.T) {
+	sender, store, recurring, _ := newTestRecurring()
+	recurring.limits.Runs = NewRateLimiter(RateLimit{}, RateLimit{Burst: 1, Per: time.Hour})
+	store.items = []domain.RecurringNotification{
+		{ID: "a", Cron: "@hourly", TimeZone: "UTC", NextRun: testNow, Notification: domain.Notification{Message: "first"}},
+		{ID: "b", Cron: "@hourly", TimeZone: "UTC", NextRun: testNow, Notification: domain.Notification{Message: "second"}},
+	}
+
+	var reported []error
+
+	recurring.runDue(t.Context(), func(err error) { reported = append(reported, err) })
+
+	if sender.notification.Message != "first" || len(reported) != 1 || !errors.Is(reported[0], ErrSendRateLimited) {
+		t.Fatalf("sent = %q, reported = %v; want the second run turned down", sender.notification.Message, reported)
+	}
+
+	if got := store.items[1]; got.LastError == "" || !got.NextRun.Equal(testNow.Add(time.Hour)) {
+		t.Fatalf("job = %+v, want the limit recorded and the next run scheduled", got)
+	}
+}
+
+func TestRecurringUseCase_RunRefundsRefusedSplit(t *testing.T) {
+	sender := &fakeSender{}
+	store := &memRecurringStore{}
+	limiter := NewRateLimiter(RateLimit{Burst: 2, Per: time.Hour}, RateLimit{})
+	recurring := NewRecurringUseCase(store, NewSendNotificationUseCase(sender, WithLengthPolicy(LengthSplit)),
+		RecurringLimits{Runs: limiter})
+	recurring.now = func() time.Time { return testNow }
+
+	// Three parts need three notifications; the run is turned down and its first one given back.
+	long := domain.Notification{Message: strings.Repeat("x", 2*MaxMessageLength+1)}
+	if err := recurring.execute(t.Context(), long); !errors.Is(err, ErrSendRateLimited) {
+		t.Fatalf("execute() error = %v, want %v", err, ErrSendRateLimited)
+	}
+
+	if sender.called {
+		t.Fatal("refused split message was sent")
+	}
+
+	if err := limiter.Allow(recurringSession, 2); err != nil {
+		t.Fatalf("Allow(2) error = %v, want the refused run's notification given back", err)
+	}
+}
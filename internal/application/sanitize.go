@@ -0,0 +1,198 @@
+package application
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+var (
+	tagPattern       = regexp.MustCompile(`^<(/?)([a-zA-Z][a-zA-Z0-9]*)((?:\s+[^>]*?)?)\s*(/?)>`)
+	commentPattern   = regexp.MustCompile(`^<!--[\s\S]*?(?:-->|$)`)
+	attributePattern = regexp.MustCompile(`([^\s"'>/=]+)(?:\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+)))?`)
+	colorPattern     = regexp.MustCompile(`^(#[0-9a-fA-F]{3,8}|[a-zA-Z]+)$`)
+)
+
+// allowedAttributes lists the tags Pushover renders and the one attribute each may keep.
+var allowedAttributes = map[string]string{
+	"b":    "",
+	"i":    "",
+	"u":    "",
+	"font": "color",
+	"a":    "href",
+}
+
+// Elements whose content is dropped along with the tags.
+var droppedElements = []string{"script", "style"}
+
+// sanitize cleans the message when HTML rendering is on and returns what it removed.
+func sanitize(notification domain.Notification) (domain.Notification, []string) {
+	if !notification.HTML {
+		return notification, nil
+	}
+
+	var removed []string
+
+	notification.Message, removed = sanitizeHTML(notification.Message)
+
+	return notification, removed
+}
+
+// sanitizeHTML keeps the tags and attributes Pushover supports, links only to http, https and
+// mailto URLs, and escapes any other markup. Unsupported tags are stripped, keeping their text.
+func sanitizeHTML(input string) (string, []string) {
+	var (
+		out     strings.Builder
+		removed []string
+		links   []bool // Whether each open <a> was kept, so its </a> follows suit.
+	)
+
+	report := func(format string, args ...any) {
+		if entry := fmt.Sprintf(format, args...); !slices.Contains(removed, entry) {
+			removed = append(removed, entry)
+		}
+	}
+
+	for i := 0; i < len(input); {
+		rest := input[i:]
+		if rest[0] != '<' {
+			next := strings.IndexByte(rest, '<')
+			if next < 0 {
+				next = len(rest)
+			}
+
+			out.WriteString(escapeText(rest[:next]))
+			i += next
+
+			continue
+		}
+
+		if m := commentPattern.FindString(rest); m != "" {
+			report("comment")
+			i += len(m)
+
+			continue
+		}
+
+		m := tagPattern.FindStringSubmatch(rest)
+		if m == nil {
+			out.WriteString("&lt;")
+			i++
+
+			continue
+		}
+
+		i += len(m[0])
+		closing, name, attrs := m[1] == "/", strings.ToLower(m[2]), m[3]
+
+		if slices.Contains(droppedElements, name) && !closing {
+			i += skipElement(input[i:], name)
+			report("<%s> element", name)
+
+			continue
+		}
+
+		allowed, ok := allowedAttributes[name]
+		if !ok {
+			report("<%s> tag", name)
+			continue
+		}
+
+		if closing {
+			if name == "a" && len(links) > 0 {
+				kept := links[len(links)-1]
+				links = links[:len(links)-1]
+
+				if !kept {
+					continue
+				}
+			}
+
+			out.WriteString("</" + name + ">")
+
+			continue
+		}
+
+		tag, kept := sanitizeTag(name, allowed, attrs, report)
+		if name == "a" {
+			links = append(links, kept)
+		}
+
+		if kept {
+			out.WriteString(tag)
+		}
+	}
+
+	return out.String(), removed
+}
+
+// sanitizeTag rebuilds an opening tag with its allowed attribute only. A link without an
+// acceptable href is dropped as a whole.
+func sanitizeTag(name, allowed, attrs string, report func(format string, args ...any)) (string, bool) {
+	var kept string
+
+	for _, attr := range attributePattern.FindAllStringSubmatch(attrs, -1) {
+		key := strings.ToLower(attr[1])
+		value := html.UnescapeString(attr[2] + attr[3] + attr[4])
+
+		switch {
+		case key != allowed:
+			report("%s attribute on <%s>", key, name)
+		case key == "href" && !safeURL(value):
+			report("link to %q", value)
+		case key == "color" && !colorPattern.MatchString(strings.TrimSpace(value)):
+			report("color %q", value)
+		default:
+			kept = fmt.Sprintf(` %s="%s"`, key, html.EscapeString(strings.TrimSpace(value)))
+		}
+	}
+
+	if name == "a" && kept == "" {
+		return "", false
+	}
+
+	return "<" + name + kept + ">", true
+}
+
+// safeURL allows http, https and mailto links. Browsers ignore control characters and
+// whitespace inside a scheme, so they are removed before the check.
+func safeURL(value string) bool {
+	normalized := strings.Map(func(r rune) rune {
+		if r <= ' ' || r == 0x7f {
+			return -1
+		}
+
+		return r
+	}, strings.ToLower(value))
+
+	for _, scheme := range []string{"http://", "https://", "mailto:"} {
+		if strings.HasPrefix(normalized, scheme) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// skipElement returns the length of the element content up to and including its closing tag.
+func skipElement(rest, name string) int {
+	end := strings.Index(strings.ToLower(rest), "</"+name)
+	if end < 0 {
+		return len(rest)
+	}
+
+	if closeEnd := strings.IndexByte(rest[end:], '>'); closeEnd >= 0 {
+		return end + closeEnd + 1
+	}
+
+	return len(rest)
+}
+
+// escapeText escapes stray '>' while leaving existing entities such as &amp; alone.
+func escapeText(text string) string {
+	return strings.ReplaceAll(text, ">", "&gt;")
+}
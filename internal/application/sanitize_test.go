@@ -0,0 +1,145 @@
+package application
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+func TestSanitizeHTML(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		removed []string
+	}{
+		{
+			name:  "allowed tags kept",
+			input: `<b>bold</b> <i>it</i> <u>un</u> <font color="#ff0000">red</font> <a href="https://e.com/?a=1&amp;b=2">link</a>`,
+			want:  `<b>bold</b> <i>it</i> <u>un</u> <font color="#ff0000">red</font> <a href="https://e.com/?a=1&amp;b=2">link</a>`,
+		},
+		{
+			name:  "mailto and named color",
+			input: `<A HREF='mailto:ops@example.com'>mail</A> <font color=blue>x</font>`,
+			want:  `<a href="mailto:ops@example.com">mail</a> <font color="blue">x</font>`,
+		},
+		{
+			name:    "unsupported tags stripped",
+			input:   `<div><p>Hello <span class="x">world</span></p></div>`,
+			want:    `Hello world`,
+			removed: []string{"<div> tag", "<p> tag", "<span> tag"},
+		},
+		{
+			name:    "script content dropped",
+			input:   `ok<script>alert("x")</script> <STYLE>b{}</STYLE>done`,
+			want:    `ok done`,
+			removed: []string{"<script> element", "<style> element"},
+		},
+		{
+			name:    "javascript link dropped with its closing tag",
+			input:   `<a href="javascript:alert(1)">click <b>me</b></a>`,
+			want:    `click <b>me</b>`,
+			removed: []string{`link to "javascript:alert(1)"`},
+		},
+		{
+			name:    "obfuscated scheme",
+			input:   `<a href="  java&#x09;script:alert(1)">x</a>`,
+			want:    `x`,
+			removed: []string{`link to "  java\tscript:alert(1)"`},
+		},
+		{
+			name:    "data link",
+			input:   `<a href="data:text/html;base64,PHNjcmlwdD4=">x</a>`,
+			want:    `x`,
+			removed: []string{`link to "data:text/html;base64,PHNjcmlwdD4="`},
+		},
+		{
+			name:    "disallowed attributes",
+			input:   `<b onclick="steal()">x</b> <a href="https://e.com" target="_blank">y</a> <font color="red;x:expression(1)" size="9">z</font>`,
+			want:    `<b>x</b> <a href="https://e.com">y</a> <font>z</font>`,
+			removed: []string{"onclick attribute on <b>", "target attribute on <a>", `color "red;x:expression(1)"`, "size attribute on <font>"},
+		},
+		{
+			name:    "comment",
+			input:   `a<!-- secret -->b`,
+			want:    `ab`,
+			removed: []string{"comment"},
+		},
+		{
+			name:  "stray brackets escaped",
+			input: `if a < b and c > d`,
+			want:  `if a &lt; b and c &gt; d`,
+		},
+		{
+			name:    "nested kept link inside dropped link",
+			input:   `<a href="vbscript:x">a</a><a href="https://ok.example">b</a>`,
+			want:    `a<a href="https://ok.example">b</a>`,
+			removed: []string{`link to "vbscript:x"`},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, removed := sanitizeHTML(tc.input)
+
+			assertString(t, got, tc.want, "html")
+
+			if !slices.Equal(removed, tc.removed) {
+				t.Fatalf("removed = %q, want %q", removed, tc.removed)
+			}
+		})
+	}
+}
+
+func TestExecute_SanitizesHTML(t *testing.T) {
+	sender, useCase := newUseCaseWithFake()
+
+	result, err := useCase.Execute(context.Background(), domain.Notification{
+		Message: `<b>down</b><img src="x" onerror="y">`,
+		HTML:    true,
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	assertString(t, sender.notification.Message, "<b>down</b>", "Message")
+
+	if !slices.Equal(result.Sanitized, []string{"<img> tag"}) {
+		t.Fatalf("Sanitized = %q, want [<img> tag]", result.Sanitized)
+	}
+}
+
+func TestExecute_SanitizesMarkdownLinks(t *testing.T) {
+	sender, useCase := newUseCaseWithFake()
+
+	result, err := useCase.Execute(context.Background(), domain.Notification{
+		Message:  "[docs](javascript:alert())",
+		Markdown: true,
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	assertString(t, sender.notification.Message, "docs)", "Message")
+
+	if len(result.Sanitized) != 1 {
+		t.Fatalf("Sanitized = %q, want the link reported", result.Sanitized)
+	}
+}
+
+func TestExecute_PlainTextNotSanitized(t *testing.T) {
+	sender, useCase := newUseCaseWithFake()
+
+	result, err := useCase.Execute(context.Background(), domain.Notification{Message: "<b>literal</b>"})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	assertString(t, sender.notification.Message, "<b>literal</b>", "Message")
+
+	if result.Sanitized != nil {
+		t.Fatalf("Sanitized = %q, want nil", result.Sanitized)
+	}
+}
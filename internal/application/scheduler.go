@@ -0,0 +1,281 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+const (
+	// MaxScheduleAhead is how far in the future a notification may be scheduled.
+	MaxScheduleAhead = 366 * 24 * time.Hour
+
+	maxDeliveryAttempts = 10
+	firstRetryDelay     = time.Minute
+	maxRetryDelay       = time.Hour
+	// schedulerIdle bounds how long the runner sleeps, so edits to the store and clock jumps are noticed.
+	schedulerIdle = time.Minute
+	// deliveryLease is how long a claim keeps other servers off a notification being delivered. A server
+	// that stops mid-delivery leaves the notification to be delivered again once the lease runs out.
+	deliveryLease = 10 * time.Minute
+)
+
+var (
+	ErrScheduleInPast      = errors.New("scheduled time must be in the future")
+	ErrScheduleTooFar      = errors.New("scheduled time must be within a year")
+	ErrScheduledIDRequired = errors.New("scheduled notification id is required")
+	ErrScheduledNotFound   = errors.New("scheduled notification not found")
+
+	errNotClaimed = errors.New("scheduled notification is not due or claimed by another server")
+)
+
+// SchedulerUseCase queues notifications in a persistent store and delivers them through send when due.
+type SchedulerUseCase struct {
+	store domain.ScheduleStore
+	send  *SendNotificationUseCase
+	now   func() time.Time
+	wake  chan struct{}
+	owner string // Marks this server's claims in a store shared with others
+}
+
+func NewSchedulerUseCase(store domain.ScheduleStore, send *SendNotificationUseCase) *SchedulerUseCase {
+	return &SchedulerUseCase{
+		store: store,
+		send:  send,
+		now:   time.Now,
+		wake:  make(chan struct{}, 1),
+		owner: newScheduleID(),
+	}
+}
+
+// Schedule validates the notification now and queues it for sendAt.
+func (u *SchedulerUseCase) Schedule(
+	ctx context.Context,
+	notification domain.Notification,
+	sendAt time.Time,
+) (domain.ScheduledNotification, error) {
+	now := u.now()
+
+	switch {
+	case !sendAt.After(now):
+		return domain.ScheduledNotification{}, ErrScheduleInPast
+	case sendAt.Sub(now) > MaxScheduleAhead:
+		return domain.ScheduledNotification{}, ErrScheduleTooFar
+	}
+
+	if err := u.send.Check(ctx, notification); err != nil {
+		return domain.ScheduledNotification{}, err
+	}
+
+	if err := u.send.chargeParts(ctx, notification); err != nil {
+		return domain.ScheduledNotification{}, err
+	}
+
+	scheduled := domain.ScheduledNotification{
+		ID:           newScheduleID(),
+		Notification: notification,
+		SendAt:       sendAt.UTC(),
+		CreatedAt:    now.UTC(),
+	}
+
+	if err := u.store.SaveScheduled(ctx, scheduled); err != nil {
+		return domain.ScheduledNotification{}, fmt.Errorf("save scheduled notification: %w", err)
+	}
+
+	u.notify()
+
+	return scheduled, nil
+}
+
+// List returns the queued notifications, soonest first.
+func (u *SchedulerUseCase) List(ctx context.Context) ([]domain.ScheduledNotification, error) {
+	list, err := u.store.ListScheduled(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list scheduled notifications: %w", err)
+	}
+
+	slices.SortStableFunc(list, func(a, b domain.ScheduledNotification) int {
+		return a.SendAt.Compare(b.SendAt)
+	})
+
+	return list, nil
+}
+
+func (u *SchedulerUseCase) Cancel(ctx context.Context, id string) error {
+	if id == "" {
+		return ErrScheduledIDRequired
+	}
+
+	found, err := u.store.DeleteScheduled(ctx, id)
+	if err != nil {
+		return fmt.Errorf("cancel scheduled notification: %w", err)
+	}
+
+	if !found {
+		return fmt.Errorf("%w: %s", ErrScheduledNotFound, id)
+	}
+
+	return nil
+}
+
+// Run delivers due notifications until ctx is done. Notifications that came due while the
+// server was not running are sent on the first pass. report receives delivery and store errors.
+func (u *SchedulerUseCase) Run(ctx context.Context, report func(error)) {
+	runUntilDone(ctx, u.wake, func() time.Duration { return u.deliverDue(ctx, report) })
+}
+
+func (u *SchedulerUseCase) notify() {
+	wakeUp(u.wake)
+}
+
+// runUntilDone calls step, then waits for the delay it returns or a signal on wake, until ctx is done.
+func runUntilDone(ctx context.Context, wake <-chan struct{}, step func() time.Duration) {
+	for {
+		timer := time.NewTimer(step())
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-wake:
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+// wakeUp signals a runner without blocking; one pending signal is enough.
+func wakeUp(wake chan<- struct{}) {
+	select {
+	case wake <- struct{}{}:
+	default:
+	}
+}
+
+// deliverDue sends every due notification and returns how long to wait for the next one.
+func (u *SchedulerUseCase) deliverDue(ctx context.Context, report func(error)) time.Duration {
+	list, err := u.store.ListScheduled(ctx)
+	if err != nil {
+		report(fmt.Errorf("list scheduled notifications: %w", err))
+		return schedulerIdle
+	}
+
+	next := schedulerIdle
+
+	for _, scheduled := range list {
+		now := u.now()
+
+		if scheduled.Due(now) {
+			u.deliver(ctx, scheduled, report)
+			continue
+		}
+
+		if scheduled.Failed {
+			continue
+		}
+
+		at := scheduled.SendAt
+		if !scheduled.RetryAt.IsZero() {
+			at = scheduled.RetryAt
+		}
+
+		if scheduled.ClaimedUntil.After(at) {
+			at = scheduled.ClaimedUntil
+		}
+
+		next = min(next, at.Sub(now))
+	}
+
+	return next
+}
+
+// deliver sends one notification. Temporary failures are retried with a growing delay;
+// permanent ones, or too many attempts, mark it failed.
+func (u *SchedulerUseCase) deliver(ctx context.Context, scheduled domain.ScheduledNotification, report func(error)) {
+	// Claiming marks the notification as being delivered, so that servers sharing the store send it once.
+	// It stays stored until it is sent, so that it survives a server stopping mid-delivery.
+	_, found, err := u.store.UpdateScheduled(ctx, scheduled.ID, func(stored *domain.ScheduledNotification) error {
+		now := u.now()
+		if !stored.Due(now) {
+			return errNotClaimed
+		}
+
+		stored.ClaimedBy = u.owner
+		stored.ClaimedUntil = now.Add(deliveryLease).UTC()
+
+		return nil
+	})
+
+	switch {
+	case errors.Is(err, errNotClaimed), err == nil && !found:
+		return
+	case err != nil:
+		report(fmt.Errorf("claim scheduled notification %s: %w", scheduled.ID, err))
+		return
+	}
+
+	_, sendErr := u.send.Execute(ctx, scheduled.Notification)
+
+	// The outcome is recorded even when shutting down, so that a sent notification is not sent again.
+	finish := context.WithoutCancel(ctx)
+
+	if sendErr == nil {
+		if _, err := u.store.DeleteScheduled(finish, scheduled.ID); err != nil {
+			report(fmt.Errorf("remove delivered notification %s: %w", scheduled.ID, err))
+		}
+
+		return
+	}
+
+	// Shutting down is not a delivery failure; the claim is released so that the next start tries again.
+	shuttingDown := ctx.Err() != nil
+	if !shuttingDown {
+		report(fmt.Errorf("deliver scheduled notification %s: %w", scheduled.ID, sendErr))
+	}
+
+	_, _, err = u.store.UpdateScheduled(finish, scheduled.ID, func(stored *domain.ScheduledNotification) error {
+		// After the lease ran out, another server may have taken the notification over.
+		if stored.ClaimedBy != u.owner {
+			return errNotClaimed
+		}
+
+		stored.ClaimedBy = ""
+		stored.ClaimedUntil = time.Time{}
+
+		if !shuttingDown {
+			u.recordFailure(stored, sendErr)
+		}
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, errNotClaimed) {
+		report(fmt.Errorf("save scheduled notification %s: %w", scheduled.ID, err))
+	}
+}
+
+// recordFailure schedules a retry after a temporary failure, or marks the notification failed.
+func (u *SchedulerUseCase) recordFailure(scheduled *domain.ScheduledNotification, err error) {
+	scheduled.Attempts++
+	scheduled.LastError = err.Error()
+
+	if domain.IsRetryable(err) && scheduled.Attempts < maxDeliveryAttempts {
+		delay := min(firstRetryDelay<<(scheduled.Attempts-1), maxRetryDelay)
+		scheduled.RetryAt = u.now().Add(delay).UTC()
+	} else {
+		scheduled.Failed = true
+		scheduled.RetryAt = time.Time{}
+	}
+}
+
+func newScheduleID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,375 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+var testNow = time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+type memScheduleStore struct {
+	items []domain.ScheduledNotification
+	mu    sync.Mutex
+}
+
+func (m *memScheduleStore) SaveScheduled(_ context.Context, scheduled domain.ScheduledNotification) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	index := slices.IndexFunc(m.items, func(item domain.ScheduledNotification) bool { return item.ID == scheduled.ID })
+	if index >= 0 {
+		m.items[index] = scheduled
+	} else {
+		m.items = append(m.items, scheduled)
+	}
+
+	return nil
+}
+
+func (m *memScheduleStore) DeleteScheduled(_ context.Context, id string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	before := len(m.items)
+	m.items = slices.DeleteFunc(m.items, func(item domain.ScheduledNotification) bool { return item.ID == id })
+
+	return len(m.items) != before, nil
+}
+
+func (m *memScheduleStore) ListScheduled(_ context.Context) ([]domain.ScheduledNotification, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return slices.Clone(m.items), nil
+}
+
+func (m *memScheduleStore) UpdateScheduled(
+	_ context.Context,
+	id string,
+	change func(scheduled *domain.ScheduledNotification) error,
+) (domain.ScheduledNotification, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	index := slices.IndexFunc(m.items, func(item domain.ScheduledNotification) bool { return item.ID == id })
+	if index < 0 {
+		return domain.ScheduledNotification{}, false, nil
+	}
+
+	scheduled := m.items[index]
+	if err := change(&scheduled); err != nil {
+		return domain.ScheduledNotification{}, true, err
+	}
+
+	m.items[index] = scheduled
+
+	return scheduled, true, nil
+}
+
+func newTestScheduler() (*fakeSender, *memScheduleStore, *SchedulerUseCase) {
+	sender, send := newUseCaseWithFake()
+	store := &memScheduleStore{}
+	scheduler := NewSchedulerUseCase(store, send)
+	scheduler.now = func() time.Time { return testNow }
+
+	return sender, store, scheduler
+}
+
+func TestSchedulerUseCase_Schedule(t *testing.T) {
+	sender, store, scheduler := newTestScheduler()
+
+	scheduled, err := scheduler.Schedule(t.Context(), domain.Notification{Message: testMessage}, testNow.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("Schedule() error = %v", err)
+	}
+
+	if scheduled.ID == "" || !scheduled.SendAt.Equal(testNow.Add(time.Hour)) {
+		t.Fatalf("scheduled = %+v", scheduled)
+	}
+
+	if len(store.items) != 1 || store.items[0].ID != scheduled.ID {
+		t.Fatalf("store = %+v", store.items)
+	}
+
+	if sender.called {
+		t.Fatal("sender was called before the notification was due")
+	}
+}
+
+func TestSchedulerUseCase_ScheduleRejects(t *testing.T) {
+	tests := []struct {
+		name         string
+		notification domain.Notification
+		sendAt       time.Time
+		want         error
+	}{
+		{name: "past", notification: domain.Notification{Message: testMessage}, sendAt: testNow.Add(-time.Minute), want: ErrScheduleInPast},
+		{name: "now", notification: domain.Notification{Message: testMessage}, sendAt: testNow, want: ErrScheduleInPast},
+		{name: "too far", notification: domain.Notification{Message: testMessage}, sendAt: testNow.AddDate(2, 0, 0), want: ErrScheduleTooFar},
+		{name: "invalid notification", sendAt: testNow.Add(time.Hour), want: ErrMessageRequired},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, store, scheduler := newTestScheduler()
+
+			if _, err := scheduler.Schedule(t.Context(), tc.notification, tc.sendAt); !errors.Is(err, tc.want) {
+				t.Fatalf("Schedule() error = %v, want %v", err, tc.want)
+			}
+
+			if len(store.items) != 0 {
+				t.Fatalf("store = %+v, want empty", store.items)
+			}
+		})
+	}
+}
+
+func TestSchedulerUseCase_ListSortsBySendAt(t *testing.T) {
+	_, store, scheduler := newTestScheduler()
+	store.items = []domain.ScheduledNotification{
+		{ID: "later", SendAt: testNow.Add(2 * time.Hour)},
+		{ID: "sooner", SendAt: testNow.Add(time.Hour)},
+	}
+
+	list, err := scheduler.List(t.Context())
+	if err != nil || len(list) != 2 || list[0].ID != "sooner" {
+		t.Fatalf("List() = %+v, %v", list, err)
+	}
+}
+
+func TestSchedulerUseCase_Cancel(t *testing.T) {
+	_, store, scheduler := newTestScheduler()
+	store.items = []domain.ScheduledNotification{{ID: "a1"}}
+
+	if err := scheduler.Cancel(t.Context(), "a1"); err != nil {
+		t.Fatalf("Cancel() error = %v", err)
+	}
+
+	if err := scheduler.Cancel(t.Context(), "a1"); !errors.Is(err, ErrScheduledNotFound) {
+		t.Fatalf("Cancel() error = %v, want %v", err, ErrScheduledNotFound)
+	}
+
+	if err := scheduler.Cancel(t.Context(), ""); !errors.Is(err, ErrScheduledIDRequired) {
+		t.Fatalf("Cancel() error = %v, want %v", err, ErrScheduledIDRequired)
+	}
+}
+
+func TestSchedulerUseCase_DeliverDue(t *testing.T) {
+	sender, store, scheduler := newTestScheduler()
+	store.items = []domain.ScheduledNotification{
+		{ID: "due", SendAt: testNow.Add(-time.Hour), Notification: domain.Notification{Message: "missed"}},
+		{ID: "pending", SendAt: testNow.Add(10 * time.Second), Notification: domain.Notification{Message: "later"}},
+	}
+
+	next := scheduler.deliverDue(t.Context(), func(err error) { t.Errorf("report(%v)", err) })
+
+	if sender.notification.Message != "missed" {
+		t.Fatalf("sent = %+v, want the due notification", sender.notification)
+	}
+
+	if len(store.items) != 1 || store.items[0].ID != "pending" {
+		t.Fatalf("store = %+v, want only pending", store.items)
+	}
+
+	if next != 10*time.Second {
+		t.Fatalf("next = %v, want 10s", next)
+	}
+}
+
+func TestSchedulerUseCase_DeliverClaimsOnce(t *testing.T) {
+	sender, store, first := newTestScheduler()
+	second := NewSchedulerUseCase(store, NewSendNotificationUseCase(sender))
+	store.items = []domain.ScheduledNotification{
+		{ID: "due", SendAt: testNow.Add(-time.Minute), Notification: domain.Notification{Message: "once"}},
+	}
+
+	// Both servers read the queue before either delivers.
+	due, _ := store.ListScheduled(t.Context())
+
+	first.deliver(t.Context(), due[0], func(err error) { t.Errorf("report(%v)", err) })
+
+	sender.called = false
+	second.deliver(t.Context(), due[0], func(err error) { t.Errorf("report(%v)", err) })
+
+	if sender.called {
+		t.Fatal("second server sent the notification again")
+	}
+}
+
+// hookSender calls during from inside Send.
+type hookSender struct {
+	during func()
+}
+
+func (s hookSender) Send(_ context.Context, _ domain.Notification) (domain.SendResult, error) {
+	s.during()
+
+	return domain.SendResult{}, nil
+}
+
+func TestSchedulerUseCase_DeliverKeepsClaimUntilSent(t *testing.T) {
+	store := &memScheduleStore{items: []domain.ScheduledNotification{
+		{ID: "due", SendAt: testNow.Add(-time.Minute), Notification: domain.Notification{Message: "once"}},
+	}}
+	other, otherSend := newUseCaseWithFake()
+	second := NewSchedulerUseCase(store, otherSend)
+	second.now = func() time.Time { return testNow }
+
+	var first *SchedulerUseCase
+
+	first = NewSchedulerUseCase(store, NewSendNotificationUseCase(hookSender{during: func() {
+		// A crash now must not lose the notification, and another server must not send it meanwhile.
+		if len(store.items) != 1 || store.items[0].ClaimedBy != first.owner {
+			t.Errorf("store while sending = %+v, want the claimed notification", store.items)
+		}
+
+		second.deliverDue(t.Context(), func(err error) { t.Errorf("report(%v)", err) })
+	}}))
+	first.now = func() time.Time { return testNow }
+
+	first.deliverDue(t.Context(), func(err error) { t.Errorf("report(%v)", err) })
+
+	if other.called {
+		t.Fatal("second server sent a claimed notification")
+	}
+
+	if len(store.items) != 0 {
+		t.Fatalf("store = %+v, want the delivered notification removed", store.items)
+	}
+}
+
+func TestSchedulerUseCase_DeliverAfterLease(t *testing.T) {
+	sender, store, scheduler := newTestScheduler()
+	store.items = []domain.ScheduledNotification{
+		{
+			ID: "abandoned", SendAt: testNow.Add(-time.Hour), Notification: domain.Notification{Message: "abandoned"},
+			ClaimedBy: "crashed", ClaimedUntil: testNow.Add(-time.Second),
+		},
+		{
+			ID: "sending", SendAt: testNow.Add(-time.Minute), Notification: domain.Notification{Message: "sending"},
+			ClaimedBy: "other", ClaimedUntil: testNow.Add(30 * time.Second),
+		},
+	}
+
+	next := scheduler.deliverDue(t.Context(), func(err error) { t.Errorf("report(%v)", err) })
+
+	if sender.notification.Message != "abandoned" {
+		t.Fatalf("sent = %+v, want the notification whose lease ran out", sender.notification)
+	}
+
+	if len(store.items) != 1 || store.items[0].ID != "sending" {
+		t.Fatalf("store = %+v, want only the one another server is sending", store.items)
+	}
+
+	if next != 30*time.Second {
+		t.Fatalf("next = %v, want the end of the other server's lease", next)
+	}
+}
+
+func TestSchedulerUseCase_DeliverRetriesTemporaryFailures(t *testing.T) {
+	sender, store, scheduler := newTestScheduler()
+	sender.err = fmt.Errorf("send: %w", domain.ErrUpstreamUnavailable)
+	store.items = []domain.ScheduledNotification{
+		{ID: "a1", SendAt: testNow, Notification: domain.Notification{Message: testMessage}},
+	}
+
+	var reported []error
+
+	scheduler.deliverDue(t.Context(), func(err error) { reported = append(reported, err) })
+
+	got := store.items[0]
+	if got.Failed || got.Attempts != 1 || !got.RetryAt.Equal(testNow.Add(firstRetryDelay)) || got.LastError == "" ||
+		got.ClaimedBy != "" || !got.ClaimedUntil.IsZero() {
+		t.Fatalf("scheduled = %+v, want a retry in %v", got, firstRetryDelay)
+	}
+
+	if len(reported) != 1 || !errors.Is(reported[0], domain.ErrUpstreamUnavailable) {
+		t.Fatalf("reported = %v", reported)
+	}
+
+	store.items[0].Attempts = maxDeliveryAttempts - 1
+	store.items[0].RetryAt = testNow
+
+	scheduler.deliverDue(t.Context(), func(error) {})
+
+	if got := store.items[0]; !got.Failed || !got.RetryAt.IsZero() {
+		t.Fatalf("scheduled = %+v, want failed after %d attempts", got, maxDeliveryAttempts)
+	}
+}
+
+func TestSchedulerUseCase_DeliverPermanentFailure(t *testing.T) {
+	sender, store, scheduler := newTestScheduler()
+	sender.err = fmt.Errorf("send: %w", domain.ErrInvalidRecipient)
+	store.items = []domain.ScheduledNotification{
+		{ID: "a1", SendAt: testNow, Notification: domain.Notification{Message: testMessage}},
+	}
+
+	scheduler.deliverDue(t.Context(), func(error) {})
+
+	if got := store.items[0]; !got.Failed || got.Attempts != 1 {
+		t.Fatalf("scheduled = %+v, want failed", got)
+	}
+
+	sender.called = false
+
+	scheduler.deliverDue(t.Context(), func(error) {})
+
+	if sender.called {
+		t.Fatal("failed notification was sent again")
+	}
+}
+
+type signalSender struct {
+	sent chan domain.Notification
+}
+
+func (s signalSender) Send(_ context.Context, notification domain.Notification) (domain.SendResult, error) {
+	s.sent <- notification
+
+	return domain.SendResult{}, nil
+}
+
+func TestSchedulerUseCase_Run(t *testing.T) {
+	sender := signalSender{sent: make(chan domain.Notification)}
+	store := &memScheduleStore{items: []domain.ScheduledNotification{
+		{ID: "a1", SendAt: time.Now().Add(-time.Minute), Notification: domain.Notification{Message: "missed"}},
+	}}
+	scheduler := NewSchedulerUseCase(store, NewSendNotificationUseCase(sender))
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan struct{})
+
+	go func() {
+		scheduler.Run(ctx, func(err error) { t.Errorf("report(%v)", err) })
+		close(done)
+	}()
+
+	// A notification missed while the server was stopped goes out on start.
+	if got := <-sender.sent; got.Message != "missed" {
+		t.Fatalf("sent %q, want missed", got.Message)
+	}
+
+	// A new one wakes the runner instead of waiting for the idle timer.
+	if _, err := scheduler.Schedule(t.Context(), domain.Notification{Message: "soon"}, time.Now().Add(50*time.Millisecond)); err != nil {
+		t.Fatalf("Schedule() error = %v", err)
+	}
+
+	select {
+	case got := <-sender.sent:
+		if got.Message != "soon" {
+			t.Fatalf("sent %q, want soon", got.Message)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("scheduled notification was not sent")
+	}
+
+	cancel()
+	<-done
+}
@@ -4,25 +4,141 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/adlandh/pushover-mcp/internal/domain"
 )
 
 var (
-	ErrMessageRequired  = errors.New("message is required")
-	ErrPriorityOutRange = errors.New("priority must be between -2 and 2")
+	ErrMessageRequired   = errors.New("message is required")
+	ErrPriorityOutRange  = errors.New("priority must be between -2 and 2")
+	ErrInvalidTag        = errors.New("tags must not contain commas")
+	ErrHTMLAndMonospace  = errors.New("html and monospace are mutually exclusive")
+	ErrMarkdownMonospace = errors.New("markdown format and monospace are mutually exclusive")
+	ErrTTLInvalid        = errors.New("ttl must be a positive number of seconds")
+	ErrTimestampInvalid  = errors.New("timestamp must be a positive Unix time")
+	ErrUnknownSound      = errors.New("unknown sound")
+	ErrUnknownDevice     = errors.New("unknown device")
+	ErrUnknownApp        = errors.New("unknown app")
 )
 
+// NotificationExecutor checks and sends notifications; SendNotificationUseCase,
+// QuietHoursUseCase and DigestUseCase implement it.
+type NotificationExecutor interface {
+	Execute(ctx context.Context, notification domain.Notification) (domain.SendResult, error)
+	Check(ctx context.Context, notification domain.Notification) error
+}
+
+// AppCatalog lists what an application profile accepts: the sounds of its token and the devices of its user key.
+type AppCatalog interface {
+	domain.SoundCatalog
+	domain.DeviceCatalog
+}
+
 type SendNotificationUseCase struct {
-	sender domain.NotificationSender
+	sender   domain.NotificationSender
+	sounds   domain.SoundCatalog
+	devices  domain.DeviceCatalog
+	aliases  map[string]domain.RecipientAlias
+	apps     map[string]domain.NotificationSender
+	catalogs map[string]AppCatalog
+	lengths  LengthPolicy
+}
+
+type Option func(u *SendNotificationUseCase)
+
+// WithSoundCatalog rejects notifications whose sound is not in the catalog.
+func WithSoundCatalog(sounds domain.SoundCatalog) Option {
+	return func(u *SendNotificationUseCase) {
+		u.sounds = sounds
+	}
+}
+
+// WithDeviceCatalog rejects notifications targeting a device the user key does not have.
+func WithDeviceCatalog(devices domain.DeviceCatalog) Option {
+	return func(u *SendNotificationUseCase) {
+		u.devices = devices
+	}
+}
+
+// WithRecipientAliases lets the notification's User and the fan-out recipients name an alias instead of a key.
+func WithRecipientAliases(aliases map[string]domain.RecipientAlias) Option {
+	return func(u *SendNotificationUseCase) {
+		u.aliases = aliases
+	}
+}
+
+// WithApps lets a notification pick a named application profile; the sender passed to
+// NewSendNotificationUseCase stays the default for notifications without one.
+func WithApps(apps map[string]domain.NotificationSender) Option {
+	return func(u *SendNotificationUseCase) {
+		u.apps = apps
+	}
+}
+
+// WithAppCatalogs checks the sound and device of a notification that names an application profile against
+// that profile's catalog; WithSoundCatalog and WithDeviceCatalog only apply to the default profile.
+// A profile without a catalog is not checked.
+func WithAppCatalogs(catalogs map[string]AppCatalog) Option {
+	return func(u *SendNotificationUseCase) {
+		u.catalogs = catalogs
+	}
+}
+
+func NewSendNotificationUseCase(sender domain.NotificationSender, opts ...Option) *SendNotificationUseCase {
+	u := &SendNotificationUseCase{sender: sender, lengths: LengthReject}
+	for _, opt := range opts {
+		opt(u)
+	}
+
+	return u
+}
+
+func (u *SendNotificationUseCase) Execute(ctx context.Context, notification domain.Notification) (domain.SendResult, error) {
+	notification, removed := u.prepare(notification)
+
+	if err := u.validate(ctx, notification); err != nil {
+		return domain.SendResult{}, err
+	}
+
+	result, err := u.deliver(ctx, notification)
+	if err != nil {
+		return domain.SendResult{}, fmt.Errorf("send notification: %w", err)
+	}
+
+	result.Sanitized = removed
+
+	return result, nil
+}
+
+// Check validates the notification as Execute would, without sending it.
+func (u *SendNotificationUseCase) Check(ctx context.Context, notification domain.Notification) error {
+	notification, _ = u.prepare(notification)
+
+	return u.validate(ctx, notification)
 }
 
-func NewSendNotificationUseCase(sender domain.NotificationSender) *SendNotificationUseCase {
-	return &SendNotificationUseCase{sender: sender}
+// prepare resolves the recipient alias and renders and sanitizes the message; it returns the removed HTML.
+func (u *SendNotificationUseCase) prepare(notification domain.Notification) (domain.Notification, []string) {
+	if notification.User != "" {
+		notification = u.addressTo(notification, notification.User)
+	}
+
+	return sanitize(renderMarkdown(notification))
+}
+
+// senderFor picks the application profile; validate has already rejected unknown names.
+func (u *SendNotificationUseCase) senderFor(notification domain.Notification) domain.NotificationSender {
+	if sender, ok := u.apps[notification.App]; ok {
+		return sender
+	}
+
+	return u.sender
 }
 
-func (u *SendNotificationUseCase) Execute(ctx context.Context, notification domain.Notification) error {
+func (u *SendNotificationUseCase) validate(ctx context.Context, notification domain.Notification) error {
 	if strings.TrimSpace(notification.Message) == "" {
 		return ErrMessageRequired
 	}
@@ -33,8 +149,160 @@ func (u *SendNotificationUseCase) Execute(ctx context.Context, notification doma
 		}
 	}
 
-	if err := u.sender.Send(ctx, notification); err != nil {
-		return fmt.Errorf("send notification: %w", err)
+	if notification.Markdown && notification.Monospace {
+		return ErrMarkdownMonospace
+	}
+
+	if notification.HTML && notification.Monospace {
+		return ErrHTMLAndMonospace
+	}
+
+	if notification.TTL != nil && *notification.TTL <= 0 {
+		return ErrTTLInvalid
+	}
+
+	if notification.Timestamp != nil && *notification.Timestamp <= 0 {
+		return ErrTimestampInvalid
+	}
+
+	for _, tag := range notification.Tags {
+		if strings.Contains(tag, ",") {
+			return ErrInvalidTag
+		}
+	}
+
+	if err := u.validateLengths(notification); err != nil {
+		return err
+	}
+
+	if err := validateAttachment(notification.Attachment); err != nil {
+		return err
+	}
+
+	if err := u.validateApp(notification.App); err != nil {
+		return err
+	}
+
+	sounds, devices := u.catalogsFor(notification.App)
+
+	if err := validateSound(ctx, sounds, notification.Sound); err != nil {
+		return err
+	}
+
+	// The device catalog belongs to the profile's user key, so other recipients are not checked.
+	if notification.User == "" {
+		return validateDevice(ctx, devices, notification.Device)
+	}
+
+	return nil
+}
+
+// catalogsFor picks the catalogs of the application profile the notification is sent with.
+func (u *SendNotificationUseCase) catalogsFor(app string) (domain.SoundCatalog, domain.DeviceCatalog) {
+	app = strings.TrimSpace(app)
+	if app == "" {
+		return u.sounds, u.devices
+	}
+
+	catalog, ok := u.catalogs[app]
+	if !ok {
+		return nil, nil
+	}
+
+	return catalog, catalog
+}
+
+// addressTo sets the recipient. An alias name is replaced by its key and fills in the alias defaults;
+// anything else is taken as a user or group key.
+func (u *SendNotificationUseCase) addressTo(notification domain.Notification, recipient string) domain.Notification {
+	alias, ok := u.aliases[recipient]
+	if !ok {
+		notification.User = recipient
+		return notification
+	}
+
+	notification.User = alias.Key
+
+	if strings.TrimSpace(notification.Device) == "" {
+		notification.Device = alias.Device
+	}
+
+	if strings.TrimSpace(notification.Sound) == "" {
+		notification.Sound = alias.Sound
+	}
+
+	if notification.Priority == nil {
+		notification.Priority = alias.Priority
+	}
+
+	return notification
+}
+
+// appFor picks the named profile's client from apps; an empty name means fallback, the default profile.
+func appFor[T any](apps map[string]T, app string, fallback T) (T, error) {
+	app = strings.TrimSpace(app)
+	if app == "" {
+		return fallback, nil
+	}
+
+	client, ok := apps[app]
+	if !ok {
+		var zero T
+
+		return zero, fmt.Errorf("%w %q, available: %s", ErrUnknownApp, app, strings.Join(slices.Sorted(maps.Keys(apps)), ", "))
+	}
+
+	return client, nil
+}
+
+func (u *SendNotificationUseCase) validateApp(app string) error {
+	_, err := appFor(u.apps, app, u.sender)
+
+	return err
+}
+
+// validateSound is best-effort: when the catalog cannot be loaded the sound is passed through
+// and Pushover falls back to the default sound.
+func validateSound(ctx context.Context, catalog domain.SoundCatalog, sound string) error {
+	sound = strings.TrimSpace(sound)
+	if catalog == nil || sound == "" {
+		return nil
+	}
+
+	sounds, err := catalog.Sounds(ctx)
+	if err != nil || len(sounds) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(sounds))
+	for _, s := range sounds {
+		if s.Name == sound {
+			return nil
+		}
+
+		names = append(names, s.Name)
+	}
+
+	return fmt.Errorf("%w %q, available: %s", ErrUnknownSound, sound, strings.Join(names, ", "))
+}
+
+// validateDevice is best-effort like validateSound. Group keys report no devices and are not checked.
+func validateDevice(ctx context.Context, catalog domain.DeviceCatalog, device string) error {
+	if catalog == nil || strings.TrimSpace(device) == "" {
+		return nil
+	}
+
+	devices, err := catalog.Devices(ctx)
+	if err != nil || len(devices) == 0 {
+		return nil
+	}
+
+	// Pushover accepts a comma-separated device list.
+	for name := range strings.SplitSeq(device, ",") {
+		name = strings.TrimSpace(name)
+		if name != "" && !slices.Contains(devices, name) {
+			return fmt.Errorf("%w %q, available: %s", ErrUnknownDevice, name, strings.Join(devices, ", "))
+		}
 	}
 
 	return nil
@@ -15,15 +15,16 @@ const (
 
 type fakeSender struct {
 	err          error
+	result       domain.SendResult
 	called       bool
 	notification domain.Notification
 }
 
-func (f *fakeSender) Send(_ context.Context, notification domain.Notification) error {
+func (f *fakeSender) Send(_ context.Context, notification domain.Notification) (domain.SendResult, error) {
 	f.called = true
 	f.notification = notification
 
-	return f.err
+	return f.result, f.err
 }
 
 func newUseCaseWithFake() (*fakeSender, *SendNotificationUseCase) {
@@ -71,7 +72,7 @@ func TestSendNotificationUseCase_Execute_Success(t *testing.T) {
 		Priority: &priority,
 	}
 
-	err := useCase.Execute(context.Background(), notification)
+	_, err := useCase.Execute(context.Background(), notification)
 	if err != nil {
 		t.Fatalf("Execute() error = %v", err)
 	}
@@ -88,6 +89,19 @@ func TestSendNotificationUseCase_Execute_Success(t *testing.T) {
 	assertIntPtr(t, sender.notification.Priority, 1, "priority")
 }
 
+func TestSendNotificationUseCase_Execute_ReturnsSenderResult(t *testing.T) {
+	sender, useCase := newUseCaseWithFake()
+	sender.result = domain.SendResult{Request: "req-1", Receipt: "rcpt-1"}
+
+	result, err := useCase.Execute(context.Background(), domain.Notification{Message: testMessage})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	assertString(t, result.Request, "req-1", "request")
+	assertString(t, result.Receipt, "rcpt-1", "receipt")
+}
+
 func TestSendNotificationUseCase_Execute_AllOptionalFields(t *testing.T) {
 	sender, useCase := newUseCaseWithFake()
 
@@ -106,7 +120,7 @@ func TestSendNotificationUseCase_Execute_AllOptionalFields(t *testing.T) {
 		Device:   device,
 	}
 
-	err := useCase.Execute(context.Background(), notification)
+	_, err := useCase.Execute(context.Background(), notification)
 	if err != nil {
 		t.Fatalf("Execute() error = %v", err)
 	}
@@ -123,7 +137,7 @@ func TestSendNotificationUseCase_Execute_AllOptionalFields(t *testing.T) {
 func TestSendNotificationUseCase_Execute_MessageRequired(t *testing.T) {
 	sender, useCase := newUseCaseWithFake()
 
-	err := useCase.Execute(context.Background(), domain.Notification{Message: "   "})
+	_, err := useCase.Execute(context.Background(), domain.Notification{Message: "   "})
 	assertValidationError(t, sender, err, ErrMessageRequired)
 }
 
@@ -140,7 +154,7 @@ func TestSendNotificationUseCase_Execute_PriorityOutOfRange(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			sender, useCase := newUseCaseWithFake()
 
-			err := useCase.Execute(context.Background(), domain.Notification{
+			_, err := useCase.Execute(context.Background(), domain.Notification{
 				Message:  testMessage,
 				Priority: &tc.priority,
 			})
@@ -153,7 +167,7 @@ func TestSendNotificationUseCase_Execute_SenderError(t *testing.T) {
 	sender := &fakeSender{err: errors.New("network error")}
 	useCase := NewSendNotificationUseCase(sender)
 
-	err := useCase.Execute(context.Background(), domain.Notification{Message: testMessage})
+	_, err := useCase.Execute(context.Background(), domain.Notification{Message: testMessage})
 	if err == nil {
 		t.Fatal("Execute() error = nil, want non-nil")
 	}
@@ -166,3 +180,137 @@ func TestSendNotificationUseCase_Execute_SenderError(t *testing.T) {
 		t.Fatal("sender.Send was not called")
 	}
 }
+
+func TestSendNotificationUseCase_Execute_InvalidTag(t *testing.T) {
+	sender, useCase := newUseCaseWithFake()
+
+	_, err := useCase.Execute(context.Background(), domain.Notification{
+		Message: testMessage,
+		Tags:    []string{"deploy", "a,b"},
+	})
+	assertValidationError(t, sender, err, ErrInvalidTag)
+}
+
+func TestSendNotificationUseCase_Execute_FormattingAndTimingValidation(t *testing.T) {
+	zero := 0
+	var negative int64 = -1
+
+	tests := []struct {
+		name         string
+		notification domain.Notification
+		want         error
+	}{
+		{
+			name:         "html and monospace",
+			notification: domain.Notification{Message: testMessage, HTML: true, Monospace: true},
+			want:         ErrHTMLAndMonospace,
+		},
+		{
+			name:         "zero ttl",
+			notification: domain.Notification{Message: testMessage, TTL: &zero},
+			want:         ErrTTLInvalid,
+		},
+		{
+			name:         "negative timestamp",
+			notification: domain.Notification{Message: testMessage, Timestamp: &negative},
+			want:         ErrTimestampInvalid,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			sender, useCase := newUseCaseWithFake()
+
+			_, err := useCase.Execute(context.Background(), tc.notification)
+			assertValidationError(t, sender, err, tc.want)
+		})
+	}
+}
+
+func TestExecute_App(t *testing.T) {
+	primary := &fakeSender{}
+	ci := &fakeSender{result: domain.SendResult{Request: "req-ci"}}
+	useCase := NewSendNotificationUseCase(primary, WithApps(map[string]domain.NotificationSender{
+		"default": primary,
+		"ci":      ci,
+	}))
+
+	result, err := useCase.Execute(context.Background(), domain.Notification{Message: testMessage, App: "ci"})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if !ci.called || primary.called || result.Request != "req-ci" {
+		t.Fatalf("ci called = %v, primary called = %v, result = %+v", ci.called, primary.called, result)
+	}
+
+	if _, err := useCase.Execute(context.Background(), domain.Notification{Message: testMessage}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if !primary.called {
+		t.Fatal("notification without app did not use the default sender")
+	}
+}
+
+// fakeAppCatalog is one application profile's sounds and devices.
+type fakeAppCatalog struct {
+	fakeSoundCatalog
+	fakeDeviceCatalog
+}
+
+func TestExecute_AppCatalogs(t *testing.T) {
+	primary := &fakeSender{}
+	ci := &fakeSender{}
+	useCase := NewSendNotificationUseCase(primary,
+		WithSoundCatalog(&fakeSoundCatalog{sounds: testSounds}),
+		WithDeviceCatalog(&fakeDeviceCatalog{devices: []string{"iphone"}}),
+		WithApps(map[string]domain.NotificationSender{"default": primary, "ci": ci}),
+		WithAppCatalogs(map[string]AppCatalog{
+			"ci": &fakeAppCatalog{
+				fakeSoundCatalog{sounds: []domain.Sound{{Name: "deploy"}}},
+				fakeDeviceCatalog{devices: []string{"build-box"}},
+			},
+		}),
+	)
+
+	tests := []struct {
+		name         string
+		notification domain.Notification
+		want         error
+	}{
+		{name: "app sound", notification: domain.Notification{App: "ci", Sound: "deploy"}},
+		{name: "app device", notification: domain.Notification{App: "ci", Device: "build-box"}},
+		{name: "default sound on app", notification: domain.Notification{App: "ci", Sound: "bike"}, want: ErrUnknownSound},
+		{name: "default device on app", notification: domain.Notification{App: "ci", Device: "iphone"}, want: ErrUnknownDevice},
+		{name: "app sound on default", notification: domain.Notification{Sound: "deploy"}, want: ErrUnknownSound},
+		{name: "app device on default", notification: domain.Notification{Device: "build-box"}, want: ErrUnknownDevice},
+		{name: "profile without catalog", notification: domain.Notification{App: "default", Device: "anything"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.notification.Message = testMessage
+
+			err := useCase.Check(context.Background(), tc.notification)
+			if !errors.Is(err, tc.want) {
+				t.Fatalf("Check() error = %v, want %v", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestExecute_UnknownApp(t *testing.T) {
+	sender := &fakeSender{}
+	useCase := NewSendNotificationUseCase(sender, WithApps(map[string]domain.NotificationSender{
+		"default": sender,
+		"ci":      sender,
+	}))
+
+	_, err := useCase.Execute(context.Background(), domain.Notification{Message: testMessage, App: "infra"})
+	assertValidationError(t, sender, err, ErrUnknownApp)
+
+	if !strings.Contains(err.Error(), "available: ci, default") {
+		t.Fatalf("error = %v, want available apps listed", err)
+	}
+}
@@ -0,0 +1,25 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+type SoundUseCase struct {
+	catalog domain.SoundCatalog
+}
+
+func NewSoundUseCase(catalog domain.SoundCatalog) *SoundUseCase {
+	return &SoundUseCase{catalog: catalog}
+}
+
+func (u *SoundUseCase) List(ctx context.Context) ([]domain.Sound, error) {
+	sounds, err := u.catalog.Sounds(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list sounds: %w", err)
+	}
+
+	return sounds, nil
+}
@@ -0,0 +1,72 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+type fakeSoundCatalog struct {
+	err    error
+	sounds []domain.Sound
+}
+
+func (f *fakeSoundCatalog) Sounds(_ context.Context) ([]domain.Sound, error) {
+	return f.sounds, f.err
+}
+
+var testSounds = []domain.Sound{
+	{Name: "bike", Description: "Bike"},
+	{Name: "pushover", Description: "Pushover (default)"},
+}
+
+func TestSendNotificationUseCase_Execute_Sound(t *testing.T) {
+	tests := []struct {
+		name    string
+		catalog *fakeSoundCatalog
+		sound   string
+		wantErr error
+	}{
+		{name: "known", catalog: &fakeSoundCatalog{sounds: testSounds}, sound: "bike"},
+		{name: "unknown", catalog: &fakeSoundCatalog{sounds: testSounds}, sound: "bkie", wantErr: ErrUnknownSound},
+		{name: "catalog unavailable", catalog: &fakeSoundCatalog{err: errors.New("offline")}, sound: "bkie"},
+		{name: "no sound", catalog: &fakeSoundCatalog{sounds: testSounds}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			sender := &fakeSender{}
+			useCase := NewSendNotificationUseCase(sender, WithSoundCatalog(tc.catalog))
+
+			_, err := useCase.Execute(context.Background(), domain.Notification{Message: testMessage, Sound: tc.sound})
+			if tc.wantErr != nil {
+				assertValidationError(t, sender, err, tc.wantErr)
+
+				if !strings.Contains(err.Error(), "bike, pushover") {
+					t.Fatalf("error = %q, want available sounds listed", err.Error())
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestSoundUseCase_List(t *testing.T) {
+	sounds, err := NewSoundUseCase(&fakeSoundCatalog{sounds: testSounds}).List(context.Background())
+	if err != nil || len(sounds) != 2 {
+		t.Fatalf("List() = %v, %v", sounds, err)
+	}
+
+	_, err = NewSoundUseCase(&fakeSoundCatalog{err: errors.New("offline")}).List(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "list sounds") {
+		t.Fatalf("List() error = %v, want wrapped error", err)
+	}
+}
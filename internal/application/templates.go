@@ -0,0 +1,201 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+var (
+	ErrUnknownTemplate  = errors.New("unknown template")
+	ErrTemplateData     = errors.New("template data does not fit the template")
+	ErrTemplatePriority = errors.New("template priority must render to an integer")
+)
+
+// TemplateInfo describes a template for clients: its name, purpose and the data keys it uses.
+type TemplateInfo struct {
+	Name        string
+	Description string
+	Variables   []string
+}
+
+type messageTemplate struct {
+	message  *template.Template
+	title    *template.Template
+	url      *template.Template
+	priority *template.Template
+	info     TemplateInfo
+}
+
+type TemplateUseCase struct {
+	send      NotificationExecutor
+	templates map[string]messageTemplate
+}
+
+// NewTemplateUseCase parses the templates up front so that broken definitions fail at startup.
+// Rendered notifications go through send and its validation.
+func NewTemplateUseCase(send NotificationExecutor, templates []domain.MessageTemplate) (*TemplateUseCase, error) {
+	u := &TemplateUseCase{send: send, templates: make(map[string]messageTemplate, len(templates))}
+
+	for _, t := range templates {
+		parsed, err := parseMessageTemplate(t)
+		if err != nil {
+			return nil, fmt.Errorf("parse template %q: %w", t.Name, err)
+		}
+
+		u.templates[t.Name] = parsed
+	}
+
+	return u, nil
+}
+
+// List returns the templates sorted by name.
+func (u *TemplateUseCase) List() []TemplateInfo {
+	infos := make([]TemplateInfo, 0, len(u.templates))
+	for _, t := range u.templates {
+		infos = append(infos, t.info)
+	}
+
+	slices.SortFunc(infos, func(a, b TemplateInfo) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return infos
+}
+
+// Send renders the named template with data and sends the result.
+func (u *TemplateUseCase) Send(ctx context.Context, name string, data map[string]any) (domain.SendResult, error) {
+	notification, err := u.Render(name, data)
+	if err != nil {
+		return domain.SendResult{}, err
+	}
+
+	return u.send.Execute(ctx, notification)
+}
+
+// Render fills the named template with data. Every variable the template uses must be present.
+func (u *TemplateUseCase) Render(name string, data map[string]any) (domain.Notification, error) {
+	t, ok := u.templates[name]
+	if !ok {
+		return domain.Notification{}, fmt.Errorf("%w %q", ErrUnknownTemplate, name)
+	}
+
+	if data == nil {
+		data = map[string]any{}
+	}
+
+	var (
+		notification domain.Notification
+		priority     string
+		err          error
+	)
+
+	for _, field := range []struct {
+		tmpl *template.Template
+		out  *string
+	}{
+		{t.message, &notification.Message},
+		{t.title, &notification.Title},
+		{t.url, &notification.URL},
+		{t.priority, &priority},
+	} {
+		if *field.out, err = render(field.tmpl, data); err != nil {
+			return domain.Notification{}, err
+		}
+	}
+
+	if priority = strings.TrimSpace(priority); priority != "" {
+		value, err := strconv.Atoi(priority)
+		if err != nil {
+			return domain.Notification{}, fmt.Errorf("%w, got %q", ErrTemplatePriority, priority)
+		}
+
+		notification.Priority = &value
+	}
+
+	return notification, nil
+}
+
+func render(tmpl *template.Template, data map[string]any) (string, error) {
+	var out strings.Builder
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrTemplateData, err)
+	}
+
+	return out.String(), nil
+}
+
+func parseMessageTemplate(t domain.MessageTemplate) (messageTemplate, error) {
+	parsed := messageTemplate{info: TemplateInfo{Name: t.Name, Description: t.Description}}
+	variables := map[string]bool{}
+
+	for _, field := range []struct {
+		out    **template.Template
+		name   string
+		source string
+	}{
+		{&parsed.message, "message", t.Message},
+		{&parsed.title, "title", t.Title},
+		{&parsed.url, "url", t.URL},
+		{&parsed.priority, "priority", t.Priority},
+	} {
+		tmpl, err := template.New(field.name).Option("missingkey=error").Parse(field.source)
+		if err != nil {
+			return messageTemplate{}, err
+		}
+
+		collectVariables(tmpl.Root, variables)
+		*field.out = tmpl
+	}
+
+	parsed.info.Variables = slices.Sorted(maps.Keys(variables))
+
+	return parsed, nil
+}
+
+// collectVariables records the top-level data keys ({{.service}}) a template reads.
+// Inside range and with the dot changes, so their bodies are not searched.
+func collectVariables(node parse.Node, variables map[string]bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+
+		for _, child := range n.Nodes {
+			collectVariables(child, variables)
+		}
+	case *parse.ActionNode:
+		collectVariables(n.Pipe, variables)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+
+		for _, cmd := range n.Cmds {
+			for _, arg := range cmd.Args {
+				collectVariables(arg, variables)
+			}
+		}
+	case *parse.FieldNode:
+		variables[n.Ident[0]] = true
+	case *parse.IfNode:
+		collectVariables(n.Pipe, variables)
+		collectVariables(n.List, variables)
+		collectVariables(n.ElseList, variables)
+	case *parse.RangeNode:
+		collectVariables(n.Pipe, variables)
+		collectVariables(n.ElseList, variables)
+	case *parse.WithNode:
+		collectVariables(n.Pipe, variables)
+		collectVariables(n.ElseList, variables)
+	}
+}
@@ -0,0 +1,131 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+var testTemplates = []domain.MessageTemplate{
+	{
+		Name:        "deploy",
+		Description: "Deployment finished",
+		Message:     "Deploy {{.service}} to {{.env}} finished in {{.duration}}",
+		Title:       "Deploy {{.service}}",
+		URL:         "https://ci.example.com/{{.service}}",
+		Priority:    `{{if eq .status "failed"}}1{{else}}-1{{end}}`,
+	},
+	{
+		Name:    "digest",
+		Message: "{{range .items}}- {{.name}}\n{{end}}",
+	},
+}
+
+func newTemplateUseCase(t *testing.T) (*fakeSender, *TemplateUseCase) {
+	t.Helper()
+
+	sender, send := newUseCaseWithFake()
+
+	templates, err := NewTemplateUseCase(send, testTemplates)
+	if err != nil {
+		t.Fatalf("NewTemplateUseCase() error = %v", err)
+	}
+
+	return sender, templates
+}
+
+func TestTemplateUseCase_List(t *testing.T) {
+	_, templates := newTemplateUseCase(t)
+
+	infos := templates.List()
+	if len(infos) != 2 || infos[0].Name != "deploy" || infos[1].Name != "digest" {
+		t.Fatalf("List() = %+v", infos)
+	}
+
+	if !slices.Equal(infos[0].Variables, []string{"duration", "env", "service", "status"}) {
+		t.Fatalf("deploy variables = %v", infos[0].Variables)
+	}
+
+	// Fields inside range belong to the items, not to the data object.
+	if !slices.Equal(infos[1].Variables, []string{"items"}) {
+		t.Fatalf("digest variables = %v", infos[1].Variables)
+	}
+}
+
+func TestTemplateUseCase_Send(t *testing.T) {
+	sender, templates := newTemplateUseCase(t)
+
+	_, err := templates.Send(context.Background(), "deploy", map[string]any{
+		"service":  "api",
+		"env":      "prod",
+		"duration": "2m",
+		"status":   "failed",
+	})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	assertString(t, sender.notification.Message, "Deploy api to prod finished in 2m", "Message")
+	assertString(t, sender.notification.Title, "Deploy api", "Title")
+	assertString(t, sender.notification.URL, "https://ci.example.com/api", "URL")
+	assertIntPtr(t, sender.notification.Priority, 1, "Priority")
+}
+
+func TestTemplateUseCase_EmptyPriorityIsDefault(t *testing.T) {
+	sender, send := newUseCaseWithFake()
+
+	templates, err := NewTemplateUseCase(send, []domain.MessageTemplate{{Name: "ping", Message: "pong"}})
+	if err != nil {
+		t.Fatalf("NewTemplateUseCase() error = %v", err)
+	}
+
+	if _, err := templates.Send(context.Background(), "ping", nil); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	if sender.notification.Priority != nil {
+		t.Fatalf("Priority = %v, want nil", *sender.notification.Priority)
+	}
+}
+
+func TestTemplateUseCase_Errors(t *testing.T) {
+	tests := []struct {
+		want error
+		data map[string]any
+		name string
+	}{
+		{name: "unknown", want: ErrUnknownTemplate},
+		{name: "deploy", data: map[string]any{"service": "api"}, want: ErrTemplateData},
+	}
+
+	for _, tc := range tests {
+		sender, templates := newTemplateUseCase(t)
+
+		_, err := templates.Send(context.Background(), tc.name, tc.data)
+		assertValidationError(t, sender, err, tc.want)
+	}
+}
+
+func TestTemplateUseCase_InvalidPriority(t *testing.T) {
+	sender, send := newUseCaseWithFake()
+
+	templates, err := NewTemplateUseCase(send, []domain.MessageTemplate{{Name: "x", Message: "m", Priority: "{{.level}}"}})
+	if err != nil {
+		t.Fatalf("NewTemplateUseCase() error = %v", err)
+	}
+
+	_, err = templates.Send(context.Background(), "x", map[string]any{"level": "high"})
+	assertValidationError(t, sender, err, ErrTemplatePriority)
+}
+
+func TestNewTemplateUseCase_ParseError(t *testing.T) {
+	_, send := newUseCaseWithFake()
+
+	_, err := NewTemplateUseCase(send, []domain.MessageTemplate{{Name: "broken", Message: "{{.x"}})
+	if err == nil || errors.Is(err, ErrTemplateData) {
+		t.Fatalf("error = %v, want parse error", err)
+	}
+}
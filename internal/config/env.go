@@ -1,16 +1,44 @@
 package config
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/adlandh/pushover-mcp/internal/application"
+	"github.com/adlandh/pushover-mcp/internal/domain"
 	"github.com/adlandh/pushover-mcp/internal/driven"
 	"github.com/caarlos0/env/v11"
 )
 
+// PrimaryApp names the application profile built from PUSHOVER_API_TOKEN.
+const PrimaryApp = "default"
+
 type EnvConfig struct {
-	Pushover driven.Config
-	Timeout  time.Duration
+	Recipients      map[string]domain.RecipientAlias
+	Apps            map[string]driven.Config // Profiles from PUSHOVER_APPS, without PrimaryApp
+	DefaultApp      string                   // Profile used when a notification names none; empty means PrimaryApp
+	LengthPolicy    application.LengthPolicy
+	Templates       []domain.MessageTemplate
+	StateDir        string                    // Directory for the scheduled notification queue; empty disables scheduling
+	QuietHours      *application.QuietHours   // nil unless PUSHOVER_QUIET_HOURS is set
+	DedupWindow     time.Duration             // Identical notifications within this window are sent once; 0 disables
+	IdempotencyTTL  time.Duration             // How long an idempotency key is remembered; 0 disables keys
+	RateLimit       application.RateLimit     // Notifications sent through the tools by all clients; zero disables
+	SessionLimit    application.RateLimit     // The same per client session; zero disables
+	MaxRecurring    int                       // Recurring notifications that may exist at once; 0 disables the cap
+	Digest          *application.DigestPolicy // nil unless PUSHOVER_DIGEST_PRIORITY is set
+	AttachmentDir   string                    // Only directory attachment_path may read from; empty disables it
+	Pushover        driven.Config
+	Timeout         time.Duration
+	ValidateOnStart bool
+	Group           *driven.GroupConfig // nil unless PUSHOVER_GROUP_TOOLS is enabled
 }
 
 type rawEnvConfig struct {
@@ -18,6 +46,69 @@ type rawEnvConfig struct {
 	PushoverUserKey  string        `env:"PUSHOVER_USER_KEY,notEmpty"`
 	PushoverAPIURL   string        `env:"PUSHOVER_API_URL"`
 	PushoverTimeout  time.Duration `env:"PUSHOVER_TIMEOUT" envDefault:"15s"`
+	ValidateOnStart  bool          `env:"PUSHOVER_VALIDATE_ON_START"`
+	GroupTools       bool          `env:"PUSHOVER_GROUP_TOOLS"`
+	GroupKey         string        `env:"PUSHOVER_GROUP_KEY"`
+	RetryMaxAttempts int           `env:"PUSHOVER_RETRY_MAX_ATTEMPTS" envDefault:"3"`
+	RetryBaseDelay   time.Duration `env:"PUSHOVER_RETRY_BASE_DELAY" envDefault:"500ms"`
+	RetryMaxDelay    time.Duration `env:"PUSHOVER_RETRY_MAX_DELAY" envDefault:"10s"`
+	Recipients       string        `env:"PUSHOVER_RECIPIENTS"`
+	Apps             string        `env:"PUSHOVER_APPS"`
+	DefaultApp       string        `env:"PUSHOVER_DEFAULT_APP" envDefault:"default"`
+	LengthPolicy     string        `env:"PUSHOVER_LENGTH_POLICY" envDefault:"reject"`
+	TemplatesFile    string        `env:"PUSHOVER_TEMPLATES_FILE"`
+	StateDir         string        `env:"PUSHOVER_STATE_DIR"`
+	AttachmentDir    string        `env:"PUSHOVER_ATTACHMENT_DIR"`
+	QuietHours       string        `env:"PUSHOVER_QUIET_HOURS"`
+	DedupWindow      time.Duration `env:"PUSHOVER_DEDUP_WINDOW"`
+	IdempotencyTTL   time.Duration `env:"PUSHOVER_IDEMPOTENCY_TTL" envDefault:"24h"`
+	RateLimit        string        `env:"PUSHOVER_RATE_LIMIT"`
+	SessionRateLimit string        `env:"PUSHOVER_SESSION_RATE_LIMIT"`
+	MaxRecurring     int           `env:"PUSHOVER_MAX_RECURRING" envDefault:"20"`
+	DigestPriority   *int          `env:"PUSHOVER_DIGEST_PRIORITY"`
+	DigestWindow     time.Duration `env:"PUSHOVER_DIGEST_WINDOW" envDefault:"10m"`
+	DigestMaxItems   int           `env:"PUSHOVER_DIGEST_MAX_ITEMS" envDefault:"10"`
+}
+
+// messageTemplate is one entry of the PUSHOVER_TEMPLATES_FILE JSON object, keyed by template name.
+// Priority may be a number or a template string.
+type messageTemplate struct {
+	Description string          `json:"description"`
+	Message     string          `json:"message"`
+	Title       string          `json:"title"`
+	URL         string          `json:"url"`
+	Priority    json.RawMessage `json:"priority"`
+}
+
+// quietHours is PUSHOVER_QUIET_HOURS, e.g.
+// {"time_zone": "Europe/Berlin", "action": "downgrade", "windows": [{"days": ["mon", "fri"], "start": "22:00", "end": "07:00"}]}.
+type quietHours struct {
+	Priority *int          `json:"priority"`
+	TimeZone string        `json:"time_zone"`
+	Action   string        `json:"action"`
+	Windows  []quietWindow `json:"windows"`
+}
+
+type quietWindow struct {
+	Start string   `json:"start"`
+	End   string   `json:"end"`
+	Days  []string `json:"days"`
+}
+
+// appProfile is one entry of PUSHOVER_APPS, e.g. {"ci": {"token": "azGDORePK8gMaC0QOYAMyEEuzJnyUi"}}.
+// The user key defaults to PUSHOVER_USER_KEY.
+type appProfile struct {
+	Token   string `json:"token"`
+	UserKey string `json:"user_key"`
+}
+
+// recipientAlias is one entry of PUSHOVER_RECIPIENTS, e.g.
+// {"oncall": {"key": "uQiRzpo4DXghDmr9QzzfQu27cmVRsG", "device": "phone", "priority": 1}}.
+type recipientAlias struct {
+	Priority *int   `json:"priority"`
+	Key      string `json:"key"`
+	Device   string `json:"device"`
+	Sound    string `json:"sound"`
 }
 
 func FromEnv() (EnvConfig, error) {
@@ -26,11 +117,357 @@ func FromEnv() (EnvConfig, error) {
 		return EnvConfig{}, fmt.Errorf("parse env: %w", err)
 	}
 
-	return EnvConfig{Pushover: driven.Config{
+	if raw.RetryMaxAttempts < 1 {
+		return EnvConfig{}, errors.New("PUSHOVER_RETRY_MAX_ATTEMPTS must be at least 1")
+	}
+
+	retry := driven.RetryPolicy{
+		MaxAttempts: raw.RetryMaxAttempts,
+		BaseDelay:   raw.RetryBaseDelay,
+		MaxDelay:    raw.RetryMaxDelay,
+	}
+
+	if raw.DedupWindow < 0 || raw.IdempotencyTTL < 0 {
+		return EnvConfig{}, errors.New("PUSHOVER_DEDUP_WINDOW and PUSHOVER_IDEMPOTENCY_TTL must not be negative")
+	}
+
+	if raw.MaxRecurring < 0 {
+		return EnvConfig{}, errors.New("PUSHOVER_MAX_RECURRING must not be negative")
+	}
+
+	cfg := EnvConfig{Pushover: driven.Config{
 		APIToken: raw.PushoverAPIToken,
 		UserKey:  raw.PushoverUserKey,
 		APIURL:   raw.PushoverAPIURL,
+		Retry:    retry,
 	},
-		Timeout: raw.PushoverTimeout,
-	}, nil
+		Timeout:         raw.PushoverTimeout,
+		ValidateOnStart: raw.ValidateOnStart,
+		DedupWindow:     raw.DedupWindow,
+		IdempotencyTTL:  raw.IdempotencyTTL,
+		MaxRecurring:    raw.MaxRecurring,
+	}
+
+	lengthPolicy, err := application.ParseLengthPolicy(raw.LengthPolicy)
+	if err != nil {
+		return EnvConfig{}, fmt.Errorf("PUSHOVER_LENGTH_POLICY: %w", err)
+	}
+
+	cfg.LengthPolicy = lengthPolicy
+
+	if cfg.RateLimit, err = application.ParseRateLimit(raw.RateLimit); err != nil {
+		return EnvConfig{}, fmt.Errorf("PUSHOVER_RATE_LIMIT: %w", err)
+	}
+
+	if cfg.SessionLimit, err = application.ParseRateLimit(raw.SessionRateLimit); err != nil {
+		return EnvConfig{}, fmt.Errorf("PUSHOVER_SESSION_RATE_LIMIT: %w", err)
+	}
+
+	if strings.TrimSpace(raw.Recipients) != "" {
+		recipients, err := parseRecipients(raw.Recipients)
+		if err != nil {
+			return EnvConfig{}, fmt.Errorf("parse PUSHOVER_RECIPIENTS: %w", err)
+		}
+
+		cfg.Recipients = recipients
+	}
+
+	if strings.TrimSpace(raw.Apps) != "" {
+		apps, err := parseApps(raw.Apps, cfg.Pushover)
+		if err != nil {
+			return EnvConfig{}, fmt.Errorf("parse PUSHOVER_APPS: %w", err)
+		}
+
+		cfg.Apps = apps
+	}
+
+	cfg.DefaultApp = raw.DefaultApp
+	if _, ok := cfg.Apps[cfg.DefaultApp]; !ok && cfg.DefaultApp != PrimaryApp {
+		return EnvConfig{}, fmt.Errorf("PUSHOVER_DEFAULT_APP %q is not defined in PUSHOVER_APPS", cfg.DefaultApp)
+	}
+
+	if raw.TemplatesFile != "" {
+		templates, err := loadTemplates(raw.TemplatesFile)
+		if err != nil {
+			return EnvConfig{}, fmt.Errorf("load PUSHOVER_TEMPLATES_FILE: %w", err)
+		}
+
+		cfg.Templates = templates
+	}
+
+	cfg.StateDir = strings.TrimSpace(raw.StateDir)
+
+	if raw.AttachmentDir != "" {
+		dir, err := resolveAttachmentDir(raw.AttachmentDir)
+		if err != nil {
+			return EnvConfig{}, fmt.Errorf("PUSHOVER_ATTACHMENT_DIR: %w", err)
+		}
+
+		cfg.AttachmentDir = dir
+	}
+
+	if strings.TrimSpace(raw.QuietHours) != "" {
+		quiet, err := parseQuietHours(raw.QuietHours)
+		if err != nil {
+			return EnvConfig{}, fmt.Errorf("parse PUSHOVER_QUIET_HOURS: %w", err)
+		}
+
+		cfg.QuietHours = &quiet
+	}
+
+	if raw.DigestPriority != nil {
+		digest := application.DigestPolicy{
+			Priority: *raw.DigestPriority,
+			Window:   raw.DigestWindow,
+			MaxItems: raw.DigestMaxItems,
+		}
+
+		if err := digest.Validate(); err != nil {
+			return EnvConfig{}, fmt.Errorf("PUSHOVER_DIGEST_*: %w", err)
+		}
+
+		cfg.Digest = &digest
+	}
+
+	if raw.GroupTools {
+		if raw.GroupKey == "" {
+			return EnvConfig{}, errors.New("PUSHOVER_GROUP_KEY is required when PUSHOVER_GROUP_TOOLS is enabled")
+		}
+
+		cfg.Group = &driven.GroupConfig{
+			APIToken: raw.PushoverAPIToken,
+			GroupKey: raw.GroupKey,
+			APIURL:   raw.PushoverAPIURL,
+			Retry:    retry,
+		}
+	}
+
+	return cfg, nil
+}
+
+// resolveAttachmentDir makes the directory absolute, so that relative attachment paths do not
+// depend on the working directory, and checks that it exists.
+func resolveAttachmentDir(value string) (string, error) {
+	dir, err := filepath.Abs(value)
+	if err != nil {
+		return "", err
+	}
+
+	info, err := os.Stat(dir)
+	if err != nil {
+		return "", err
+	}
+
+	if !info.IsDir() {
+		return "", fmt.Errorf("%s is not a directory", dir)
+	}
+
+	return dir, nil
+}
+
+func parseRecipients(value string) (map[string]domain.RecipientAlias, error) {
+	var parsed map[string]recipientAlias
+	if err := json.Unmarshal([]byte(value), &parsed); err != nil {
+		return nil, err
+	}
+
+	aliases := make(map[string]domain.RecipientAlias, len(parsed))
+
+	for name, alias := range parsed {
+		switch {
+		case strings.TrimSpace(name) == "" || strings.Contains(name, ","):
+			return nil, fmt.Errorf("invalid alias name %q", name)
+		case strings.TrimSpace(alias.Key) == "" || strings.Contains(alias.Key, ","):
+			return nil, fmt.Errorf("alias %q needs a single user or group key", name)
+		case alias.Priority != nil && (*alias.Priority < -2 || *alias.Priority > 2):
+			return nil, fmt.Errorf("alias %q: priority must be between -2 and 2", name)
+		}
+
+		aliases[name] = domain.RecipientAlias{
+			Key:      strings.TrimSpace(alias.Key),
+			Device:   alias.Device,
+			Sound:    alias.Sound,
+			Priority: alias.Priority,
+		}
+	}
+
+	return aliases, nil
+}
+
+// parseApps builds a client configuration per profile on top of the primary one.
+func parseApps(value string, primary driven.Config) (map[string]driven.Config, error) {
+	var parsed map[string]appProfile
+	if err := json.Unmarshal([]byte(value), &parsed); err != nil {
+		return nil, err
+	}
+
+	apps := make(map[string]driven.Config, len(parsed))
+
+	for name, profile := range parsed {
+		switch {
+		case strings.TrimSpace(name) == "":
+			return nil, errors.New("app name must not be empty")
+		case name == PrimaryApp:
+			return nil, fmt.Errorf("app name %q is reserved for PUSHOVER_API_TOKEN", PrimaryApp)
+		case strings.TrimSpace(profile.Token) == "":
+			return nil, fmt.Errorf("app %q needs a token", name)
+		}
+
+		cfg := primary
+		cfg.APIToken = strings.TrimSpace(profile.Token)
+
+		if userKey := strings.TrimSpace(profile.UserKey); userKey != "" {
+			cfg.UserKey = userKey
+		}
+
+		apps[name] = cfg
+	}
+
+	return apps, nil
+}
+
+func parseQuietHours(value string) (application.QuietHours, error) {
+	var parsed quietHours
+	if err := json.Unmarshal([]byte(value), &parsed); err != nil {
+		return application.QuietHours{}, err
+	}
+
+	// Windows are wall-clock times, so a silent UTC default would shift them by the user's offset.
+	if strings.TrimSpace(parsed.TimeZone) == "" {
+		return application.QuietHours{}, errors.New(`time_zone is required, e.g. "Europe/Berlin" or "UTC"`)
+	}
+
+	loc, err := time.LoadLocation(parsed.TimeZone)
+	if err != nil {
+		return application.QuietHours{}, fmt.Errorf("time zone: %w", err)
+	}
+
+	action := application.QuietDowngrade
+	if parsed.Action != "" {
+		if action, err = application.ParseQuietAction(parsed.Action); err != nil {
+			return application.QuietHours{}, err
+		}
+	}
+
+	priority := -1
+	if parsed.Priority != nil {
+		priority = *parsed.Priority
+	}
+
+	if priority != -1 && priority != -2 {
+		return application.QuietHours{}, errors.New("priority must be -1 or -2")
+	}
+
+	if len(parsed.Windows) == 0 {
+		return application.QuietHours{}, errors.New("at least one window is required")
+	}
+
+	windows := make([]application.QuietWindow, 0, len(parsed.Windows))
+
+	for i, w := range parsed.Windows {
+		window, err := parseQuietWindow(w)
+		if err != nil {
+			return application.QuietHours{}, fmt.Errorf("window %d: %w", i+1, err)
+		}
+
+		windows = append(windows, window)
+	}
+
+	return application.QuietHours{Location: loc, Action: action, Priority: priority, Windows: windows}, nil
+}
+
+func parseQuietWindow(w quietWindow) (application.QuietWindow, error) {
+	start, err := parseClock(w.Start)
+	if err != nil {
+		return application.QuietWindow{}, fmt.Errorf("start: %w", err)
+	}
+
+	end, err := parseClock(w.End)
+	if err != nil {
+		return application.QuietWindow{}, fmt.Errorf("end: %w", err)
+	}
+
+	days := make([]time.Weekday, 0, len(w.Days))
+
+	for _, name := range w.Days {
+		day, err := parseWeekday(name)
+		if err != nil {
+			return application.QuietWindow{}, err
+		}
+
+		days = append(days, day)
+	}
+
+	return application.QuietWindow{Days: days, Start: start, End: end}, nil
+}
+
+// parseClock turns "HH:MM" into minutes after midnight.
+func parseClock(value string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("want HH:MM, got %q", value)
+	}
+
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// parseWeekday accepts full and three-letter English day names in any case.
+func parseWeekday(value string) (time.Weekday, error) {
+	name := strings.ToLower(strings.TrimSpace(value))
+
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		full := strings.ToLower(day.String())
+		if name == full || name == full[:3] {
+			return day, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown day %q", value)
+}
+
+func loadTemplates(path string) ([]domain.MessageTemplate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var parsed map[string]messageTemplate
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return nil, err
+	}
+
+	templates := make([]domain.MessageTemplate, 0, len(parsed))
+
+	for _, name := range slices.Sorted(maps.Keys(parsed)) {
+		t := parsed[name]
+
+		switch {
+		case strings.TrimSpace(name) == "":
+			return nil, errors.New("template name must not be empty")
+		case strings.TrimSpace(t.Message) == "":
+			return nil, fmt.Errorf("template %q needs a message", name)
+		}
+
+		priority := strings.TrimSpace(string(t.Priority))
+		if priority == "null" {
+			priority = ""
+		}
+
+		if strings.HasPrefix(priority, `"`) {
+			if err := json.Unmarshal(t.Priority, &priority); err != nil {
+				return nil, fmt.Errorf("template %q priority: %w", name, err)
+			}
+		}
+
+		templates = append(templates, domain.MessageTemplate{
+			Name:        name,
+			Description: t.Description,
+			Message:     t.Message,
+			Title:       t.Title,
+			URL:         t.URL,
+			Priority:    priority,
+		})
+	}
+
+	return templates, nil
 }
@@ -1,9 +1,14 @@
 package config
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/adlandh/pushover-mcp/internal/application"
+	"github.com/adlandh/pushover-mcp/internal/driven"
 )
 
 const (
@@ -70,6 +75,24 @@ func TestFromEnv_DefaultTimeout(t *testing.T) {
 	if cfg.Timeout != 15*time.Second {
 		t.Fatalf("Timeout = %v, want %v", cfg.Timeout, 15*time.Second)
 	}
+
+	if cfg.ValidateOnStart {
+		t.Fatal("ValidateOnStart = true, want false")
+	}
+}
+
+func TestFromEnv_ValidateOnStart(t *testing.T) {
+	setPushoverEnv(t, testAPIToken, testUserKey, "", "")
+	t.Setenv("PUSHOVER_VALIDATE_ON_START", "true")
+
+	cfg, err := FromEnv()
+	if err != nil {
+		t.Fatalf("FromEnv() error = %v", err)
+	}
+
+	if !cfg.ValidateOnStart {
+		t.Fatal("ValidateOnStart = false, want true")
+	}
 }
 
 func TestFromEnv_MissingAPIToken(t *testing.T) {
@@ -85,3 +108,574 @@ func TestFromEnv_MissingUserKey(t *testing.T) {
 	_, err := FromEnv()
 	assertParseEnvError(t, err, "PUSHOVER_USER_KEY")
 }
+
+func TestFromEnv_GroupTools(t *testing.T) {
+	setPushoverEnv(t, testAPIToken, testUserKey, testAPIURL, "")
+	t.Setenv("PUSHOVER_GROUP_TOOLS", "true")
+	t.Setenv("PUSHOVER_GROUP_KEY", "group-789")
+
+	cfg, err := FromEnv()
+	if err != nil {
+		t.Fatalf("FromEnv() error = %v", err)
+	}
+
+	if cfg.Group == nil {
+		t.Fatal("Group = nil, want config")
+	}
+
+	if cfg.Group.GroupKey != "group-789" || cfg.Group.APIToken != testAPIToken || cfg.Group.APIURL != testAPIURL {
+		t.Fatalf("Group = %+v", cfg.Group)
+	}
+}
+
+func TestFromEnv_GroupToolsDisabledByDefault(t *testing.T) {
+	setPushoverEnv(t, testAPIToken, testUserKey, "", "")
+	t.Setenv("PUSHOVER_GROUP_KEY", "group-789")
+
+	cfg, err := FromEnv()
+	if err != nil {
+		t.Fatalf("FromEnv() error = %v", err)
+	}
+
+	if cfg.Group != nil {
+		t.Fatalf("Group = %+v, want nil", cfg.Group)
+	}
+}
+
+func TestFromEnv_GroupToolsMissingKey(t *testing.T) {
+	setPushoverEnv(t, testAPIToken, testUserKey, "", "")
+	t.Setenv("PUSHOVER_GROUP_TOOLS", "true")
+	t.Setenv("PUSHOVER_GROUP_KEY", "")
+
+	_, err := FromEnv()
+	if err == nil || !strings.Contains(err.Error(), "PUSHOVER_GROUP_KEY") {
+		t.Fatalf("FromEnv() error = %v, want PUSHOVER_GROUP_KEY error", err)
+	}
+}
+
+func TestFromEnv_RetryDefaults(t *testing.T) {
+	setPushoverEnv(t, testAPIToken, testUserKey, "", "")
+
+	cfg, err := FromEnv()
+	if err != nil {
+		t.Fatalf("FromEnv() error = %v", err)
+	}
+
+	want := driven.RetryPolicy{MaxAttempts: 3, BaseDelay: 500 * time.Millisecond, MaxDelay: 10 * time.Second}
+	if cfg.Pushover.Retry != want {
+		t.Fatalf("Retry = %+v, want %+v", cfg.Pushover.Retry, want)
+	}
+}
+
+func TestFromEnv_Retry(t *testing.T) {
+	setPushoverEnv(t, testAPIToken, testUserKey, "", "")
+	t.Setenv("PUSHOVER_RETRY_MAX_ATTEMPTS", "5")
+	t.Setenv("PUSHOVER_RETRY_BASE_DELAY", "1s")
+	t.Setenv("PUSHOVER_RETRY_MAX_DELAY", "30s")
+	t.Setenv("PUSHOVER_GROUP_TOOLS", "true")
+	t.Setenv("PUSHOVER_GROUP_KEY", "group-789")
+
+	cfg, err := FromEnv()
+	if err != nil {
+		t.Fatalf("FromEnv() error = %v", err)
+	}
+
+	want := driven.RetryPolicy{MaxAttempts: 5, BaseDelay: time.Second, MaxDelay: 30 * time.Second}
+	if cfg.Pushover.Retry != want || cfg.Group.Retry != want {
+		t.Fatalf("Retry = %+v / %+v, want %+v", cfg.Pushover.Retry, cfg.Group.Retry, want)
+	}
+}
+
+func TestFromEnv_RetryInvalidMaxAttempts(t *testing.T) {
+	setPushoverEnv(t, testAPIToken, testUserKey, "", "")
+	t.Setenv("PUSHOVER_RETRY_MAX_ATTEMPTS", "0")
+
+	_, err := FromEnv()
+	if err == nil || !strings.Contains(err.Error(), "PUSHOVER_RETRY_MAX_ATTEMPTS") {
+		t.Fatalf("FromEnv() error = %v, want PUSHOVER_RETRY_MAX_ATTEMPTS error", err)
+	}
+}
+
+func TestFromEnv_Recipients(t *testing.T) {
+	setPushoverEnv(t, testAPIToken, testUserKey, "", "")
+	t.Setenv("PUSHOVER_RECIPIENTS",
+		`{"oncall": {"key": " user-oncall ", "device": "phone", "sound": "siren", "priority": 1}, "me": {"key": "user-me"}}`)
+
+	cfg, err := FromEnv()
+	if err != nil {
+		t.Fatalf("FromEnv() error = %v", err)
+	}
+
+	oncall, ok := cfg.Recipients["oncall"]
+	if !ok || oncall.Key != "user-oncall" || oncall.Device != "phone" || oncall.Sound != "siren" ||
+		oncall.Priority == nil || *oncall.Priority != 1 {
+		t.Fatalf("oncall = %+v", oncall)
+	}
+
+	if me := cfg.Recipients["me"]; me.Key != "user-me" || me.Priority != nil {
+		t.Fatalf("me = %+v", me)
+	}
+}
+
+func TestFromEnv_NoRecipients(t *testing.T) {
+	setPushoverEnv(t, testAPIToken, testUserKey, "", "")
+
+	cfg, err := FromEnv()
+	if err != nil {
+		t.Fatalf("FromEnv() error = %v", err)
+	}
+
+	if len(cfg.Recipients) != 0 {
+		t.Fatalf("Recipients = %+v, want none", cfg.Recipients)
+	}
+}
+
+func TestFromEnv_InvalidRecipients(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{name: "malformed", value: `{"oncall":`, want: "unexpected end of JSON input"},
+		{name: "missing key", value: `{"oncall": {"device": "phone"}}`, want: `alias "oncall" needs a single user or group key`},
+		{name: "key list", value: `{"team": {"key": "user-a,user-b"}}`, want: `alias "team" needs a single user or group key`},
+		{name: "priority", value: `{"oncall": {"key": "user-a", "priority": 3}}`, want: "priority must be between -2 and 2"},
+		{name: "alias name", value: `{"a,b": {"key": "user-a"}}`, want: `invalid alias name "a,b"`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			setPushoverEnv(t, testAPIToken, testUserKey, "", "")
+			t.Setenv("PUSHOVER_RECIPIENTS", tc.value)
+
+			_, err := FromEnv()
+			if err == nil || !strings.Contains(err.Error(), "PUSHOVER_RECIPIENTS") || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("FromEnv() error = %v, want %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestFromEnv_Apps(t *testing.T) {
+	setPushoverEnv(t, testAPIToken, testUserKey, testAPIURL, "")
+	t.Setenv("PUSHOVER_APPS", `{"ci": {"token": "token-ci"}, "personal": {"token": "token-me", "user_key": "user-me"}}`)
+	t.Setenv("PUSHOVER_DEFAULT_APP", "ci")
+
+	cfg, err := FromEnv()
+	if err != nil {
+		t.Fatalf("FromEnv() error = %v", err)
+	}
+
+	if cfg.DefaultApp != "ci" {
+		t.Fatalf("DefaultApp = %q, want ci", cfg.DefaultApp)
+	}
+
+	ci := cfg.Apps["ci"]
+	if ci.APIToken != "token-ci" || ci.UserKey != testUserKey || ci.APIURL != testAPIURL || ci.Retry != cfg.Pushover.Retry {
+		t.Fatalf("ci = %+v", ci)
+	}
+
+	if personal := cfg.Apps["personal"]; personal.APIToken != "token-me" || personal.UserKey != "user-me" {
+		t.Fatalf("personal = %+v", personal)
+	}
+}
+
+func TestFromEnv_DefaultAppIsPrimary(t *testing.T) {
+	setPushoverEnv(t, testAPIToken, testUserKey, "", "")
+
+	cfg, err := FromEnv()
+	if err != nil {
+		t.Fatalf("FromEnv() error = %v", err)
+	}
+
+	if cfg.DefaultApp != PrimaryApp || len(cfg.Apps) != 0 {
+		t.Fatalf("DefaultApp = %q, Apps = %+v", cfg.DefaultApp, cfg.Apps)
+	}
+}
+
+func TestFromEnv_InvalidApps(t *testing.T) {
+	tests := []struct {
+		name       string
+		apps       string
+		defaultApp string
+		want       string
+	}{
+		{name: "malformed", apps: `[]`, want: "PUSHOVER_APPS"},
+		{name: "missing token", apps: `{"ci": {}}`, want: `app "ci" needs a token`},
+		{name: "reserved name", apps: `{"default": {"token": "token-x"}}`, want: `app name "default" is reserved`},
+		{name: "unknown default", apps: `{"ci": {"token": "token-ci"}}`, defaultApp: "infra", want: `PUSHOVER_DEFAULT_APP "infra"`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			setPushoverEnv(t, testAPIToken, testUserKey, "", "")
+			t.Setenv("PUSHOVER_APPS", tc.apps)
+
+			if tc.defaultApp != "" {
+				t.Setenv("PUSHOVER_DEFAULT_APP", tc.defaultApp)
+			}
+
+			_, err := FromEnv()
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("FromEnv() error = %v, want %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestFromEnv_LengthPolicy(t *testing.T) {
+	setPushoverEnv(t, testAPIToken, testUserKey, "", "")
+
+	cfg, err := FromEnv()
+	if err != nil {
+		t.Fatalf("FromEnv() error = %v", err)
+	}
+
+	if cfg.LengthPolicy != application.LengthReject {
+		t.Fatalf("LengthPolicy = %q, want %q", cfg.LengthPolicy, application.LengthReject)
+	}
+
+	t.Setenv("PUSHOVER_LENGTH_POLICY", "split")
+
+	if cfg, err = FromEnv(); err != nil || cfg.LengthPolicy != application.LengthSplit {
+		t.Fatalf("LengthPolicy = %q, error = %v, want %q", cfg.LengthPolicy, err, application.LengthSplit)
+	}
+
+	t.Setenv("PUSHOVER_LENGTH_POLICY", "drop")
+
+	if _, err = FromEnv(); err == nil || !strings.Contains(err.Error(), "PUSHOVER_LENGTH_POLICY") {
+		t.Fatalf("FromEnv() error = %v, want PUSHOVER_LENGTH_POLICY error", err)
+	}
+}
+
+func writeTemplatesFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "templates.json")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write templates file: %v", err)
+	}
+
+	return path
+}
+
+func TestFromEnv_TemplatesFile(t *testing.T) {
+	setPushoverEnv(t, testAPIToken, testUserKey, "", "")
+	t.Setenv("PUSHOVER_TEMPLATES_FILE", writeTemplatesFile(t, `{
+		"deploy": {"description": "Deploy done", "message": "Deploy {{.service}}", "title": "CI", "url": "https://ci", "priority": "{{.p}}"},
+		"alert": {"message": "Alert", "priority": 1},
+		"note": {"message": "Note", "priority": null}
+	}`))
+
+	cfg, err := FromEnv()
+	if err != nil {
+		t.Fatalf("FromEnv() error = %v", err)
+	}
+
+	if len(cfg.Templates) != 3 {
+		t.Fatalf("Templates = %+v, want 3", cfg.Templates)
+	}
+
+	alert, deploy, note := cfg.Templates[0], cfg.Templates[1], cfg.Templates[2]
+
+	if alert.Name != "alert" || alert.Priority != "1" {
+		t.Fatalf("alert = %+v", alert)
+	}
+
+	if deploy.Name != "deploy" || deploy.Message != "Deploy {{.service}}" || deploy.Title != "CI" ||
+		deploy.URL != "https://ci" || deploy.Priority != "{{.p}}" || deploy.Description != "Deploy done" {
+		t.Fatalf("deploy = %+v", deploy)
+	}
+
+	if note.Priority != "" {
+		t.Fatalf("note priority = %q, want empty", note.Priority)
+	}
+}
+
+func TestFromEnv_InvalidTemplatesFile(t *testing.T) {
+	tests := []struct {
+		name string
+		path func(t *testing.T) string
+		want string
+	}{
+		{
+			name: "missing file",
+			path: func(t *testing.T) string { return filepath.Join(t.TempDir(), "missing.json") },
+			want: "no such file",
+		},
+		{
+			name: "malformed",
+			path: func(t *testing.T) string { return writeTemplatesFile(t, `{"deploy":`) },
+			want: "unexpected end of JSON input",
+		},
+		{
+			name: "no message",
+			path: func(t *testing.T) string { return writeTemplatesFile(t, `{"deploy": {"title": "x"}}`) },
+			want: `template "deploy" needs a message`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			setPushoverEnv(t, testAPIToken, testUserKey, "", "")
+			t.Setenv("PUSHOVER_TEMPLATES_FILE", tc.path(t))
+
+			_, err := FromEnv()
+			if err == nil || !strings.Contains(err.Error(), "PUSHOVER_TEMPLATES_FILE") || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("FromEnv() error = %v, want %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestFromEnv_StateDir(t *testing.T) {
+	setPushoverEnv(t, testAPIToken, testUserKey, "", "")
+	t.Setenv("PUSHOVER_STATE_DIR", "/var/lib/pushover-mcp")
+
+	cfg, err := FromEnv()
+	if err != nil {
+		t.Fatalf("FromEnv() error = %v", err)
+	}
+
+	if cfg.StateDir != "/var/lib/pushover-mcp" {
+		t.Fatalf("StateDir = %q, want /var/lib/pushover-mcp", cfg.StateDir)
+	}
+}
+
+func TestFromEnv_StateDirUnset(t *testing.T) {
+	setPushoverEnv(t, testAPIToken, testUserKey, "", "")
+	t.Setenv("XDG_STATE_HOME", "/state")
+	t.Setenv("HOME", "")
+
+	cfg, err := FromEnv()
+	if err != nil {
+		t.Fatalf("FromEnv() error = %v", err)
+	}
+
+	if cfg.StateDir != "" {
+		t.Fatalf("StateDir = %q, want empty to disable scheduling", cfg.StateDir)
+	}
+}
+
+func TestFromEnv_QuietHours(t *testing.T) {
+	setPushoverEnv(t, testAPIToken, testUserKey, "", "")
+	t.Setenv("PUSHOVER_QUIET_HOURS", `{
+		"time_zone": "Europe/Berlin",
+		"action": "hold",
+		"windows": [
+			{"days": ["Mon", "tuesday", "wed", "thu", "fri"], "start": "22:00", "end": "07:00"},
+			{"start": "12:30", "end": "13:00"}
+		]
+	}`)
+
+	cfg, err := FromEnv()
+	if err != nil {
+		t.Fatalf("FromEnv() error = %v", err)
+	}
+
+	quiet := cfg.QuietHours
+	if quiet == nil || quiet.Location.String() != "Europe/Berlin" || quiet.Action != application.QuietHold || quiet.Priority != -1 {
+		t.Fatalf("QuietHours = %+v", quiet)
+	}
+
+	if len(quiet.Windows) != 2 {
+		t.Fatalf("Windows = %+v, want 2", quiet.Windows)
+	}
+
+	night := quiet.Windows[0]
+	if night.Start != 22*60 || night.End != 7*60 || len(night.Days) != 5 || night.Days[1] != time.Tuesday {
+		t.Fatalf("night window = %+v", night)
+	}
+
+	if lunch := quiet.Windows[1]; lunch.Start != 12*60+30 || len(lunch.Days) != 0 {
+		t.Fatalf("lunch window = %+v", lunch)
+	}
+}
+
+func TestFromEnv_NoQuietHours(t *testing.T) {
+	setPushoverEnv(t, testAPIToken, testUserKey, "", "")
+
+	cfg, err := FromEnv()
+	if err != nil {
+		t.Fatalf("FromEnv() error = %v", err)
+	}
+
+	if cfg.QuietHours != nil {
+		t.Fatalf("QuietHours = %+v, want nil", cfg.QuietHours)
+	}
+}
+
+func TestFromEnv_InvalidQuietHours(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{name: "malformed", value: `{"windows":`, want: "unexpected end of JSON input"},
+		{name: "no time zone", value: `{"windows": [{"start": "22:00", "end": "07:00"}]}`, want: "time_zone is required"},
+		{name: "time zone", value: `{"time_zone": "Mars/Olympus", "windows": [{"start": "22:00", "end": "07:00"}]}`, want: "time zone"},
+		{name: "action", value: `{"time_zone": "UTC", "action": "mute", "windows": [{"start": "22:00", "end": "07:00"}]}`, want: "action must be"},
+		{name: "priority", value: `{"time_zone": "UTC", "priority": 0, "windows": [{"start": "22:00", "end": "07:00"}]}`, want: "priority must be -1 or -2"},
+		{name: "no windows", value: `{"time_zone": "UTC", "action": "hold"}`, want: "at least one window"},
+		{name: "clock", value: `{"time_zone": "UTC", "windows": [{"start": "10pm", "end": "07:00"}]}`, want: "window 1: start"},
+		{
+			name:  "day",
+			value: `{"time_zone": "UTC", "windows": [{"days": ["funday"], "start": "22:00", "end": "07:00"}]}`,
+			want:  `unknown day "funday"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			setPushoverEnv(t, testAPIToken, testUserKey, "", "")
+			t.Setenv("PUSHOVER_QUIET_HOURS", tc.value)
+
+			_, err := FromEnv()
+			if err == nil || !strings.Contains(err.Error(), "PUSHOVER_QUIET_HOURS") || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("FromEnv() error = %v, want %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestFromEnv_MaxRecurring(t *testing.T) {
+	setPushoverEnv(t, testAPIToken, testUserKey, "", "")
+
+	cfg, err := FromEnv()
+	if err != nil || cfg.MaxRecurring != 20 {
+		t.Fatalf("FromEnv() = %d, %v, want 20 recurring notifications", cfg.MaxRecurring, err)
+	}
+
+	t.Setenv("PUSHOVER_MAX_RECURRING", "-1")
+
+	if _, err := FromEnv(); err == nil || !strings.Contains(err.Error(), "PUSHOVER_MAX_RECURRING") {
+		t.Fatalf("FromEnv() error = %v, want negative cap rejected", err)
+	}
+}
+
+func TestFromEnv_Dedup(t *testing.T) {
+	setPushoverEnv(t, testAPIToken, testUserKey, "", "")
+
+	cfg, err := FromEnv()
+	if err != nil {
+		t.Fatalf("FromEnv() error = %v", err)
+	}
+
+	if cfg.DedupWindow != 0 || cfg.IdempotencyTTL != 24*time.Hour {
+		t.Fatalf("DedupWindow = %v, IdempotencyTTL = %v, want 0 and 24h", cfg.DedupWindow, cfg.IdempotencyTTL)
+	}
+
+	t.Setenv("PUSHOVER_DEDUP_WINDOW", "30s")
+	t.Setenv("PUSHOVER_IDEMPOTENCY_TTL", "1h")
+
+	cfg, err = FromEnv()
+	if err != nil {
+		t.Fatalf("FromEnv() error = %v", err)
+	}
+
+	if cfg.DedupWindow != 30*time.Second || cfg.IdempotencyTTL != time.Hour {
+		t.Fatalf("DedupWindow = %v, IdempotencyTTL = %v, want 30s and 1h", cfg.DedupWindow, cfg.IdempotencyTTL)
+	}
+
+	t.Setenv("PUSHOVER_DEDUP_WINDOW", "-1s")
+
+	if _, err := FromEnv(); err == nil || !strings.Contains(err.Error(), "must not be negative") {
+		t.Fatalf("FromEnv() error = %v, want negative duration rejected", err)
+	}
+}
+
+func TestFromEnv_RateLimits(t *testing.T) {
+	setPushoverEnv(t, testAPIToken, testUserKey, "", "")
+
+	cfg, err := FromEnv()
+	if err != nil {
+		t.Fatalf("FromEnv() error = %v", err)
+	}
+
+	if cfg.RateLimit.Burst != 0 || cfg.SessionLimit.Burst != 0 {
+		t.Fatalf("rate limits = %+v, %+v; want disabled", cfg.RateLimit, cfg.SessionLimit)
+	}
+
+	t.Setenv("PUSHOVER_RATE_LIMIT", "100/1h")
+	t.Setenv("PUSHOVER_SESSION_RATE_LIMIT", "20/10m")
+
+	cfg, err = FromEnv()
+	if err != nil {
+		t.Fatalf("FromEnv() error = %v", err)
+	}
+
+	if cfg.RateLimit.Burst != 100 || cfg.RateLimit.Per != time.Hour {
+		t.Fatalf("RateLimit = %+v, want 100 per 1h", cfg.RateLimit)
+	}
+
+	if cfg.SessionLimit.Burst != 20 || cfg.SessionLimit.Per != 10*time.Minute {
+		t.Fatalf("SessionRateLimit = %+v, want 20 per 10m", cfg.SessionLimit)
+	}
+
+	t.Setenv("PUSHOVER_SESSION_RATE_LIMIT", "20")
+
+	if _, err := FromEnv(); err == nil || !strings.Contains(err.Error(), "PUSHOVER_SESSION_RATE_LIMIT") {
+		t.Fatalf("FromEnv() error = %v, want PUSHOVER_SESSION_RATE_LIMIT rejected", err)
+	}
+}
+
+func TestFromEnv_Digest(t *testing.T) {
+	setPushoverEnv(t, testAPIToken, testUserKey, "", "")
+
+	cfg, err := FromEnv()
+	if err != nil {
+		t.Fatalf("FromEnv() error = %v", err)
+	}
+
+	if cfg.Digest != nil {
+		t.Fatalf("Digest = %+v, want nil", cfg.Digest)
+	}
+
+	t.Setenv("PUSHOVER_DIGEST_PRIORITY", "-1")
+
+	cfg, err = FromEnv()
+	if err != nil {
+		t.Fatalf("FromEnv() error = %v", err)
+	}
+
+	if cfg.Digest == nil || cfg.Digest.Priority != -1 || cfg.Digest.Window != 10*time.Minute || cfg.Digest.MaxItems != 10 {
+		t.Fatalf("Digest = %+v, want priority -1 with default window and size", cfg.Digest)
+	}
+
+	t.Setenv("PUSHOVER_DIGEST_PRIORITY", "2")
+
+	if _, err := FromEnv(); err == nil || !strings.Contains(err.Error(), "priority must be from -2 to 1") {
+		t.Fatalf("FromEnv() error = %v, want emergency priority rejected", err)
+	}
+}
+
+func TestFromEnv_AttachmentDir(t *testing.T) {
+	setPushoverEnv(t, testAPIToken, testUserKey, "", "")
+
+	cfg, err := FromEnv()
+	if err != nil {
+		t.Fatalf("FromEnv() error = %v", err)
+	}
+
+	if cfg.AttachmentDir != "" {
+		t.Fatalf("AttachmentDir = %q, want disabled", cfg.AttachmentDir)
+	}
+
+	dir := t.TempDir()
+	t.Setenv("PUSHOVER_ATTACHMENT_DIR", dir)
+
+	cfg, err = FromEnv()
+	if err != nil {
+		t.Fatalf("FromEnv() error = %v", err)
+	}
+
+	if cfg.AttachmentDir != dir {
+		t.Fatalf("AttachmentDir = %q, want %q", cfg.AttachmentDir, dir)
+	}
+
+	t.Setenv("PUSHOVER_ATTACHMENT_DIR", filepath.Join(dir, "missing"))
+
+	if _, err := FromEnv(); err == nil || !strings.Contains(err.Error(), "PUSHOVER_ATTACHMENT_DIR") {
+		t.Fatalf("FromEnv() error = %v, want a missing directory rejected", err)
+	}
+}
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Kinds of Pushover failures. APIError unwraps to one of them.
+var (
+	ErrInvalidRecipient    = errors.New("invalid recipient")
+	ErrInvalidToken        = errors.New("invalid application token")
+	ErrInvalidArgument     = errors.New("invalid argument")
+	ErrRateLimited         = errors.New("rate limited")
+	ErrQuotaExceeded       = errors.New("monthly quota exceeded")
+	ErrUpstreamUnavailable = errors.New("pushover unavailable")
+	// ErrDeliveryUnknown means the connection failed after a request that changes state may have reached Pushover.
+	ErrDeliveryUnknown = errors.New("pushover did not answer; the request may have been delivered")
+)
+
+// APIError is a request Pushover rejected or could not serve.
+type APIError struct {
+	Kind    error
+	Fields  map[string]string // Per-field problems, e.g. "user": "invalid"
+	Status  string
+	Request string
+	Errors  []string
+	Code    int
+	// RetryAfter is how long Pushover asked to wait before retrying; zero when it did not say.
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	detail := strings.Join(e.Errors, "; ")
+	if detail == "" {
+		detail = e.Kind.Error()
+	}
+
+	msg := fmt.Sprintf("pushover returned %s: %s", e.Status, detail)
+	if e.Request != "" {
+		msg += fmt.Sprintf(" (request %s)", e.Request)
+	}
+
+	return msg
+}
+
+func (e *APIError) Unwrap() error {
+	return e.Kind
+}
+
+// IsRetryable reports whether repeating the same request later may succeed.
+// A quota that is used up only recovers at the monthly reset, so it is not retryable.
+func IsRetryable(err error) bool {
+	return errors.Is(err, ErrRateLimited) || errors.Is(err, ErrUpstreamUnavailable)
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestAPIError_ErrorAndUnwrap(t *testing.T) {
+	err := &APIError{
+		Kind:    ErrInvalidRecipient,
+		Status:  "400 Bad Request",
+		Code:    400,
+		Request: "req-1",
+		Errors:  []string{"user identifier is invalid"},
+	}
+
+	want := "pushover returned 400 Bad Request: user identifier is invalid (request req-1)"
+	if err.Error() != want {
+		t.Fatalf("Error() = %q, want %q", err.Error(), want)
+	}
+
+	if !errors.Is(fmt.Errorf("send: %w", err), ErrInvalidRecipient) {
+		t.Fatal("errors.Is(err, ErrInvalidRecipient) = false")
+	}
+}
+
+func TestAPIError_ErrorWithoutDetails(t *testing.T) {
+	err := &APIError{Kind: ErrUpstreamUnavailable, Status: "503 Service Unavailable", Code: 503}
+
+	if err.Error() != "pushover returned 503 Service Unavailable: pushover unavailable" {
+		t.Fatalf("Error() = %q", err.Error())
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{err: &APIError{Kind: ErrRateLimited}, want: true},
+		{err: fmt.Errorf("request: %w", ErrUpstreamUnavailable), want: true},
+		{err: &APIError{Kind: ErrQuotaExceeded}, want: false},
+		{err: &APIError{Kind: ErrInvalidArgument}, want: false},
+		{err: errors.New("message is required"), want: false},
+	}
+
+	for _, tc := range tests {
+		if got := IsRetryable(tc.err); got != tc.want {
+			t.Fatalf("IsRetryable(%v) = %v, want %v", tc.err, got, tc.want)
+		}
+	}
+}
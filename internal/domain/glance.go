@@ -0,0 +1,17 @@
+package domain
+
+import "context"
+
+// Glance is data for watch and widget surfaces; it updates them without an alert.
+type Glance struct {
+	Count   *int
+	Percent *int // 0-100
+	Title   string
+	Text    string
+	Subtext string
+	Device  string
+}
+
+type GlanceUpdater interface {
+	UpdateGlance(ctx context.Context, glance Glance) error
+}
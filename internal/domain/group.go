@@ -0,0 +1,25 @@
+package domain
+
+import "context"
+
+type Group struct {
+	Name    string
+	Members []GroupMember
+}
+
+type GroupMember struct {
+	User     string
+	Device   string // Empty means all of the user's devices
+	Memo     string
+	Disabled bool
+}
+
+// GroupManager changes the members of one configured delivery group.
+type GroupManager interface {
+	Group(ctx context.Context) (Group, error)
+	AddUser(ctx context.Context, member GroupMember) error
+	RemoveUser(ctx context.Context, user, device string) error
+	DisableUser(ctx context.Context, user, device string) error
+	EnableUser(ctx context.Context, user, device string) error
+	Rename(ctx context.Context, name string) error
+}
@@ -1,13 +1,33 @@
 package domain
 
 type Notification struct {
-	Priority *int
-	Retry    *int // Optional; defaults to 60 for emergency priority (2)
-	Expire   *int // Optional; defaults to 3600 for emergency priority (2)
-	Message  string
-	Title    string
-	Sound    string
-	URL      string
-	URLTitle string
-	Device   string
+	Priority   *int
+	Retry      *int   // Optional; defaults to 60 for emergency priority (2)
+	Expire     *int   // Optional; defaults to 3600 for emergency priority (2)
+	Timestamp  *int64 // Unix seconds shown as the message time instead of the receive time
+	TTL        *int   // Seconds before the message is deleted from devices; ignored for emergency priority
+	User       string // Comma-separated user or group keys; empty sends to the configured user key
+	App        string // Named application profile; empty uses the default application
+	Message    string
+	Title      string
+	Sound      string
+	URL        string
+	URLTitle   string
+	Device     string
+	Tags       []string // Emergency priority only; used by cancel_by_tag
+	HTML       bool
+	Markdown   bool // Message is Markdown, converted to Pushover HTML before sending
+	Monospace  bool // Mutually exclusive with HTML
+	Attachment *Attachment
+
+	// IdempotencyKey marks repeats of one request; a sender may return the first result instead of sending again.
+	IdempotencyKey string
+}
+
+// Attachment is an image shown with the notification.
+// Attachments with a Filename are uploaded as a multipart file, others as base64.
+type Attachment struct {
+	Filename string
+	MIMEType string
+	Data     []byte
 }
@@ -5,5 +5,5 @@ import (
 )
 
 type NotificationSender interface {
-	Send(ctx context.Context, notification Notification) error
+	Send(ctx context.Context, notification Notification) (SendResult, error)
 }
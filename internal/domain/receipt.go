@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Receipt is the delivery state of an emergency-priority notification.
+// Zero times mean the event has not happened yet.
+type Receipt struct {
+	AcknowledgedAt       time.Time
+	LastDeliveredAt      time.Time
+	ExpiresAt            time.Time
+	CalledBackAt         time.Time
+	AcknowledgedBy       string
+	AcknowledgedByDevice string
+	Acknowledged         bool
+	Expired              bool
+	CalledBack           bool
+}
+
+type ReceiptService interface {
+	GetReceipt(ctx context.Context, receipt string) (Receipt, error)
+	CancelReceipt(ctx context.Context, receipt string) error
+	// CancelByTag cancels every active emergency notification carrying tag and returns how many were canceled.
+	CancelByTag(ctx context.Context, tag string) (int, error)
+}
@@ -0,0 +1,30 @@
+package domain
+
+import "context"
+
+// Recipient describes a validated user or group key.
+type Recipient struct {
+	Devices  []string // Active devices; empty for groups
+	Licenses []string
+	Group    bool
+}
+
+type RecipientValidator interface {
+	// ValidateRecipient checks a user or group key, and the device when set.
+	// An empty user checks the configured key.
+	ValidateRecipient(ctx context.Context, user, device string) (Recipient, error)
+}
+
+type DeviceCatalog interface {
+	// Devices lists the active devices of the configured user key.
+	Devices(ctx context.Context) ([]string, error)
+}
+
+// RecipientAlias names a user or group key so the model never handles the key itself.
+// The optional defaults apply when the notification leaves the field unset.
+type RecipientAlias struct {
+	Priority *int
+	Key      string
+	Device   string
+	Sound    string
+}
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// RecurringNotification is a notification sent on a cron schedule.
+type RecurringNotification struct {
+	NextRun      time.Time // Zero while paused
+	LastRun      time.Time
+	CreatedAt    time.Time
+	ID           string
+	Cron         string
+	TimeZone     string // IANA name the cron expression is evaluated in
+	LastError    string // Error of the last run; empty when it succeeded
+	Notification Notification
+	Paused       bool
+}
+
+type RecurringStore interface {
+	// SaveRecurring inserts the job or replaces the one with the same ID.
+	SaveRecurring(ctx context.Context, recurring RecurringNotification) error
+	// DeleteRecurring removes a job; it reports false when the ID is unknown.
+	DeleteRecurring(ctx context.Context, id string) (bool, error)
+	ListRecurring(ctx context.Context) ([]RecurringNotification, error)
+	// UpdateRecurring applies change to the stored job and saves it, atomically also for other processes
+	// sharing the store. It reports false when the ID is unknown; when change fails, nothing is saved.
+	UpdateRecurring(ctx context.Context, id string, change func(recurring *RecurringNotification) error) (RecurringNotification, bool, error)
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// ScheduledNotification is a notification waiting in the persistent queue.
+type ScheduledNotification struct {
+	SendAt       time.Time
+	CreatedAt    time.Time
+	RetryAt      time.Time // Next delivery attempt after a temporary failure; zero otherwise
+	ClaimedUntil time.Time // While in the future, ClaimedBy is delivering it and other servers leave it alone
+	ID           string
+	ClaimedBy    string
+	LastError    string
+	Notification Notification
+	Attempts     int
+	Failed       bool // Delivery failed permanently; kept so the failure stays visible
+}
+
+// Due reports whether a delivery attempt should be made at now.
+func (s ScheduledNotification) Due(now time.Time) bool {
+	if s.Failed || now.Before(s.ClaimedUntil) {
+		return false
+	}
+
+	if !s.RetryAt.IsZero() {
+		return !now.Before(s.RetryAt)
+	}
+
+	return !now.Before(s.SendAt)
+}
+
+type ScheduleStore interface {
+	// SaveScheduled inserts the notification or replaces the one with the same ID.
+	SaveScheduled(ctx context.Context, scheduled ScheduledNotification) error
+	// DeleteScheduled removes a notification; it reports false when the ID is unknown.
+	DeleteScheduled(ctx context.Context, id string) (bool, error)
+	ListScheduled(ctx context.Context) ([]ScheduledNotification, error)
+	// UpdateScheduled applies change to the stored notification and saves it, atomically also for other
+	// processes sharing the store. It reports false when the ID is unknown; when change fails, nothing is saved.
+	UpdateScheduled(
+		ctx context.Context,
+		id string,
+		change func(scheduled *ScheduledNotification) error,
+	) (ScheduledNotification, bool, error)
+}
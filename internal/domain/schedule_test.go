@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScheduledNotification_Due(t *testing.T) {
+	sendAt := time.Date(2026, 5, 1, 17, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		scheduled ScheduledNotification
+		now       time.Time
+		want      bool
+	}{
+		{name: "before send_at", scheduled: ScheduledNotification{SendAt: sendAt}, now: sendAt.Add(-time.Second)},
+		{name: "at send_at", scheduled: ScheduledNotification{SendAt: sendAt}, now: sendAt, want: true},
+		{name: "missed while stopped", scheduled: ScheduledNotification{SendAt: sendAt}, now: sendAt.Add(24 * time.Hour), want: true},
+		{
+			name:      "waiting for retry",
+			scheduled: ScheduledNotification{SendAt: sendAt, RetryAt: sendAt.Add(time.Minute)},
+			now:       sendAt.Add(time.Second),
+		},
+		{
+			name:      "retry due",
+			scheduled: ScheduledNotification{SendAt: sendAt, RetryAt: sendAt.Add(time.Minute)},
+			now:       sendAt.Add(time.Minute),
+			want:      true,
+		},
+		{name: "failed", scheduled: ScheduledNotification{SendAt: sendAt, Failed: true}, now: sendAt.Add(time.Hour)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.scheduled.Due(tc.now); got != tc.want {
+				t.Fatalf("Due() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// RateLimit is the monthly message quota of the application.
+type RateLimit struct {
+	Reset      time.Time
+	ObservedAt time.Time // When Pushover reported these values
+	Limit      int
+	Remaining  int
+}
+
+type RateLimitReader interface {
+	// RateLimit fetches the current quota from Pushover.
+	RateLimit(ctx context.Context) (RateLimit, error)
+	// LastRateLimit returns the quota reported with the most recent message, if any.
+	LastRateLimit() (RateLimit, bool)
+}
+
+type SendResult struct {
+	Request   string
+	Receipt   string // Set only for emergency priority (2)
+	RateLimit *RateLimit
+	Truncated []string // Fields shortened to fit Pushover's length limits
+	Sanitized []string // Unsupported HTML removed from the message
+	Parts     int      // Messages a long notification was split into; 0 when sent whole
+	Duplicate bool     // The notification was sent before; this is the earlier result
+
+	QuietHours *QuietHoursOutcome // Set when a quiet-hours policy changed the notification
+	Digest     *DigestOutcome     // Set when the notification went into a digest
+}
+
+// DigestOutcome tells what became of a notification collected for a digest. While the digest is
+// pending, FlushAt is when it goes out and Items counts what it holds so far; once sent, FlushAt
+// is zero and Items is the number of notifications the digest combined.
+type DigestOutcome struct {
+	FlushAt time.Time
+	Items   int
+}
+
+// Actions a quiet-hours policy reports in QuietHoursOutcome.
+const (
+	QuietDowngraded = "downgraded"
+	QuietHeld       = "held"
+	QuietSuppressed = "suppressed"
+)
+
+// QuietHoursOutcome tells what a quiet-hours policy did to a notification.
+type QuietHoursOutcome struct {
+	Until       time.Time // End of the quiet hours
+	Action      string
+	ScheduledID string // Scheduled notification that sends a held one when the quiet hours end
+	From        int    // Priority before a downgrade
+	To          int    // Priority after a downgrade
+}
@@ -0,0 +1,12 @@
+package domain
+
+import "context"
+
+type Sound struct {
+	Name        string
+	Description string
+}
+
+type SoundCatalog interface {
+	Sounds(ctx context.Context) ([]Sound, error)
+}
@@ -0,0 +1,12 @@
+package domain
+
+// MessageTemplate is a named notification with Go text/template sources for its fields.
+// Priority must render to an integer, or to nothing for the default priority.
+type MessageTemplate struct {
+	Name        string
+	Description string
+	Message     string
+	Title       string
+	URL         string
+	Priority    string
+}
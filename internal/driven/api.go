@@ -0,0 +1,294 @@
+package driven
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+const (
+	defaultAPIBaseURL = "https://api.pushover.net/1/messages.json"
+	messagesEndpoint  = "/messages.json"
+
+	// maxResponseSize bounds a successful response body; a large group's member list is a few KiB.
+	maxResponseSize = 1 << 20
+	// maxErrorBodySize bounds the error text kept from a failed response.
+	maxErrorBodySize = 4096
+)
+
+// api is the HTTP plumbing shared by the Pushover clients.
+type api struct {
+	httpClient *http.Client
+	baseURL    string
+	retry      retrier
+}
+
+// messagesURL applies the default to a configured messages endpoint URL.
+func messagesURL(apiURL string) string {
+	if strings.TrimSpace(apiURL) == "" {
+		return defaultAPIBaseURL
+	}
+
+	return apiURL
+}
+
+// newAPI resolves the other endpoints next to the messages endpoint URL.
+func newAPI(apiURL string, httpClient *http.Client, policy RetryPolicy) api {
+	return api{
+		httpClient: httpClient,
+		baseURL:    strings.TrimSuffix(strings.TrimRight(apiURL, "/"), messagesEndpoint),
+		retry:      newRetrier(policy),
+	}
+}
+
+// endpoint builds an API URL next to the configured messages endpoint, escaping each path segment.
+func (a *api) endpoint(segments ...string) string {
+	escaped := make([]string, 0, len(segments))
+	for _, segment := range segments {
+		escaped = append(escaped, url.PathEscape(segment))
+	}
+
+	return a.baseURL + "/" + strings.Join(escaped, "/") + ".json"
+}
+
+func (a *api) postForm(ctx context.Context, endpoint string, form url.Values, out any) (http.Header, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return a.do(req, out)
+}
+
+func (a *api) postMultipart(
+	ctx context.Context,
+	endpoint string,
+	form url.Values,
+	attachment *domain.Attachment,
+	out any,
+) (http.Header, error) {
+	var body bytes.Buffer
+
+	writer := multipart.NewWriter(&body)
+
+	for key, values := range form {
+		for _, value := range values {
+			if err := writer.WriteField(key, value); err != nil {
+				return nil, fmt.Errorf("write form field: %w", err)
+			}
+		}
+	}
+
+	partHeader := textproto.MIMEHeader{}
+	partHeader.Set("Content-Disposition", multipart.FileContentDisposition("attachment", attachment.Filename))
+	partHeader.Set("Content-Type", attachment.MIMEType)
+
+	part, err := writer.CreatePart(partHeader)
+	if err != nil {
+		return nil, fmt.Errorf("create attachment part: %w", err)
+	}
+
+	if _, err := part.Write(attachment.Data); err != nil {
+		return nil, fmt.Errorf("write attachment: %w", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("close multipart body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, &body)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	return a.do(req, out)
+}
+
+func (a *api) get(ctx context.Context, endpoint string, query url.Values, out any) (http.Header, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	req.URL.RawQuery = query.Encode()
+
+	return a.do(req, out)
+}
+
+// do sends req, repeating it according to the retry policy while the failure is retryable.
+func (a *api) do(req *http.Request, out any) (http.Header, error) {
+	var header http.Header
+
+	err := a.retry.run(req.Context(), func(attempt int) error {
+		if attempt > 0 {
+			rewound, err := rewind(req)
+			if err != nil {
+				return err
+			}
+
+			req = rewound
+		}
+
+		var err error
+
+		header, err = a.doOnce(req, out)
+
+		return err
+	})
+
+	return header, err
+}
+
+// rewind clones req with a fresh body for another attempt.
+func rewind(req *http.Request) (*http.Request, error) {
+	clone := req.Clone(req.Context())
+	if req.GetBody == nil {
+		return clone, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, fmt.Errorf("rewind request body: %w", err)
+	}
+
+	clone.Body = body
+
+	return clone, nil
+}
+
+func (a *api) doOnce(req *http.Request, out any) (http.Header, error) {
+	//nolint:gosec // API URL is controlled by explicit runtime configuration.
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		// A canceled or expired caller context is not an outage.
+		if req.Context().Err() != nil {
+			return nil, fmt.Errorf("request pushover: %w", err)
+		}
+
+		kind := domain.ErrUpstreamUnavailable
+		if req.Method != http.MethodGet && !neverSent(err) {
+			kind = domain.ErrDeliveryUnknown
+		}
+
+		return nil, fmt.Errorf("request pushover: %w: %w", kind, err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	body, err := validateResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+
+	return resp.Header, nil
+}
+
+// neverSent reports whether a transport error happened before a connection to Pushover existed,
+// so that the request provably was not delivered and repeating it cannot duplicate it.
+func neverSent(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+
+	var opErr *net.OpError
+
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+func validateResponse(resp *http.Response) ([]byte, error) {
+	failed := resp.StatusCode < 200 || resp.StatusCode >= 300
+
+	limit := int64(maxResponseSize)
+	if failed {
+		limit = maxErrorBodySize
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, limit))
+	if err != nil {
+		return nil, fmt.Errorf("read response body: %w", err)
+	}
+
+	if failed {
+		return nil, newAPIError(resp, body)
+	}
+
+	return body, nil
+}
+
+// newAPIError classifies a failed response using its status code and Pushover's JSON error body:
+// {"status":0,"user":"invalid","errors":["user identifier is invalid"],"request":"..."}.
+func newAPIError(resp *http.Response, body []byte) *domain.APIError {
+	apiErr := &domain.APIError{
+		Code:       resp.StatusCode,
+		Status:     resp.Status,
+		Fields:     map[string]string{},
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+	}
+
+	var parsed map[string]json.RawMessage
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		if text := strings.TrimSpace(string(body)); text != "" {
+			apiErr.Errors = []string{text}
+		}
+	}
+
+	for key, raw := range parsed {
+		switch key {
+		case "errors":
+			_ = json.Unmarshal(raw, &apiErr.Errors)
+		case "request":
+			_ = json.Unmarshal(raw, &apiErr.Request)
+		default:
+			var value string
+			if json.Unmarshal(raw, &value) == nil {
+				apiErr.Fields[key] = value
+			}
+		}
+	}
+
+	apiErr.Kind = classify(resp, apiErr.Fields)
+
+	return apiErr
+}
+
+func classify(resp *http.Response, fields map[string]string) error {
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		if resp.Header.Get("X-Limit-App-Remaining") == "0" {
+			return domain.ErrQuotaExceeded
+		}
+
+		return domain.ErrRateLimited
+	case resp.StatusCode >= http.StatusInternalServerError:
+		return domain.ErrUpstreamUnavailable
+	case fields["token"] != "" || resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return domain.ErrInvalidToken
+	case fields["user"] != "" || fields["device"] != "":
+		return domain.ErrInvalidRecipient
+	default:
+		return domain.ErrInvalidArgument
+	}
+}
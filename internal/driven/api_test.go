@@ -0,0 +1,195 @@
+package driven
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+func TestSend_ErrorClassification(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		remaining string
+		body      string
+		want      error
+	}{
+		{
+			name:   "invalid user",
+			status: http.StatusBadRequest,
+			body:   `{"user":"invalid","errors":["user identifier is invalid"],"status":0,"request":"req-1"}`,
+			want:   domain.ErrInvalidRecipient,
+		},
+		{
+			name:   "invalid device",
+			status: http.StatusBadRequest,
+			body:   `{"device":"invalid for this user","errors":["device name is not valid for user"],"status":0}`,
+			want:   domain.ErrInvalidRecipient,
+		},
+		{
+			name:   "invalid token",
+			status: http.StatusBadRequest,
+			body:   `{"token":"invalid","errors":["application token is invalid"],"status":0}`,
+			want:   domain.ErrInvalidToken,
+		},
+		{
+			name:   "invalid argument",
+			status: http.StatusBadRequest,
+			body:   `{"message":"cannot be blank","errors":["message cannot be blank"],"status":0}`,
+			want:   domain.ErrInvalidArgument,
+		},
+		{
+			name:      "quota exceeded",
+			status:    http.StatusTooManyRequests,
+			remaining: "0",
+			body:      `{"errors":["application has exceeded its monthly message limit"],"status":0}`,
+			want:      domain.ErrQuotaExceeded,
+		},
+		{
+			name:   "rate limited",
+			status: http.StatusTooManyRequests,
+			body:   `{"errors":["too many requests"],"status":0}`,
+			want:   domain.ErrRateLimited,
+		},
+		{
+			name:   "outage with non-JSON body",
+			status: http.StatusBadGateway,
+			body:   `<html>bad gateway</html>`,
+			want:   domain.ErrUpstreamUnavailable,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				if tc.remaining != "" {
+					w.Header().Set("X-Limit-App-Remaining", tc.remaining)
+				}
+
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer ts.Close()
+
+			_, err := newTestClient(t, ts).Send(context.Background(), domain.Notification{Message: "hello"})
+			if !errors.Is(err, tc.want) {
+				t.Fatalf("error = %v, want %v", err, tc.want)
+			}
+
+			var apiErr *domain.APIError
+			if !errors.As(err, &apiErr) || apiErr.Code != tc.status || len(apiErr.Errors) == 0 {
+				t.Fatalf("APIError = %+v", apiErr)
+			}
+		})
+	}
+}
+
+func TestSend_FieldErrorsAndRequestID(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"user":"invalid","errors":["user identifier is invalid"],"status":0,"request":"req-1"}`))
+	}))
+	defer ts.Close()
+
+	_, err := newTestClient(t, ts).Send(context.Background(), domain.Notification{Message: "hello"})
+
+	var apiErr *domain.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("error = %T, want *domain.APIError", err)
+	}
+
+	if apiErr.Request != "req-1" || apiErr.Fields["user"] != "invalid" {
+		t.Fatalf("APIError = %+v", apiErr)
+	}
+
+	if _, ok := apiErr.Fields["status"]; ok {
+		t.Fatal("numeric status reported as a field error")
+	}
+}
+
+func TestSend_NetworkErrorIsUpstreamUnavailable(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	client := newTestClient(t, ts)
+	ts.Close()
+
+	_, err := client.Send(context.Background(), domain.Notification{Message: "hello"})
+	if !errors.Is(err, domain.ErrUpstreamUnavailable) {
+		t.Fatalf("error = %v, want %v", err, domain.ErrUpstreamUnavailable)
+	}
+}
+
+// newHangingClient serves requests that never answer before the client's timeout, counting them.
+func newHangingClient(t *testing.T) (*PushoverClient, *atomic.Int32) {
+	t.Helper()
+
+	var requests atomic.Int32
+
+	release := make(chan struct{})
+
+	ts := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		requests.Add(1)
+		<-release
+	}))
+	t.Cleanup(ts.Close)
+	t.Cleanup(func() { close(release) })
+
+	httpClient := ts.Client()
+	httpClient.Timeout = 50 * time.Millisecond
+
+	cfg := testConfig(ts.URL)
+	cfg.Retry = testRetryPolicy
+
+	client, err := NewPushoverClient(cfg, httpClient)
+	if err != nil {
+		t.Fatalf(errNewClient, err)
+	}
+
+	client.retry.sleep = func(context.Context, time.Duration) error { return nil }
+
+	return client, &requests
+}
+
+func TestSend_TimeoutIsNotRetried(t *testing.T) {
+	client, requests := newHangingClient(t)
+
+	_, err := client.Send(context.Background(), domain.Notification{Message: "hello"})
+	if !errors.Is(err, domain.ErrDeliveryUnknown) || domain.IsRetryable(err) {
+		t.Fatalf("error = %v, want non-retryable %v", err, domain.ErrDeliveryUnknown)
+	}
+
+	if got := requests.Load(); got != 1 {
+		t.Fatalf("requests = %d, want 1", got)
+	}
+}
+
+func TestRateLimit_TimeoutIsRetried(t *testing.T) {
+	client, requests := newHangingClient(t)
+
+	_, err := client.RateLimit(context.Background())
+	if !errors.Is(err, domain.ErrUpstreamUnavailable) {
+		t.Fatalf("error = %v, want %v", err, domain.ErrUpstreamUnavailable)
+	}
+
+	if got := requests.Load(); got != int32(testRetryPolicy.MaxAttempts) {
+		t.Fatalf("requests = %d, want %d", got, testRetryPolicy.MaxAttempts)
+	}
+}
+
+func TestSend_CanceledContextIsNotUpstreamUnavailable(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := newTestClient(t, ts).Send(ctx, domain.Notification{Message: "hello"})
+	if err == nil || domain.IsRetryable(err) {
+		t.Fatalf("error = %v, want non-retryable error", err)
+	}
+}
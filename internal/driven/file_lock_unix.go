@@ -0,0 +1,19 @@
+//go:build unix
+
+package driven
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// lockExclusive blocks until the process holds an exclusive advisory lock on file.
+func lockExclusive(file *os.File) error {
+	for {
+		err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX)
+		if !errors.Is(err, syscall.EINTR) {
+			return err
+		}
+	}
+}
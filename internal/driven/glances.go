@@ -0,0 +1,27 @@
+package driven
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+func (c *PushoverClient) UpdateGlance(ctx context.Context, glance domain.Glance) error {
+	form := url.Values{}
+	form.Set("token", c.apiToken)
+	form.Set("user", c.userKey)
+	setOptionalString(form, "device", glance.Device)
+	setOptionalString(form, "title", glance.Title)
+	setOptionalString(form, "text", glance.Text)
+	setOptionalString(form, "subtext", glance.Subtext)
+	setOptionalInt(form, "count", glance.Count)
+	setOptionalInt(form, "percent", glance.Percent)
+
+	var parsed apiResponse
+	if _, err := c.postForm(ctx, c.endpoint("glances"), form, &parsed); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package driven
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+func TestUpdateGlance(t *testing.T) {
+	var (
+		gotPath string
+		gotForm url.Values
+	)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assertRequestMethodAndContentType(t, r)
+
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("parse form error: %v", err)
+		}
+
+		gotPath = r.URL.Path
+		gotForm = r.PostForm
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"status":1}`))
+	}))
+	defer ts.Close()
+
+	client := newTestClient(t, ts)
+
+	percent := 73
+	err := client.UpdateGlance(context.Background(), domain.Glance{
+		Title:   "Build",
+		Text:    "73% done",
+		Percent: &percent,
+	})
+	if err != nil {
+		t.Fatalf("UpdateGlance() error = %v", err)
+	}
+
+	if gotPath != "/glances.json" {
+		t.Fatalf("path = %q, want /glances.json", gotPath)
+	}
+
+	assertFormValues(t, gotForm, map[string]string{
+		"token":   testAPIToken,
+		"user":    testUserKey,
+		"title":   "Build",
+		"text":    "73% done",
+		"percent": "73",
+	})
+	assertFormValueEmpty(t, gotForm, "count")
+	assertFormValueEmpty(t, gotForm, "subtext")
+}
@@ -0,0 +1,121 @@
+package driven
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+type GroupConfig struct {
+	APIToken string
+	GroupKey string
+	APIURL   string
+	Retry    RetryPolicy
+}
+
+// GroupClient manages one delivery group through the Pushover Groups API.
+type GroupClient struct {
+	api
+	apiToken string
+	groupKey string
+}
+
+func NewGroupClient(cfg GroupConfig, httpClient *http.Client) (*GroupClient, error) {
+	if cfg.APIToken == "" {
+		return nil, errors.New("missing APIToken")
+	}
+
+	if cfg.GroupKey == "" {
+		return nil, errors.New("missing GroupKey")
+	}
+
+	if httpClient == nil {
+		return nil, errors.New("http client is required")
+	}
+
+	return &GroupClient{
+		api:      newAPI(messagesURL(cfg.APIURL), httpClient, cfg.Retry),
+		apiToken: cfg.APIToken,
+		groupKey: cfg.GroupKey,
+	}, nil
+}
+
+type groupResponse struct {
+	Name  string `json:"name"`
+	Users []struct {
+		User     string `json:"user"`
+		Device   string `json:"device"`
+		Memo     string `json:"memo"`
+		Disabled bool   `json:"disabled"`
+	} `json:"users"`
+}
+
+func (c *GroupClient) Group(ctx context.Context) (domain.Group, error) {
+	query := url.Values{}
+	query.Set("token", c.apiToken)
+
+	var parsed groupResponse
+	if _, err := c.get(ctx, c.endpoint("groups", c.groupKey), query, &parsed); err != nil {
+		return domain.Group{}, err
+	}
+
+	members := make([]domain.GroupMember, 0, len(parsed.Users))
+	for _, user := range parsed.Users {
+		members = append(members, domain.GroupMember{
+			User:     user.User,
+			Device:   user.Device,
+			Memo:     user.Memo,
+			Disabled: user.Disabled,
+		})
+	}
+
+	return domain.Group{Name: parsed.Name, Members: members}, nil
+}
+
+func (c *GroupClient) AddUser(ctx context.Context, member domain.GroupMember) error {
+	form := c.userForm(member.User, member.Device)
+	setOptionalString(form, "memo", member.Memo)
+
+	return c.post(ctx, "add_user", form)
+}
+
+func (c *GroupClient) RemoveUser(ctx context.Context, user, device string) error {
+	return c.post(ctx, "delete_user", c.userForm(user, device))
+}
+
+func (c *GroupClient) DisableUser(ctx context.Context, user, device string) error {
+	return c.post(ctx, "disable_user", c.userForm(user, device))
+}
+
+func (c *GroupClient) EnableUser(ctx context.Context, user, device string) error {
+	return c.post(ctx, "enable_user", c.userForm(user, device))
+}
+
+func (c *GroupClient) Rename(ctx context.Context, name string) error {
+	form := url.Values{}
+	form.Set("token", c.apiToken)
+	form.Set("name", name)
+
+	return c.post(ctx, "rename", form)
+}
+
+func (c *GroupClient) userForm(user, device string) url.Values {
+	form := url.Values{}
+	form.Set("token", c.apiToken)
+	form.Set("user", user)
+	setOptionalString(form, "device", device)
+
+	return form
+}
+
+func (c *GroupClient) post(ctx context.Context, action string, form url.Values) error {
+	var parsed apiResponse
+	if _, err := c.postForm(ctx, c.endpoint("groups", c.groupKey, action), form, &parsed); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,172 @@
+package driven
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+const testGroupKey = "group-1"
+
+func newTestGroupClient(t *testing.T, ts *httptest.Server) *GroupClient {
+	t.Helper()
+
+	client, err := NewGroupClient(GroupConfig{APIToken: testAPIToken, GroupKey: testGroupKey, APIURL: ts.URL}, ts.Client())
+	if err != nil {
+		t.Fatalf("NewGroupClient() error = %v", err)
+	}
+
+	return client
+}
+
+func TestNewGroupClient_Validation(t *testing.T) {
+	_, err := NewGroupClient(GroupConfig{APIToken: "token"}, &http.Client{})
+	if err == nil || !strings.Contains(err.Error(), "missing GroupKey") {
+		t.Fatalf("expected missing GroupKey error, got: %v", err)
+	}
+
+	_, err = NewGroupClient(GroupConfig{GroupKey: testGroupKey}, &http.Client{})
+	if err == nil || !strings.Contains(err.Error(), "missing APIToken") {
+		t.Fatalf("expected missing APIToken error, got: %v", err)
+	}
+}
+
+func TestGroupClient_Group(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/groups/group-1.json" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"status":1,"name":"On-call","users":[` +
+			`{"user":"user-1","device":null,"memo":"primary","disabled":false},` +
+			`{"user":"user-2","device":"iphone","memo":"","disabled":true}]}`))
+	}))
+	defer ts.Close()
+
+	group, err := newTestGroupClient(t, ts).Group(context.Background())
+	if err != nil {
+		t.Fatalf("Group() error = %v", err)
+	}
+
+	if group.Name != "On-call" || len(group.Members) != 2 {
+		t.Fatalf("group = %+v", group)
+	}
+
+	if group.Members[0].Memo != "primary" || group.Members[0].Device != "" {
+		t.Fatalf("member[0] = %+v", group.Members[0])
+	}
+
+	if !group.Members[1].Disabled || group.Members[1].Device != "iphone" {
+		t.Fatalf("member[1] = %+v", group.Members[1])
+	}
+}
+
+func TestGroupClient_GroupLargerThanErrorBody(t *testing.T) {
+	users := make([]string, 0, 100)
+	for i := range 100 {
+		users = append(users, fmt.Sprintf(`{"user":"user-%d","device":null,"memo":"member %d","disabled":false}`, i, i))
+	}
+
+	body := `{"status":1,"name":"Everyone","users":[` + strings.Join(users, ",") + `]}`
+	if len(body) <= maxErrorBodySize {
+		t.Fatalf("body is %d bytes, want more than %d", len(body), maxErrorBodySize)
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(body))
+	}))
+	defer ts.Close()
+
+	group, err := newTestGroupClient(t, ts).Group(context.Background())
+	if err != nil {
+		t.Fatalf("Group() error = %v", err)
+	}
+
+	if len(group.Members) != 100 || group.Members[99].User != "user-99" {
+		t.Fatalf("group has %d members, want 100", len(group.Members))
+	}
+}
+
+func TestGroupClient_Changes(t *testing.T) {
+	var (
+		gotPath string
+		gotForm url.Values
+	)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assertRequestMethodAndContentType(t, r)
+
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("parse form error: %v", err)
+		}
+
+		gotPath = r.URL.Path
+		gotForm = r.PostForm
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"status":1}`))
+	}))
+	defer ts.Close()
+
+	client := newTestGroupClient(t, ts)
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		call     func() error
+		wantPath string
+		wantForm map[string]string
+	}{
+		{
+			name:     "add",
+			call:     func() error { return client.AddUser(ctx, domain.GroupMember{User: "user-1", Memo: "backup"}) },
+			wantPath: "/groups/group-1/add_user.json",
+			wantForm: map[string]string{"token": testAPIToken, "user": "user-1", "memo": "backup"},
+		},
+		{
+			name:     "remove",
+			call:     func() error { return client.RemoveUser(ctx, "user-1", "iphone") },
+			wantPath: "/groups/group-1/delete_user.json",
+			wantForm: map[string]string{"user": "user-1", "device": "iphone"},
+		},
+		{
+			name:     "disable",
+			call:     func() error { return client.DisableUser(ctx, "user-1", "") },
+			wantPath: "/groups/group-1/disable_user.json",
+			wantForm: map[string]string{"user": "user-1"},
+		},
+		{
+			name:     "enable",
+			call:     func() error { return client.EnableUser(ctx, "user-1", "") },
+			wantPath: "/groups/group-1/enable_user.json",
+			wantForm: map[string]string{"user": "user-1"},
+		},
+		{
+			name:     "rename",
+			call:     func() error { return client.Rename(ctx, "Weekend on-call") },
+			wantPath: "/groups/group-1/rename.json",
+			wantForm: map[string]string{"token": testAPIToken, "name": "Weekend on-call"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.call(); err != nil {
+				t.Fatalf("call error = %v", err)
+			}
+
+			if gotPath != tc.wantPath {
+				t.Fatalf("path = %q, want %q", gotPath, tc.wantPath)
+			}
+
+			assertFormValues(t, gotForm, tc.wantForm)
+		})
+	}
+}
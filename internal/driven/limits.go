@@ -0,0 +1,62 @@
+package driven
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+// rateLimitCache keeps the quota from the latest response that reported one.
+type rateLimitCache struct {
+	limit domain.RateLimit
+	mu    sync.Mutex
+	ok    bool
+}
+
+func (c *rateLimitCache) store(limit domain.RateLimit) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.limit = limit
+	c.ok = true
+}
+
+func (c *rateLimitCache) load() (domain.RateLimit, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.limit, c.ok
+}
+
+type limitsResponse struct {
+	Limit     int   `json:"limit"`
+	Remaining int   `json:"remaining"`
+	Reset     int64 `json:"reset"`
+}
+
+func (c *PushoverClient) RateLimit(ctx context.Context) (domain.RateLimit, error) {
+	query := url.Values{}
+	query.Set("token", c.apiToken)
+
+	var parsed limitsResponse
+	if _, err := c.get(ctx, c.endpoint("apps", "limits"), query, &parsed); err != nil {
+		return domain.RateLimit{}, err
+	}
+
+	limit := domain.RateLimit{
+		Limit:      parsed.Limit,
+		Remaining:  parsed.Remaining,
+		Reset:      time.Unix(parsed.Reset, 0).UTC(),
+		ObservedAt: time.Now().UTC(),
+	}
+	c.limits.store(limit)
+
+	return limit, nil
+}
+
+func (c *PushoverClient) LastRateLimit() (domain.RateLimit, bool) {
+	return c.limits.load()
+}
@@ -0,0 +1,63 @@
+package driven
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+func TestRateLimit(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/apps/limits.json" || r.URL.Query().Get("token") != testAPIToken {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"status":1,"limit":10000,"remaining":7496,"reset":1393653600}`))
+	}))
+	defer ts.Close()
+
+	client := newTestClient(t, ts)
+
+	if _, ok := client.LastRateLimit(); ok {
+		t.Fatal("LastRateLimit() ok = true before any response")
+	}
+
+	limit, err := client.RateLimit(context.Background())
+	if err != nil {
+		t.Fatalf("RateLimit() error = %v", err)
+	}
+
+	if limit.Limit != 10000 || limit.Remaining != 7496 || !limit.Reset.Equal(time.Unix(1393653600, 0)) || limit.ObservedAt.IsZero() {
+		t.Fatalf("limit = %+v", limit)
+	}
+
+	if last, ok := client.LastRateLimit(); !ok || last.Remaining != 7496 {
+		t.Fatalf("LastRateLimit() = %+v, %v", last, ok)
+	}
+}
+
+func TestSend_RecordsRateLimitHeaders(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-Limit-App-Limit", "10000")
+		w.Header().Set("X-Limit-App-Remaining", "42")
+		w.Header().Set("X-Limit-App-Reset", "1393653600")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"status":1}`))
+	}))
+	defer ts.Close()
+
+	client := newTestClient(t, ts)
+
+	if _, err := client.Send(context.Background(), domain.Notification{Message: "hello"}); err != nil {
+		t.Fatalf(errSend, err)
+	}
+
+	if last, ok := client.LastRateLimit(); !ok || last.Remaining != 42 {
+		t.Fatalf("LastRateLimit() = %+v, %v", last, ok)
+	}
+}
@@ -2,30 +2,32 @@ package driven
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
-	"fmt"
-	"io"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/adlandh/pushover-mcp/internal/domain"
 )
 
-const defaultAPIBaseURL = "https://api.pushover.net/1/messages.json"
-
 type Config struct {
 	APIToken string
 	UserKey  string
 	APIURL   string
+	Retry    RetryPolicy
 }
 
 type PushoverClient struct {
-	httpClient *http.Client
-	apiToken   string
-	userKey    string
-	apiURL     string
+	api
+	apiToken string
+	userKey  string
+	apiURL   string
+	sounds   soundCache
+	devices  deviceCache
+	limits   rateLimitCache
 }
 
 func NewPushoverClient(cfg Config, httpClient *http.Client) (*PushoverClient, error) {
@@ -41,44 +43,50 @@ func NewPushoverClient(cfg Config, httpClient *http.Client) (*PushoverClient, er
 		return nil, errors.New("http client is required")
 	}
 
-	apiURL := cfg.APIURL
-	if strings.TrimSpace(apiURL) == "" {
-		apiURL = defaultAPIBaseURL
-	}
+	apiURL := messagesURL(cfg.APIURL)
 
 	return &PushoverClient{
-		apiToken:   cfg.APIToken,
-		userKey:    cfg.UserKey,
-		apiURL:     apiURL,
-		httpClient: httpClient,
+		api:      newAPI(apiURL, httpClient, cfg.Retry),
+		apiToken: cfg.APIToken,
+		userKey:  cfg.UserKey,
+		apiURL:   apiURL,
 	}, nil
 }
 
-func (c *PushoverClient) Send(ctx context.Context, notification domain.Notification) error {
-	form := buildFormValues(c.apiToken, c.userKey, notification)
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.apiURL, strings.NewReader(form.Encode()))
-	if err != nil {
-		return fmt.Errorf("create request: %w", err)
+func (c *PushoverClient) Send(ctx context.Context, notification domain.Notification) (domain.SendResult, error) {
+	userKey := c.userKey
+	if notification.User != "" {
+		userKey = notification.User
 	}
 
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	form := buildFormValues(c.apiToken, userKey, notification)
 
-	//nolint:gosec // API URL is controlled by explicit runtime configuration.
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("request pushover: %w", err)
+	var (
+		parsed apiResponse
+		header http.Header
+		err    error
+	)
+
+	if attachment := notification.Attachment; attachment != nil && attachment.Filename != "" {
+		header, err = c.postMultipart(ctx, c.apiURL, form, attachment, &parsed)
+	} else {
+		header, err = c.postForm(ctx, c.apiURL, form, &parsed)
 	}
 
-	defer func() {
-		_ = resp.Body.Close()
-	}()
+	if err != nil {
+		return domain.SendResult{}, err
+	}
 
-	if err := validateResponse(resp); err != nil {
-		return err
+	rateLimit := parseRateLimit(header)
+	if rateLimit != nil {
+		c.limits.store(*rateLimit)
 	}
 
-	return nil
+	return domain.SendResult{
+		Request:   parsed.Request,
+		Receipt:   parsed.Receipt,
+		RateLimit: rateLimit,
+	}, nil
 }
 
 func buildFormValues(apiToken, userKey string, notification domain.Notification) url.Values {
@@ -92,6 +100,12 @@ func buildFormValues(apiToken, userKey string, notification domain.Notification)
 	setOptionalString(form, "url", notification.URL)
 	setOptionalString(form, "url_title", notification.URLTitle)
 	setOptionalString(form, "device", notification.Device)
+	setFlag(form, "html", notification.HTML)
+	setFlag(form, "monospace", notification.Monospace)
+	setOptionalInt(form, "ttl", notification.TTL)
+	setOptionalInt64(form, "timestamp", notification.Timestamp)
+	setTags(form, notification.Tags)
+	setBase64Attachment(form, notification.Attachment)
 
 	return form
 }
@@ -111,6 +125,55 @@ func setOptionalString(form url.Values, key, value string) {
 	form.Set(key, trimmed)
 }
 
+func setFlag(form url.Values, key string, value bool) {
+	if !value {
+		return
+	}
+
+	form.Set(key, "1")
+}
+
+func setOptionalInt(form url.Values, key string, value *int) {
+	if value == nil {
+		return
+	}
+
+	form.Set(key, strconv.Itoa(*value))
+}
+
+func setOptionalInt64(form url.Values, key string, value *int64) {
+	if value == nil {
+		return
+	}
+
+	form.Set(key, strconv.FormatInt(*value, 10))
+}
+
+// setBase64Attachment inlines attachments that have no file name; file attachments go as multipart.
+func setBase64Attachment(form url.Values, attachment *domain.Attachment) {
+	if attachment == nil || attachment.Filename != "" {
+		return
+	}
+
+	form.Set("attachment_base64", base64.StdEncoding.EncodeToString(attachment.Data))
+	form.Set("attachment_type", attachment.MIMEType)
+}
+
+func setTags(form url.Values, tags []string) {
+	trimmed := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			trimmed = append(trimmed, tag)
+		}
+	}
+
+	if len(trimmed) == 0 {
+		return
+	}
+
+	form.Set("tags", strings.Join(trimmed, ","))
+}
+
 func setPriority(form url.Values, notification domain.Notification) {
 	if notification.Priority == nil {
 		return
@@ -134,15 +197,32 @@ func setPriority(form url.Values, notification domain.Notification) {
 	}
 }
 
-func validateResponse(resp *http.Response) error {
-	body, err := io.ReadAll(io.LimitReader(resp.Body, 4096))
+type apiResponse struct {
+	Request string `json:"request"`
+	Receipt string `json:"receipt"`
+}
+
+// parseRateLimit reads the X-Limit-App-* headers; nil when any of them is missing or malformed.
+func parseRateLimit(header http.Header) *domain.RateLimit {
+	limit, err := strconv.Atoi(header.Get("X-Limit-App-Limit"))
 	if err != nil {
-		return fmt.Errorf("read response body: %w", err)
+		return nil
 	}
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("pushover returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	remaining, err := strconv.Atoi(header.Get("X-Limit-App-Remaining"))
+	if err != nil {
+		return nil
 	}
 
-	return nil
+	reset, err := strconv.ParseInt(header.Get("X-Limit-App-Reset"), 10, 64)
+	if err != nil {
+		return nil
+	}
+
+	return &domain.RateLimit{
+		Limit:      limit,
+		Remaining:  remaining,
+		Reset:      time.Unix(reset, 0).UTC(),
+		ObservedAt: time.Now().UTC(),
+	}
 }
@@ -8,6 +8,7 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/adlandh/pushover-mcp/internal/domain"
 )
@@ -141,13 +142,15 @@ func TestSend_SuccessAndFormPayload(t *testing.T) {
 		URL:      testURL,
 		URLTitle: "Link",
 		Device:   "iphone",
+		Tags:     []string{"deploy", " ", "release "},
 	}
 
-	if err := client.Send(context.Background(), n); err != nil {
+	if _, err := client.Send(context.Background(), n); err != nil {
 		t.Fatalf(errSend, err)
 	}
 
 	assertFormValues(t, received, map[string]string{
+		"tags":      "deploy,release",
 		"token":     testAPIToken,
 		"user":      testUserKey,
 		"message":   "deployed",
@@ -162,6 +165,36 @@ func TestSend_SuccessAndFormPayload(t *testing.T) {
 	})
 }
 
+func TestSend_FormattingAndTiming(t *testing.T) {
+	var received url.Values
+
+	ts := setupTestServer(t, &received)
+	defer ts.Close()
+
+	client := newTestClient(t, ts)
+
+	ttl := 3600
+	var timestamp int64 = 1331249662
+
+	n := domain.Notification{
+		Message:   "trace",
+		Monospace: true,
+		TTL:       &ttl,
+		Timestamp: &timestamp,
+	}
+
+	if _, err := client.Send(context.Background(), n); err != nil {
+		t.Fatalf(errSend, err)
+	}
+
+	assertFormValues(t, received, map[string]string{
+		"monospace": "1",
+		"ttl":       "3600",
+		"timestamp": "1331249662",
+	})
+	assertFormValueEmpty(t, received, "html")
+}
+
 func TestSend_EmergencyPriority_DefaultRetryExpire(t *testing.T) {
 	var received url.Values
 
@@ -176,7 +209,7 @@ func TestSend_EmergencyPriority_DefaultRetryExpire(t *testing.T) {
 		Priority: &priority,
 	}
 
-	if err := client.Send(context.Background(), n); err != nil {
+	if _, err := client.Send(context.Background(), n); err != nil {
 		t.Fatalf(errSend, err)
 	}
 
@@ -201,7 +234,7 @@ func TestSend_PriorityNotEmergency_NoRetryExpire(t *testing.T) {
 		Priority: &priority,
 	}
 
-	if err := client.Send(context.Background(), n); err != nil {
+	if _, err := client.Send(context.Background(), n); err != nil {
 		t.Fatalf(errSend, err)
 	}
 
@@ -221,13 +254,35 @@ func TestSend_NoPriority(t *testing.T) {
 		Message: "test without priority",
 	}
 
-	if err := client.Send(context.Background(), n); err != nil {
+	if _, err := client.Send(context.Background(), n); err != nil {
 		t.Fatalf(errSend, err)
 	}
 
 	assertFormValueEmpty(t, received, "priority")
 }
 
+func TestSend_UserOverridesConfiguredKey(t *testing.T) {
+	var received url.Values
+
+	ts := setupTestServer(t, &received)
+	defer ts.Close()
+
+	client := newTestClient(t, ts)
+
+	n := domain.Notification{
+		Message: "to the team",
+		User:    "user-2,group-3",
+	}
+
+	if _, err := client.Send(context.Background(), n); err != nil {
+		t.Fatalf(errSend, err)
+	}
+
+	if got := received.Get("user"); got != "user-2,group-3" {
+		t.Fatalf("user = %q, want %q", got, "user-2,group-3")
+	}
+}
+
 func TestSend_EmptyOptionalFields(t *testing.T) {
 	var received url.Values
 
@@ -242,7 +297,7 @@ func TestSend_EmptyOptionalFields(t *testing.T) {
 		Sound:   "   ",
 	}
 
-	if err := client.Send(context.Background(), n); err != nil {
+	if _, err := client.Send(context.Background(), n); err != nil {
 		t.Fatalf(errSend, err)
 	}
 
@@ -262,7 +317,7 @@ func TestSend_RequestError(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel()
 
-	err := client.Send(ctx, domain.Notification{Message: "test"})
+	_, err := client.Send(ctx, domain.Notification{Message: "test"})
 	if err == nil {
 		t.Fatal(errSendNil)
 	}
@@ -278,7 +333,7 @@ func TestSend_InvalidURL(t *testing.T) {
 		t.Fatalf(errNewClient, err)
 	}
 
-	err = client.Send(context.Background(), domain.Notification{Message: "test"})
+	_, err = client.Send(context.Background(), domain.Notification{Message: "test"})
 	if err == nil {
 		t.Fatal(errSendNil)
 	}
@@ -297,7 +352,7 @@ func TestSend_Non2xx(t *testing.T) {
 
 	client := newTestClient(t, ts)
 
-	err := client.Send(context.Background(), domain.Notification{Message: "hello"})
+	_, err := client.Send(context.Background(), domain.Notification{Message: "hello"})
 	if err == nil {
 		t.Fatal(errSendNil)
 	}
@@ -306,3 +361,134 @@ func TestSend_Non2xx(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
+
+func TestSend_ParsesResultAndRateLimit(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-Limit-App-Limit", "10000")
+		w.Header().Set("X-Limit-App-Remaining", "7496")
+		w.Header().Set("X-Limit-App-Reset", "1393653600")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"status":1,"request":"req-1","receipt":"rcpt-1"}`))
+	}))
+	defer ts.Close()
+
+	client := newTestClient(t, ts)
+
+	result, err := client.Send(context.Background(), domain.Notification{Message: "hello"})
+	if err != nil {
+		t.Fatalf(errSend, err)
+	}
+
+	if result.Request != "req-1" || result.Receipt != "rcpt-1" {
+		t.Fatalf("result = %+v, want request req-1 and receipt rcpt-1", result)
+	}
+
+	if result.RateLimit == nil {
+		t.Fatal("rate limit is nil")
+	}
+
+	if result.RateLimit.Limit != 10000 || result.RateLimit.Remaining != 7496 {
+		t.Fatalf("rate limit = %+v", result.RateLimit)
+	}
+
+	if !result.RateLimit.Reset.Equal(time.Unix(1393653600, 0)) {
+		t.Fatalf("reset = %v", result.RateLimit.Reset)
+	}
+}
+
+func TestSend_NoRateLimitHeaders(t *testing.T) {
+	var received url.Values
+
+	ts := setupTestServer(t, &received)
+	defer ts.Close()
+
+	client := newTestClient(t, ts)
+
+	result, err := client.Send(context.Background(), domain.Notification{Message: "hello"})
+	if err != nil {
+		t.Fatalf(errSend, err)
+	}
+
+	if result.RateLimit != nil {
+		t.Fatalf("rate limit = %+v, want nil", result.RateLimit)
+	}
+}
+
+func TestSend_Base64Attachment(t *testing.T) {
+	var received url.Values
+
+	ts := setupTestServer(t, &received)
+	defer ts.Close()
+
+	client := newTestClient(t, ts)
+
+	n := domain.Notification{
+		Message:    "chart",
+		Attachment: &domain.Attachment{MIMEType: "image/png", Data: []byte("png-bytes")},
+	}
+
+	if _, err := client.Send(context.Background(), n); err != nil {
+		t.Fatalf(errSend, err)
+	}
+
+	assertFormValues(t, received, map[string]string{
+		"message":           "chart",
+		"attachment_base64": "cG5nLWJ5dGVz",
+		"attachment_type":   "image/png",
+	})
+}
+
+func TestSend_FileAttachmentMultipart(t *testing.T) {
+	var (
+		gotMessage  string
+		gotToken    string
+		gotFilename string
+		gotType     string
+		gotData     []byte
+	)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("parse multipart error: %v", err)
+		}
+
+		gotMessage = r.FormValue("message")
+		gotToken = r.FormValue("token")
+
+		file, header, err := r.FormFile("attachment")
+		if err != nil {
+			t.Fatalf("form file error: %v", err)
+		}
+
+		gotFilename = header.Filename
+		gotType = header.Header.Get("Content-Type")
+		gotData, _ = io.ReadAll(file)
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"status":1}`))
+	}))
+	defer ts.Close()
+
+	client := newTestClient(t, ts)
+
+	n := domain.Notification{
+		Message: "screenshot",
+		Attachment: &domain.Attachment{
+			Filename: "failure.png",
+			MIMEType: "image/png",
+			Data:     []byte("png-bytes"),
+		},
+	}
+
+	if _, err := client.Send(context.Background(), n); err != nil {
+		t.Fatalf(errSend, err)
+	}
+
+	if gotMessage != "screenshot" || gotToken != testAPIToken {
+		t.Fatalf("message = %q token = %q", gotMessage, gotToken)
+	}
+
+	if gotFilename != "failure.png" || gotType != "image/png" || string(gotData) != "png-bytes" {
+		t.Fatalf("attachment = %q %q %q", gotFilename, gotType, gotData)
+	}
+}
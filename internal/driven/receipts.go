@@ -0,0 +1,80 @@
+package driven
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+type receiptResponse struct {
+	AcknowledgedBy       string `json:"acknowledged_by"`
+	AcknowledgedByDevice string `json:"acknowledged_by_device"`
+	AcknowledgedAt       int64  `json:"acknowledged_at"`
+	LastDeliveredAt      int64  `json:"last_delivered_at"`
+	ExpiresAt            int64  `json:"expires_at"`
+	CalledBackAt         int64  `json:"called_back_at"`
+	Acknowledged         int    `json:"acknowledged"`
+	Expired              int    `json:"expired"`
+	CalledBack           int    `json:"called_back"`
+}
+
+func (c *PushoverClient) GetReceipt(ctx context.Context, receipt string) (domain.Receipt, error) {
+	query := url.Values{}
+	query.Set("token", c.apiToken)
+
+	var parsed receiptResponse
+	if _, err := c.get(ctx, c.endpoint("receipts", receipt), query, &parsed); err != nil {
+		return domain.Receipt{}, err
+	}
+
+	return domain.Receipt{
+		Acknowledged:         parsed.Acknowledged == 1,
+		AcknowledgedAt:       unixTime(parsed.AcknowledgedAt),
+		AcknowledgedBy:       parsed.AcknowledgedBy,
+		AcknowledgedByDevice: parsed.AcknowledgedByDevice,
+		LastDeliveredAt:      unixTime(parsed.LastDeliveredAt),
+		Expired:              parsed.Expired == 1,
+		ExpiresAt:            unixTime(parsed.ExpiresAt),
+		CalledBack:           parsed.CalledBack == 1,
+		CalledBackAt:         unixTime(parsed.CalledBackAt),
+	}, nil
+}
+
+type cancelByTagResponse struct {
+	Canceled int `json:"canceled"`
+}
+
+func (c *PushoverClient) CancelReceipt(ctx context.Context, receipt string) error {
+	form := url.Values{}
+	form.Set("token", c.apiToken)
+
+	var parsed apiResponse
+	if _, err := c.postForm(ctx, c.endpoint("receipts", receipt, "cancel"), form, &parsed); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *PushoverClient) CancelByTag(ctx context.Context, tag string) (int, error) {
+	form := url.Values{}
+	form.Set("token", c.apiToken)
+
+	var parsed cancelByTagResponse
+	if _, err := c.postForm(ctx, c.endpoint("receipts", "cancel_by_tag", tag), form, &parsed); err != nil {
+		return 0, err
+	}
+
+	return parsed.Canceled, nil
+}
+
+// unixTime maps Pushover's "0 means never" timestamps to the zero time.
+func unixTime(seconds int64) time.Time {
+	if seconds == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(seconds, 0).UTC()
+}
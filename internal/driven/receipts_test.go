@@ -0,0 +1,160 @@
+package driven
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetReceipt_Acknowledged(t *testing.T) {
+	var (
+		gotPath  string
+		gotToken string
+	)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Fatalf("method = %s, want GET", r.Method)
+		}
+
+		gotPath = r.URL.Path
+		gotToken = r.URL.Query().Get("token")
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"status":1,"acknowledged":1,"acknowledged_at":1360019238,` +
+			`"acknowledged_by":"user-1","acknowledged_by_device":"iphone","last_delivered_at":1360001238,` +
+			`"expired":0,"expires_at":1360019290,"called_back":0,"called_back_at":0}`))
+	}))
+	defer ts.Close()
+
+	client := newTestClient(t, ts)
+
+	receipt, err := client.GetReceipt(context.Background(), "rcpt-1")
+	if err != nil {
+		t.Fatalf("GetReceipt() error = %v", err)
+	}
+
+	if gotPath != "/receipts/rcpt-1.json" {
+		t.Fatalf("path = %q, want /receipts/rcpt-1.json", gotPath)
+	}
+
+	if gotToken != testAPIToken {
+		t.Fatalf("token = %q, want %q", gotToken, testAPIToken)
+	}
+
+	if !receipt.Acknowledged || receipt.AcknowledgedBy != "user-1" || receipt.AcknowledgedByDevice != "iphone" {
+		t.Fatalf("receipt = %+v", receipt)
+	}
+
+	if !receipt.AcknowledgedAt.Equal(time.Unix(1360019238, 0)) {
+		t.Fatalf("acknowledged_at = %v", receipt.AcknowledgedAt)
+	}
+
+	if receipt.Expired || receipt.CalledBack || !receipt.CalledBackAt.IsZero() {
+		t.Fatalf("receipt = %+v, want not expired and not called back", receipt)
+	}
+}
+
+func TestGetReceipt_EndpointNextToMessagesURL(t *testing.T) {
+	var gotPath string
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.EscapedPath()
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"status":1}`))
+	}))
+	defer ts.Close()
+
+	cfg := testConfig(ts.URL + "/1/messages.json")
+
+	client, err := NewPushoverClient(cfg, ts.Client())
+	if err != nil {
+		t.Fatalf(errNewClient, err)
+	}
+
+	if _, err := client.GetReceipt(context.Background(), "a/b"); err != nil {
+		t.Fatalf("GetReceipt() error = %v", err)
+	}
+
+	if gotPath != "/1/receipts/a%2Fb.json" {
+		t.Fatalf("path = %q, want escaped receipt under /1/receipts", gotPath)
+	}
+}
+
+func TestGetReceipt_Non2xx(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"status":0,"errors":["receipt not found"]}`))
+	}))
+	defer ts.Close()
+
+	client := newTestClient(t, ts)
+
+	if _, err := client.GetReceipt(context.Background(), "missing"); err == nil {
+		t.Fatal("GetReceipt() error = nil, want non-nil")
+	}
+}
+
+func TestCancelReceipt(t *testing.T) {
+	var (
+		gotPath  string
+		gotToken string
+	)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assertRequestMethodAndContentType(t, r)
+
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("parse form error: %v", err)
+		}
+
+		gotPath = r.URL.Path
+		gotToken = r.PostForm.Get("token")
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"status":1}`))
+	}))
+	defer ts.Close()
+
+	client := newTestClient(t, ts)
+
+	if err := client.CancelReceipt(context.Background(), "rcpt-1"); err != nil {
+		t.Fatalf("CancelReceipt() error = %v", err)
+	}
+
+	if gotPath != "/receipts/rcpt-1/cancel.json" || gotToken != testAPIToken {
+		t.Fatalf("path = %q token = %q", gotPath, gotToken)
+	}
+}
+
+func TestCancelByTag(t *testing.T) {
+	var gotPath string
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assertRequestMethodAndContentType(t, r)
+
+		gotPath = r.URL.Path
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"status":1,"canceled":2}`))
+	}))
+	defer ts.Close()
+
+	client := newTestClient(t, ts)
+
+	canceled, err := client.CancelByTag(context.Background(), "deploy-42")
+	if err != nil {
+		t.Fatalf("CancelByTag() error = %v", err)
+	}
+
+	if gotPath != "/receipts/cancel_by_tag/deploy-42.json" {
+		t.Fatalf("path = %q", gotPath)
+	}
+
+	if canceled != 2 {
+		t.Fatalf("canceled = %d, want 2", canceled)
+	}
+}
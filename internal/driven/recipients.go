@@ -0,0 +1,73 @@
+package driven
+
+import (
+	"context"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+// devicesCacheTTL bounds how long a newly registered device takes to be accepted by send.
+const devicesCacheTTL = time.Hour
+
+type deviceCache struct {
+	fetchedAt time.Time
+	devices   []string
+	mu        sync.Mutex
+}
+
+type recipientResponse struct {
+	Devices  []string `json:"devices"`
+	Licenses []string `json:"licenses"`
+	Group    int      `json:"group"`
+}
+
+func (c *PushoverClient) ValidateRecipient(ctx context.Context, user, device string) (domain.Recipient, error) {
+	if strings.TrimSpace(user) == "" {
+		user = c.userKey
+	}
+
+	form := url.Values{}
+	form.Set("token", c.apiToken)
+	form.Set("user", user)
+	setOptionalString(form, "device", device)
+
+	var parsed recipientResponse
+	if _, err := c.postForm(ctx, c.endpoint("users", "validate"), form, &parsed); err != nil {
+		return domain.Recipient{}, err
+	}
+
+	return domain.Recipient{
+		Devices:  parsed.Devices,
+		Licenses: parsed.Licenses,
+		Group:    parsed.Group == 1,
+	}, nil
+}
+
+func (c *PushoverClient) Devices(ctx context.Context) ([]string, error) {
+	c.devices.mu.Lock()
+	defer c.devices.mu.Unlock()
+
+	if c.devices.devices != nil && time.Since(c.devices.fetchedAt) < devicesCacheTTL {
+		return slices.Clone(c.devices.devices), nil
+	}
+
+	recipient, err := c.ValidateRecipient(ctx, "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	devices := recipient.Devices
+	if devices == nil {
+		devices = []string{}
+	}
+
+	c.devices.devices = devices
+	c.devices.fetchedAt = time.Now()
+
+	return slices.Clone(devices), nil
+}
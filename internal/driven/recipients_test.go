@@ -0,0 +1,99 @@
+package driven
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestValidateRecipient(t *testing.T) {
+	var (
+		gotPath   string
+		gotUser   string
+		gotDevice string
+	)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assertRequestMethodAndContentType(t, r)
+
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("parse form error: %v", err)
+		}
+
+		gotPath = r.URL.Path
+		gotUser = r.PostForm.Get("user")
+		gotDevice = r.PostForm.Get("device")
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"status":1,"group":0,"devices":["iphone","desktop"],"licenses":["Android","iOS"]}`))
+	}))
+	defer ts.Close()
+
+	client := newTestClient(t, ts)
+
+	recipient, err := client.ValidateRecipient(context.Background(), "", "iphone")
+	if err != nil {
+		t.Fatalf("ValidateRecipient() error = %v", err)
+	}
+
+	if gotPath != "/users/validate.json" || gotUser != testUserKey || gotDevice != "iphone" {
+		t.Fatalf("path = %q user = %q device = %q", gotPath, gotUser, gotDevice)
+	}
+
+	if recipient.Group || !slices.Equal(recipient.Devices, []string{"iphone", "desktop"}) || len(recipient.Licenses) != 2 {
+		t.Fatalf("recipient = %+v", recipient)
+	}
+
+	if _, err := client.ValidateRecipient(context.Background(), "group-1", ""); err != nil {
+		t.Fatalf("ValidateRecipient() error = %v", err)
+	}
+
+	if gotUser != "group-1" || gotDevice != "" {
+		t.Fatalf("user = %q device = %q, want explicit user and no device", gotUser, gotDevice)
+	}
+}
+
+func TestValidateRecipient_Invalid(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"status":0,"user":"invalid","errors":["user key is invalid"]}`))
+	}))
+	defer ts.Close()
+
+	client := newTestClient(t, ts)
+
+	if _, err := client.ValidateRecipient(context.Background(), "bad", ""); err == nil {
+		t.Fatal("ValidateRecipient() error = nil, want non-nil")
+	}
+}
+
+func TestDevices_Cached(t *testing.T) {
+	var calls int
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"status":1,"group":0,"devices":["iphone"]}`))
+	}))
+	defer ts.Close()
+
+	client := newTestClient(t, ts)
+
+	for range 2 {
+		devices, err := client.Devices(context.Background())
+		if err != nil {
+			t.Fatalf("Devices() error = %v", err)
+		}
+
+		if !slices.Equal(devices, []string{"iphone"}) {
+			t.Fatalf("devices = %v", devices)
+		}
+	}
+
+	if calls != 1 {
+		t.Fatalf("calls = %d, want 1", calls)
+	}
+}
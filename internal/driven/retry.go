@@ -0,0 +1,106 @@
+package driven
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+// RetryPolicy controls how often a request failing with a retryable error is repeated.
+// The zero value makes a single attempt.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// retrier repeats calls failing with domain.IsRetryable errors using jittered exponential backoff.
+type retrier struct {
+	sleep  func(ctx context.Context, d time.Duration) error
+	policy RetryPolicy
+}
+
+func newRetrier(policy RetryPolicy) retrier {
+	return retrier{policy: policy, sleep: sleepContext}
+}
+
+// run calls fn until it succeeds, fails permanently, runs out of attempts or
+// the next wait would outlast the context deadline. It returns fn's last error.
+func (r retrier) run(ctx context.Context, fn func(attempt int) error) error {
+	for attempt := 0; ; attempt++ {
+		err := fn(attempt)
+		if err == nil || !domain.IsRetryable(err) || attempt+1 >= r.policy.MaxAttempts {
+			return err
+		}
+
+		delay := r.delay(attempt, err)
+
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return err
+		}
+
+		if sleepErr := r.sleep(ctx, delay); sleepErr != nil {
+			return errors.Join(err, sleepErr)
+		}
+	}
+}
+
+// delay picks a random wait in [d/2, d] where d doubles per attempt up to MaxDelay.
+// A Retry-After from Pushover takes precedence when it asks for longer.
+func (r retrier) delay(attempt int, err error) time.Duration {
+	backoff := r.policy.BaseDelay
+	for i := 0; i < attempt && backoff < r.policy.MaxDelay; i++ {
+		backoff *= 2
+	}
+
+	if r.policy.MaxDelay > 0 && backoff > r.policy.MaxDelay {
+		backoff = r.policy.MaxDelay
+	}
+
+	if backoff > 0 {
+		backoff = backoff/2 + rand.N(backoff/2+1) //nolint:gosec // Jitter does not need a secure source.
+	}
+
+	var apiErr *domain.APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > backoff {
+		return apiErr.RetryAfter
+	}
+
+	return backoff
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// parseRetryAfter reads a Retry-After header given either in seconds or as an HTTP date.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+
+	if at, err := http.ParseTime(value); err == nil {
+		return max(at.Sub(now), 0)
+	}
+
+	return 0
+}
@@ -0,0 +1,273 @@
+package driven
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+type scriptedResponse struct {
+	header map[string]string
+	body   string
+	status int
+}
+
+// newRetryTestClient serves the scripted responses in order and records the bodies and waits.
+func newRetryTestClient(
+	t *testing.T,
+	policy RetryPolicy,
+	responses ...scriptedResponse,
+) (*PushoverClient, *[]string, *[]time.Duration) {
+	t.Helper()
+
+	var bodies []string
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("read body error: %v", err)
+		}
+
+		bodies = append(bodies, string(body))
+
+		resp := responses[min(len(bodies), len(responses))-1]
+		for key, value := range resp.header {
+			w.Header().Set(key, value)
+		}
+
+		w.WriteHeader(resp.status)
+		_, _ = w.Write([]byte(resp.body))
+	}))
+	t.Cleanup(ts.Close)
+
+	cfg := testConfig(ts.URL)
+	cfg.Retry = policy
+
+	client, err := NewPushoverClient(cfg, ts.Client())
+	if err != nil {
+		t.Fatalf(errNewClient, err)
+	}
+
+	var waits []time.Duration
+
+	client.retry.sleep = func(_ context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+
+	return client, &bodies, &waits
+}
+
+var (
+	testRetryPolicy = RetryPolicy{MaxAttempts: 3, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	okResponse      = scriptedResponse{status: http.StatusOK, body: `{"status":1,"request":"req-ok"}`}
+	outageResponse  = scriptedResponse{status: http.StatusServiceUnavailable, body: `{"status":0}`}
+)
+
+func TestSend_RetriesTransientFailures(t *testing.T) {
+	client, bodies, waits := newRetryTestClient(t, testRetryPolicy, outageResponse, outageResponse, okResponse)
+
+	result, err := client.Send(context.Background(), domain.Notification{Message: "hello"})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	if result.Request != "req-ok" {
+		t.Fatalf("Request = %q, want req-ok", result.Request)
+	}
+
+	if len(*bodies) != 3 {
+		t.Fatalf("attempts = %d, want 3", len(*bodies))
+	}
+
+	for i, body := range *bodies {
+		if !strings.Contains(body, "message=hello") {
+			t.Fatalf("attempt %d body = %q, want the form resent", i+1, body)
+		}
+	}
+
+	if len(*waits) != 2 {
+		t.Fatalf("waits = %v, want 2", *waits)
+	}
+}
+
+func TestSend_RetriesMultipartAttachment(t *testing.T) {
+	client, bodies, _ := newRetryTestClient(t, testRetryPolicy, outageResponse, okResponse)
+
+	_, err := client.Send(context.Background(), domain.Notification{
+		Message:    "hello",
+		Attachment: &domain.Attachment{Filename: "a.png", MIMEType: "image/png", Data: []byte("png-bytes")},
+	})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	if len(*bodies) != 2 || !strings.Contains((*bodies)[1], "png-bytes") {
+		t.Fatalf("bodies = %q, want attachment resent", *bodies)
+	}
+}
+
+func TestSend_GivesUpAfterMaxAttempts(t *testing.T) {
+	client, bodies, _ := newRetryTestClient(t, testRetryPolicy, outageResponse)
+
+	_, err := client.Send(context.Background(), domain.Notification{Message: "hello"})
+	if !errors.Is(err, domain.ErrUpstreamUnavailable) {
+		t.Fatalf("error = %v, want %v", err, domain.ErrUpstreamUnavailable)
+	}
+
+	if len(*bodies) != testRetryPolicy.MaxAttempts {
+		t.Fatalf("attempts = %d, want %d", len(*bodies), testRetryPolicy.MaxAttempts)
+	}
+}
+
+func TestSend_DoesNotRetryPermanentFailures(t *testing.T) {
+	tests := []struct {
+		name string
+		resp scriptedResponse
+	}{
+		{
+			name: "invalid user",
+			resp: scriptedResponse{status: http.StatusBadRequest, body: `{"user":"invalid","errors":["user identifier is invalid"]}`},
+		},
+		{
+			name: "quota exceeded",
+			resp: scriptedResponse{
+				status: http.StatusTooManyRequests,
+				header: map[string]string{"X-Limit-App-Remaining": "0"},
+				body:   `{"errors":["monthly limit reached"]}`,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client, bodies, _ := newRetryTestClient(t, testRetryPolicy, tc.resp, okResponse)
+
+			if _, err := client.Send(context.Background(), domain.Notification{Message: "hello"}); err == nil {
+				t.Fatal("Send() error = nil, want error")
+			}
+
+			if len(*bodies) != 1 {
+				t.Fatalf("attempts = %d, want 1", len(*bodies))
+			}
+		})
+	}
+}
+
+func TestSend_RetriesRateLimitHonoringRetryAfter(t *testing.T) {
+	limited := scriptedResponse{
+		status: http.StatusTooManyRequests,
+		header: map[string]string{"Retry-After": "7"},
+		body:   `{"errors":["too many requests"]}`,
+	}
+	client, bodies, waits := newRetryTestClient(t, testRetryPolicy, limited, okResponse)
+
+	if _, err := client.Send(context.Background(), domain.Notification{Message: "hello"}); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	if len(*bodies) != 2 || len(*waits) != 1 || (*waits)[0] != 7*time.Second {
+		t.Fatalf("attempts = %d, waits = %v, want 2 attempts after 7s", len(*bodies), *waits)
+	}
+}
+
+func TestSend_StopsWhenWaitOutlastsDeadline(t *testing.T) {
+	limited := scriptedResponse{
+		status: http.StatusTooManyRequests,
+		header: map[string]string{"Retry-After": "60"},
+		body:   `{"errors":["too many requests"]}`,
+	}
+	client, bodies, waits := newRetryTestClient(t, testRetryPolicy, limited, okResponse)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := client.Send(ctx, domain.Notification{Message: "hello"})
+	if !errors.Is(err, domain.ErrRateLimited) {
+		t.Fatalf("error = %v, want %v", err, domain.ErrRateLimited)
+	}
+
+	if len(*bodies) != 1 || len(*waits) != 0 {
+		t.Fatalf("attempts = %d, waits = %v, want a single attempt", len(*bodies), *waits)
+	}
+}
+
+func TestSend_SingleAttemptByDefault(t *testing.T) {
+	client, bodies, _ := newRetryTestClient(t, RetryPolicy{}, outageResponse, okResponse)
+
+	if _, err := client.Send(context.Background(), domain.Notification{Message: "hello"}); err == nil {
+		t.Fatal("Send() error = nil, want error")
+	}
+
+	if len(*bodies) != 1 {
+		t.Fatalf("attempts = %d, want 1", len(*bodies))
+	}
+}
+
+func TestRetrier_DelayBackoffAndJitter(t *testing.T) {
+	r := newRetrier(RetryPolicy{MaxAttempts: 10, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second})
+	err := &domain.APIError{Kind: domain.ErrUpstreamUnavailable}
+
+	for attempt, ceiling := range []time.Duration{
+		100 * time.Millisecond,
+		200 * time.Millisecond,
+		400 * time.Millisecond,
+		800 * time.Millisecond,
+		time.Second,
+		time.Second,
+	} {
+		for range 20 {
+			got := r.delay(attempt, err)
+			if got < ceiling/2 || got > ceiling {
+				t.Fatalf("delay(%d) = %v, want within [%v, %v]", attempt, got, ceiling/2, ceiling)
+			}
+		}
+	}
+}
+
+func TestRetrier_CanceledWhileWaiting(t *testing.T) {
+	r := newRetrier(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Hour, MaxDelay: time.Hour})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+
+	err := r.run(ctx, func(int) error {
+		calls++
+		cancel()
+
+		return domain.ErrUpstreamUnavailable
+	})
+
+	if calls != 1 || !errors.Is(err, context.Canceled) || !errors.Is(err, domain.ErrUpstreamUnavailable) {
+		t.Fatalf("calls = %d, error = %v", calls, err)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{value: "", want: 0},
+		{value: "30", want: 30 * time.Second},
+		{value: "-5", want: 0},
+		{value: "Mon, 01 Jan 2024 12:01:00 GMT", want: time.Minute},
+		{value: "Mon, 01 Jan 2024 11:00:00 GMT", want: 0},
+		{value: "soon", want: 0},
+	}
+
+	for _, tc := range tests {
+		if got := parseRetryAfter(tc.value, now); got != tc.want {
+			t.Fatalf("parseRetryAfter(%q) = %v, want %v", tc.value, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,290 @@
+package driven
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+const scheduleFileVersion = 1
+
+// FileScheduleStore keeps scheduled and recurring notifications in a JSON file so they survive restarts.
+// Every change rewrites the file through a temporary file and a rename, so a crash never
+// leaves it half written. Servers sharing the file, e.g. one per stdio client, take turns
+// through a lock on a file next to it.
+type FileScheduleStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+type scheduleFile struct {
+	Scheduled []domain.ScheduledNotification `json:"scheduled"`
+	Recurring []domain.RecurringNotification `json:"recurring,omitempty"`
+	Version   int                            `json:"version"`
+}
+
+func NewFileScheduleStore(path string) (*FileScheduleStore, error) {
+	if path == "" {
+		return nil, errors.New("schedule file path is required")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("create state dir: %w", err)
+	}
+
+	return &FileScheduleStore{path: path}, nil
+}
+
+func (s *FileScheduleStore) SaveScheduled(_ context.Context, scheduled domain.ScheduledNotification) error {
+	unlock, err := s.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	file, err := s.read()
+	if err != nil {
+		return err
+	}
+
+	index := slices.IndexFunc(file.Scheduled, func(item domain.ScheduledNotification) bool { return item.ID == scheduled.ID })
+	if index >= 0 {
+		file.Scheduled[index] = scheduled
+	} else {
+		file.Scheduled = append(file.Scheduled, scheduled)
+	}
+
+	return s.write(file)
+}
+
+func (s *FileScheduleStore) DeleteScheduled(_ context.Context, id string) (bool, error) {
+	unlock, err := s.lock()
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+
+	file, err := s.read()
+	if err != nil {
+		return false, err
+	}
+
+	before := len(file.Scheduled)
+
+	file.Scheduled = slices.DeleteFunc(file.Scheduled, func(item domain.ScheduledNotification) bool { return item.ID == id })
+	if len(file.Scheduled) == before {
+		return false, nil
+	}
+
+	return true, s.write(file)
+}
+
+func (s *FileScheduleStore) ListScheduled(_ context.Context) ([]domain.ScheduledNotification, error) {
+	unlock, err := s.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	file, err := s.read()
+
+	return file.Scheduled, err
+}
+
+func (s *FileScheduleStore) UpdateScheduled(
+	_ context.Context,
+	id string,
+	change func(scheduled *domain.ScheduledNotification) error,
+) (domain.ScheduledNotification, bool, error) {
+	unlock, err := s.lock()
+	if err != nil {
+		return domain.ScheduledNotification{}, false, err
+	}
+	defer unlock()
+
+	file, err := s.read()
+	if err != nil {
+		return domain.ScheduledNotification{}, false, err
+	}
+
+	index := slices.IndexFunc(file.Scheduled, func(item domain.ScheduledNotification) bool { return item.ID == id })
+	if index < 0 {
+		return domain.ScheduledNotification{}, false, nil
+	}
+
+	if err := change(&file.Scheduled[index]); err != nil {
+		return domain.ScheduledNotification{}, true, err
+	}
+
+	return file.Scheduled[index], true, s.write(file)
+}
+
+func (s *FileScheduleStore) SaveRecurring(_ context.Context, recurring domain.RecurringNotification) error {
+	unlock, err := s.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	file, err := s.read()
+	if err != nil {
+		return err
+	}
+
+	index := slices.IndexFunc(file.Recurring, func(item domain.RecurringNotification) bool { return item.ID == recurring.ID })
+	if index >= 0 {
+		file.Recurring[index] = recurring
+	} else {
+		file.Recurring = append(file.Recurring, recurring)
+	}
+
+	return s.write(file)
+}
+
+func (s *FileScheduleStore) DeleteRecurring(_ context.Context, id string) (bool, error) {
+	unlock, err := s.lock()
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+
+	file, err := s.read()
+	if err != nil {
+		return false, err
+	}
+
+	before := len(file.Recurring)
+
+	file.Recurring = slices.DeleteFunc(file.Recurring, func(item domain.RecurringNotification) bool { return item.ID == id })
+	if len(file.Recurring) == before {
+		return false, nil
+	}
+
+	return true, s.write(file)
+}
+
+func (s *FileScheduleStore) ListRecurring(_ context.Context) ([]domain.RecurringNotification, error) {
+	unlock, err := s.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	file, err := s.read()
+
+	return file.Recurring, err
+}
+
+func (s *FileScheduleStore) UpdateRecurring(
+	_ context.Context,
+	id string,
+	change func(recurring *domain.RecurringNotification) error,
+) (domain.RecurringNotification, bool, error) {
+	unlock, err := s.lock()
+	if err != nil {
+		return domain.RecurringNotification{}, false, err
+	}
+	defer unlock()
+
+	file, err := s.read()
+	if err != nil {
+		return domain.RecurringNotification{}, false, err
+	}
+
+	index := slices.IndexFunc(file.Recurring, func(item domain.RecurringNotification) bool { return item.ID == id })
+	if index < 0 {
+		return domain.RecurringNotification{}, false, nil
+	}
+
+	if err := change(&file.Recurring[index]); err != nil {
+		return domain.RecurringNotification{}, true, err
+	}
+
+	return file.Recurring[index], true, s.write(file)
+}
+
+// lock gives the caller the file to itself, against other goroutines and other processes,
+// until it calls the returned function.
+func (s *FileScheduleStore) lock() (func(), error) {
+	s.mu.Lock()
+
+	lockFile, err := os.OpenFile(s.path+".lock", os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		s.mu.Unlock()
+		return nil, fmt.Errorf("lock schedule file: %w", err)
+	}
+
+	if err := lockExclusive(lockFile); err != nil {
+		_ = lockFile.Close()
+		s.mu.Unlock()
+
+		return nil, fmt.Errorf("lock schedule file: %w", err)
+	}
+
+	return func() {
+		// Closing the file releases the lock.
+		_ = lockFile.Close()
+		s.mu.Unlock()
+	}, nil
+}
+
+// read returns an empty file when none has been written yet.
+func (s *FileScheduleStore) read() (scheduleFile, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return scheduleFile{Version: scheduleFileVersion}, nil
+	}
+
+	if err != nil {
+		return scheduleFile{}, fmt.Errorf("read schedule file: %w", err)
+	}
+
+	var file scheduleFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return scheduleFile{}, fmt.Errorf("decode schedule file: %w", err)
+	}
+
+	if file.Version != scheduleFileVersion {
+		return scheduleFile{}, fmt.Errorf("unsupported schedule file version %d", file.Version)
+	}
+
+	return file, nil
+}
+
+func (s *FileScheduleStore) write(file scheduleFile) error {
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode schedule file: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("write schedule file: %w", err)
+	}
+
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("write schedule file: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write schedule file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("write schedule file: %w", err)
+	}
+
+	return nil
+}
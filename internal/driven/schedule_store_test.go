@@ -0,0 +1,278 @@
+package driven
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+func newTestScheduleStore(t *testing.T) (*FileScheduleStore, string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "state", "scheduled.json")
+
+	store, err := NewFileScheduleStore(path)
+	if err != nil {
+		t.Fatalf("NewFileScheduleStore() error = %v", err)
+	}
+
+	return store, path
+}
+
+func TestFileScheduleStore_EmptyWithoutFile(t *testing.T) {
+	store, _ := newTestScheduleStore(t)
+
+	list, err := store.ListScheduled(t.Context())
+	if err != nil || len(list) != 0 {
+		t.Fatalf("ListScheduled() = %v, %v, want empty", list, err)
+	}
+}
+
+func TestFileScheduleStore_PersistsAcrossInstances(t *testing.T) {
+	store, path := newTestScheduleStore(t)
+	priority := 1
+	sendAt := time.Date(2026, 5, 1, 17, 0, 0, 0, time.UTC)
+
+	scheduled := domain.ScheduledNotification{
+		ID:           "a1",
+		SendAt:       sendAt,
+		Notification: domain.Notification{Message: "stand-up", Priority: &priority, User: "oncall"},
+	}
+
+	if err := store.SaveScheduled(t.Context(), scheduled); err != nil {
+		t.Fatalf("SaveScheduled() error = %v", err)
+	}
+
+	scheduled.Attempts = 2
+	if err := store.SaveScheduled(t.Context(), scheduled); err != nil {
+		t.Fatalf("SaveScheduled() error = %v", err)
+	}
+
+	reopened, err := NewFileScheduleStore(path)
+	if err != nil {
+		t.Fatalf("NewFileScheduleStore() error = %v", err)
+	}
+
+	list, err := reopened.ListScheduled(t.Context())
+	if err != nil {
+		t.Fatalf("ListScheduled() error = %v", err)
+	}
+
+	if len(list) != 1 {
+		t.Fatalf("ListScheduled() = %+v, want one item", list)
+	}
+
+	got := list[0]
+	if got.ID != "a1" || !got.SendAt.Equal(sendAt) || got.Attempts != 2 ||
+		got.Notification.Message != "stand-up" || *got.Notification.Priority != 1 || got.Notification.User != "oncall" {
+		t.Fatalf("scheduled = %+v", got)
+	}
+}
+
+func TestFileScheduleStore_Delete(t *testing.T) {
+	store, _ := newTestScheduleStore(t)
+
+	for _, id := range []string{"a1", "b2"} {
+		if err := store.SaveScheduled(t.Context(), domain.ScheduledNotification{ID: id}); err != nil {
+			t.Fatalf("SaveScheduled() error = %v", err)
+		}
+	}
+
+	found, err := store.DeleteScheduled(t.Context(), "a1")
+	if err != nil || !found {
+		t.Fatalf("DeleteScheduled(a1) = %v, %v, want true", found, err)
+	}
+
+	found, err = store.DeleteScheduled(t.Context(), "missing")
+	if err != nil || found {
+		t.Fatalf("DeleteScheduled(missing) = %v, %v, want false", found, err)
+	}
+
+	list, _ := store.ListScheduled(t.Context())
+	if len(list) != 1 || list[0].ID != "b2" {
+		t.Fatalf("ListScheduled() = %+v, want [b2]", list)
+	}
+}
+
+func TestFileScheduleStore_CorruptFile(t *testing.T) {
+	store, path := newTestScheduleStore(t)
+
+	if err := os.WriteFile(path, []byte(`{"scheduled":`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := store.ListScheduled(t.Context()); err == nil || !strings.Contains(err.Error(), "decode schedule file") {
+		t.Fatalf("ListScheduled() error = %v, want decode error", err)
+	}
+}
+
+func TestFileScheduleStore_Recurring(t *testing.T) {
+	store, path := newTestScheduleStore(t)
+
+	if err := store.SaveScheduled(t.Context(), domain.ScheduledNotification{ID: "once"}); err != nil {
+		t.Fatalf("SaveScheduled() error = %v", err)
+	}
+
+	recurring := domain.RecurringNotification{
+		ID:           "r1",
+		Cron:         "30 9 * * 1-5",
+		TimeZone:     "Europe/Berlin",
+		Notification: domain.Notification{Message: "summarize overnight CI"},
+	}
+
+	if err := store.SaveRecurring(t.Context(), recurring); err != nil {
+		t.Fatalf("SaveRecurring() error = %v", err)
+	}
+
+	recurring.Paused = true
+	if err := store.SaveRecurring(t.Context(), recurring); err != nil {
+		t.Fatalf("SaveRecurring() error = %v", err)
+	}
+
+	reopened, err := NewFileScheduleStore(path)
+	if err != nil {
+		t.Fatalf("NewFileScheduleStore() error = %v", err)
+	}
+
+	list, err := reopened.ListRecurring(t.Context())
+	if err != nil || len(list) != 1 || !list[0].Paused || list[0].Cron != "30 9 * * 1-5" || list[0].TimeZone != "Europe/Berlin" {
+		t.Fatalf("ListRecurring() = %+v, %v", list, err)
+	}
+
+	if scheduled, _ := reopened.ListScheduled(t.Context()); len(scheduled) != 1 {
+		t.Fatalf("ListScheduled() = %+v, want the one-off notification kept", scheduled)
+	}
+
+	if found, err := reopened.DeleteRecurring(t.Context(), "r1"); err != nil || !found {
+		t.Fatalf("DeleteRecurring(r1) = %v, %v, want true", found, err)
+	}
+
+	if found, err := reopened.DeleteRecurring(t.Context(), "r1"); err != nil || found {
+		t.Fatalf("DeleteRecurring(r1) again = %v, %v, want false", found, err)
+	}
+}
+
+func TestFileScheduleStore_UpdateRecurring(t *testing.T) {
+	store, _ := newTestScheduleStore(t)
+
+	if err := store.SaveRecurring(t.Context(), domain.RecurringNotification{ID: "r1", Cron: "@hourly"}); err != nil {
+		t.Fatalf("SaveRecurring() error = %v", err)
+	}
+
+	updated, found, err := store.UpdateRecurring(t.Context(), "r1", func(recurring *domain.RecurringNotification) error {
+		recurring.Paused = true
+		return nil
+	})
+	if err != nil || !found || !updated.Paused {
+		t.Fatalf("UpdateRecurring() = %+v, %v, %v, want paused", updated, found, err)
+	}
+
+	_, found, err = store.UpdateRecurring(t.Context(), "r1", func(recurring *domain.RecurringNotification) error {
+		recurring.Paused = false
+		return errors.New("conflict")
+	})
+	if err == nil || !found {
+		t.Fatalf("UpdateRecurring() = %v, %v, want the change error", found, err)
+	}
+
+	if list, _ := store.ListRecurring(t.Context()); !list[0].Paused {
+		t.Fatalf("ListRecurring() = %+v, want the failed change not saved", list)
+	}
+
+	if _, found, err := store.UpdateRecurring(t.Context(), "r2", nil); err != nil || found {
+		t.Fatalf("UpdateRecurring(r2) = %v, %v, want false", found, err)
+	}
+}
+
+func TestFileScheduleStore_UpdateScheduled(t *testing.T) {
+	store, path := newTestScheduleStore(t)
+	claimedUntil := time.Date(2026, 5, 1, 12, 10, 0, 0, time.UTC)
+
+	if err := store.SaveScheduled(t.Context(), domain.ScheduledNotification{ID: "a1"}); err != nil {
+		t.Fatalf("SaveScheduled() error = %v", err)
+	}
+
+	_, found, err := store.UpdateScheduled(t.Context(), "a1", func(scheduled *domain.ScheduledNotification) error {
+		scheduled.ClaimedBy = "server-1"
+		scheduled.ClaimedUntil = claimedUntil
+
+		return nil
+	})
+	if err != nil || !found {
+		t.Fatalf("UpdateScheduled() = %v, %v, want claimed", found, err)
+	}
+
+	_, found, err = store.UpdateScheduled(t.Context(), "a1", func(scheduled *domain.ScheduledNotification) error {
+		scheduled.ClaimedBy = "server-2"
+		return errors.New("claimed")
+	})
+	if err == nil || !found {
+		t.Fatalf("UpdateScheduled() = %v, %v, want the change error", found, err)
+	}
+
+	reopened, err := NewFileScheduleStore(path)
+	if err != nil {
+		t.Fatalf("NewFileScheduleStore() error = %v", err)
+	}
+
+	list, _ := reopened.ListScheduled(t.Context())
+	if len(list) != 1 || list[0].ClaimedBy != "server-1" || !list[0].ClaimedUntil.Equal(claimedUntil) {
+		t.Fatalf("ListScheduled() = %+v, want the first claim persisted", list)
+	}
+
+	if _, found, err := store.UpdateScheduled(t.Context(), "a2", nil); err != nil || found {
+		t.Fatalf("UpdateScheduled(a2) = %v, %v, want false", found, err)
+	}
+}
+
+// Stores opened on the same file stand in for servers sharing a state directory; without the
+// file lock their read-modify-write cycles overwrite each other.
+func TestFileScheduleStore_SharedBetweenInstances(t *testing.T) {
+	first, path := newTestScheduleStore(t)
+
+	second, err := NewFileScheduleStore(path)
+	if err != nil {
+		t.Fatalf("NewFileScheduleStore() error = %v", err)
+	}
+
+	const perStore = 20
+
+	var wg sync.WaitGroup
+
+	for i, store := range []*FileScheduleStore{first, second} {
+		for j := range perStore {
+			wg.Go(func() {
+				id := fmt.Sprintf("%d-%d", i, j)
+				if err := store.SaveScheduled(t.Context(), domain.ScheduledNotification{ID: id}); err != nil {
+					t.Errorf("SaveScheduled(%s) error = %v", id, err)
+				}
+			})
+		}
+	}
+
+	wg.Wait()
+
+	list, err := first.ListScheduled(t.Context())
+	if err != nil || len(list) != 2*perStore {
+		t.Fatalf("ListScheduled() has %d notifications, %v, want %d", len(list), err, 2*perStore)
+	}
+
+	claims := 0
+
+	for _, store := range []*FileScheduleStore{first, second} {
+		if found, _ := store.DeleteScheduled(t.Context(), "0-0"); found {
+			claims++
+		}
+	}
+
+	if claims != 1 {
+		t.Fatalf("claimed %d times, want once", claims)
+	}
+}
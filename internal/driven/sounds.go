@@ -0,0 +1,57 @@
+package driven
+
+import (
+	"context"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+// soundsCacheTTL bounds how long custom sounds added in the Pushover dashboard take to show up.
+const soundsCacheTTL = time.Hour
+
+type soundCache struct {
+	fetchedAt time.Time
+	sounds    []domain.Sound
+	mu        sync.Mutex
+}
+
+type soundsResponse struct {
+	Sounds map[string]string `json:"sounds"`
+}
+
+// Sounds returns the sounds available to the application, including custom account sounds, sorted by name.
+func (c *PushoverClient) Sounds(ctx context.Context) ([]domain.Sound, error) {
+	c.sounds.mu.Lock()
+	defer c.sounds.mu.Unlock()
+
+	if c.sounds.sounds != nil && time.Since(c.sounds.fetchedAt) < soundsCacheTTL {
+		return slices.Clone(c.sounds.sounds), nil
+	}
+
+	query := url.Values{}
+	query.Set("token", c.apiToken)
+
+	var parsed soundsResponse
+	if _, err := c.get(ctx, c.endpoint("sounds"), query, &parsed); err != nil {
+		return nil, err
+	}
+
+	sounds := make([]domain.Sound, 0, len(parsed.Sounds))
+	for name, description := range parsed.Sounds {
+		sounds = append(sounds, domain.Sound{Name: name, Description: description})
+	}
+
+	slices.SortFunc(sounds, func(a, b domain.Sound) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	c.sounds.sounds = sounds
+	c.sounds.fetchedAt = time.Now()
+
+	return slices.Clone(sounds), nil
+}
@@ -0,0 +1,64 @@
+package driven
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSounds_SortedAndCached(t *testing.T) {
+	var calls int
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+
+		if r.URL.Path != "/sounds.json" || r.URL.Query().Get("token") != testAPIToken {
+			t.Fatalf("unexpected request: %s", r.URL)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"status":1,"sounds":{"pushover":"Pushover (default)","bike":"Bike","custom-alarm":"Custom Alarm"}}`))
+	}))
+	defer ts.Close()
+
+	client := newTestClient(t, ts)
+
+	for range 2 {
+		sounds, err := client.Sounds(context.Background())
+		if err != nil {
+			t.Fatalf("Sounds() error = %v", err)
+		}
+
+		if len(sounds) != 3 || sounds[0].Name != "bike" || sounds[1].Name != "custom-alarm" || sounds[2].Description != "Pushover (default)" {
+			t.Fatalf("sounds = %+v", sounds)
+		}
+	}
+
+	if calls != 1 {
+		t.Fatalf("calls = %d, want 1", calls)
+	}
+}
+
+func TestSounds_ErrorNotCached(t *testing.T) {
+	var calls int
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	client := newTestClient(t, ts)
+
+	for range 2 {
+		if _, err := client.Sounds(context.Background()); err == nil {
+			t.Fatal("Sounds() error = nil, want non-nil")
+		}
+	}
+
+	if calls != 2 {
+		t.Fatalf("calls = %d, want 2", calls)
+	}
+}
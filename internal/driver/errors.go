@@ -0,0 +1,35 @@
+package driver
+
+import (
+	"errors"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/adlandh/pushover-mcp/internal/application"
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+// retryHint tells the model whether repeating a failed call makes sense.
+func retryHint(err error) string {
+	switch {
+	case errors.Is(err, domain.ErrQuotaExceeded):
+		return "Do not retry before the monthly quota resets (see get_limits)."
+	case errors.Is(err, domain.ErrDeliveryUnknown):
+		return "The request may already have reached Pushover; check before retrying, since a retry could repeat it."
+	case domain.IsRetryable(err):
+		return "The failure is temporary; retrying later may succeed."
+	default:
+		return "Retrying the same request will not help."
+	}
+}
+
+// toolErrorWithRetryHint reports a failed send; a send the rate limit refused says when it is allowed.
+func toolErrorWithRetryHint(message string, err error) *mcp.CallToolResult {
+	var limitErr *application.RateLimitError
+	if errors.As(err, &limitErr) {
+		return rateLimitedToolResult(*limitErr, time.Now())
+	}
+
+	return mcp.NewToolResultErrorf("%s: %v. %s", message, err, retryHint(err))
+}
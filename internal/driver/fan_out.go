@@ -0,0 +1,81 @@
+package driver
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+type recipientSendResult struct {
+	QuietHours *quietHoursResult `json:"quiet_hours,omitempty"`
+	Recipient  string            `json:"recipient"`
+	Request    string            `json:"request,omitempty"`
+	Receipt    string            `json:"receipt,omitempty"`
+	Error      string            `json:"error,omitempty"`
+	Sent       bool              `json:"sent"`
+	Duplicate  bool              `json:"duplicate,omitempty"`
+}
+
+// fanOut sends to several recipients. The call only fails as a whole when no recipient got the notification
+// and quiet hours did not hold it back.
+func fanOut(
+	ctx context.Context,
+	useCase NotificationExecutor,
+	notification domain.Notification,
+	recipients []string,
+) *mcp.CallToolResult {
+	results, err := useCase.ExecuteFanOut(ctx, notification, recipients)
+	if err != nil {
+		return toolErrorWithRetryHint("Failed to send notification", err)
+	}
+
+	out := sendResult{Recipients: make([]recipientSendResult, 0, len(results))}
+	lines := make([]string, 0, len(results)+1)
+	sent, quieted := 0, 0
+
+	for _, result := range results {
+		item := recipientSendResult{Recipient: result.Recipient}
+		quiet := result.Result.QuietHours
+
+		switch {
+		case result.Err != nil:
+			item.Error = result.Err.Error()
+			lines = append(lines, fmt.Sprintf("%s: failed: %v. %s", result.Recipient, result.Err, retryHint(result.Err)))
+		case quiet != nil && quiet.Action != domain.QuietDowngraded:
+			quieted++
+			item.QuietHours = newQuietHoursResult(quiet)
+			lines = append(lines, fmt.Sprintf("%s: %s", result.Recipient, quietHoursText(*quiet)))
+		default:
+			sent++
+			item.Sent = true
+			item.Request = result.Result.Request
+			item.Receipt = result.Result.Receipt
+			item.Duplicate = result.Result.Duplicate
+			item.QuietHours = newQuietHoursResult(quiet)
+			lines = append(lines, fmt.Sprintf("%s: %s", result.Recipient, sendResultText(result.Result)))
+
+			out.Sanitized = result.Result.Sanitized
+
+			if result.Result.RateLimit != nil {
+				limit := newRateLimit(*result.Result.RateLimit)
+				out.RateLimit = &limit
+			}
+		}
+
+		out.Recipients = append(out.Recipients, item)
+	}
+
+	summary := fmt.Sprintf("Notification sent to %d of %d recipients.", sent, len(results))
+	if quieted > 0 {
+		summary += fmt.Sprintf(" Held back by quiet hours: %d.", quieted)
+	}
+
+	toolResult := mcp.NewToolResultStructured(out, strings.Join(append([]string{summary}, lines...), "\n"))
+	toolResult.IsError = sent == 0 && quieted == 0
+
+	return toolResult
+}
@@ -0,0 +1,211 @@
+package driver
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/adlandh/pushover-mcp/internal/application"
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+// perUserSender rejects any request addressed to one of the bad keys.
+type perUserSender struct {
+	bad []string
+}
+
+func (f *perUserSender) Send(_ context.Context, notification domain.Notification) (domain.SendResult, error) {
+	for _, key := range f.bad {
+		if strings.Contains(notification.User, key) {
+			return domain.SendResult{}, &domain.APIError{
+				Kind:   domain.ErrInvalidRecipient,
+				Status: "400 Bad Request",
+				Errors: []string{"user identifier is invalid"},
+			}
+		}
+	}
+
+	return domain.SendResult{Request: "req-" + notification.User}, nil
+}
+
+func TestSendToolHandler_Recipients(t *testing.T) {
+	tool := setupServerWithTool(t, &perUserSender{})
+
+	result := callToolHandler(t, tool, newCallToolRequest(map[string]any{
+		"message":    testMessage,
+		"recipients": []any{"oncall-key", "lead-key"},
+	}))
+
+	assertResultText(t, result, strings.Join([]string{
+		"Notification sent to 2 of 2 recipients.",
+		"oncall-key: Notification sent. Request: req-oncall-key,lead-key.",
+		"lead-key: Notification sent. Request: req-oncall-key,lead-key.",
+	}, "\n"))
+
+	structured, ok := result.StructuredContent.(sendResult)
+	if !ok {
+		t.Fatalf("structured content = %T, want sendResult", result.StructuredContent)
+	}
+
+	if len(structured.Recipients) != 2 || !structured.Recipients[0].Sent || !structured.Recipients[1].Sent {
+		t.Fatalf("recipients = %+v", structured.Recipients)
+	}
+}
+
+func TestSendToolHandler_RecipientsPartialFailure(t *testing.T) {
+	tool := setupServerWithTool(t, &perUserSender{bad: []string{"bad-key"}})
+
+	result := callToolHandler(t, tool, newCallToolRequest(map[string]any{
+		"message":    testMessage,
+		"recipients": []any{"oncall-key", "bad-key"},
+	}))
+
+	if result.IsError {
+		t.Fatal("result IsError = true, want false when one recipient got the notification")
+	}
+
+	structured, ok := result.StructuredContent.(sendResult)
+	if !ok {
+		t.Fatalf("structured content = %T, want sendResult", result.StructuredContent)
+	}
+
+	got := structured.Recipients
+	if len(got) != 2 || !got[0].Sent || got[0].Request != "req-oncall-key" || got[1].Sent {
+		t.Fatalf("recipients = %+v", got)
+	}
+
+	if !strings.Contains(got[1].Error, "user identifier is invalid") {
+		t.Fatalf("error = %q, want the Pushover error", got[1].Error)
+	}
+}
+
+func TestSendToolHandler_RecipientsAllFailed(t *testing.T) {
+	tool := setupServerWithTool(t, &perUserSender{bad: []string{"bad-key"}})
+
+	result := callToolHandler(t, tool, newCallToolRequest(map[string]any{
+		"message":    testMessage,
+		"recipients": []any{"bad-key"},
+	}))
+
+	assertResultContainsText(t, result, "Notification sent to 0 of 1 recipients.")
+	assertResultContainsText(t, result, "Retrying the same request will not help")
+}
+
+func TestSendToolHandler_RecipientsValidation(t *testing.T) {
+	tool := setupServerWithTool(t, &perUserSender{})
+
+	result := callToolHandler(t, tool, newCallToolRequest(map[string]any{
+		"message":    testMessage,
+		"recipients": []any{"a,b"},
+	}))
+
+	assertResultContainsText(t, result, "recipients must not contain commas")
+}
+
+func newServerWithAliases(t *testing.T, sender domain.NotificationSender) *server.ServerTool {
+	t.Helper()
+
+	useCase := application.NewSendNotificationUseCase(sender, application.WithRecipientAliases(
+		map[string]domain.RecipientAlias{
+			"me":     {Key: "user-me"},
+			"oncall": {Key: "user-oncall", Sound: "siren"},
+		},
+	))
+	s := NewServer(testServerName, testServerVersion, useCase, WithRecipientAliases([]string{"me", "oncall"}))
+
+	return s.GetTool(toolNameSend)
+}
+
+func TestWithRecipientAliases_RecipientEnum(t *testing.T) {
+	tool := newServerWithAliases(t, &fakeNotificationSender{})
+
+	property, ok := tool.Tool.InputSchema.Properties["recipient"].(map[string]any)
+	if !ok {
+		t.Fatal("recipient property missing")
+	}
+
+	enum, ok := property["enum"].([]string)
+	if !ok || !slices.Equal(enum, []string{"me", "oncall"}) {
+		t.Fatalf("recipient enum = %v, want [me oncall]", property["enum"])
+	}
+}
+
+func TestNewServer_NoAliases_NoRecipientArgument(t *testing.T) {
+	tool := setupServerWithTool(t, &fakeNotificationSender{})
+
+	if _, ok := tool.Tool.InputSchema.Properties["recipient"]; ok {
+		t.Fatal("recipient property registered without aliases")
+	}
+}
+
+func TestSendToolHandler_RecipientAlias(t *testing.T) {
+	sender := &fakeNotificationSender{}
+	tool := newServerWithAliases(t, sender)
+
+	result := callToolHandler(t, tool, newCallToolRequest(map[string]any{
+		"message":   testMessage,
+		"recipient": "oncall",
+	}))
+
+	assertResultText(t, result, NotificationSentMessage)
+
+	if sender.notification.User != "user-oncall" || sender.notification.Sound != "siren" {
+		t.Fatalf("notification = %+v, want alias key and sound", sender.notification)
+	}
+}
+
+func TestSendToolHandler_RecipientAndRecipients(t *testing.T) {
+	tool := newServerWithAliases(t, &fakeNotificationSender{})
+
+	result := callToolHandler(t, tool, newCallToolRequest(map[string]any{
+		"message":    testMessage,
+		"recipient":  "oncall",
+		"recipients": []any{"me"},
+	}))
+
+	assertResultContainsText(t, result, "recipient and recipients are mutually exclusive")
+}
+
+func TestSendToolHandler_RecipientsQuietHours(t *testing.T) {
+	until := time.Date(2026, 5, 2, 7, 0, 0, 0, time.UTC)
+	s := NewServer(testServerName, testServerVersion, &stubExecutor{results: []application.RecipientResult{
+		{Recipient: "pager", Result: domain.SendResult{Request: "req-1"}},
+		{Recipient: "lead", Result: domain.SendResult{QuietHours: &domain.QuietHoursOutcome{
+			Until: until, Action: domain.QuietHeld, ScheduledID: "a1",
+		}}},
+	}})
+
+	result := callToolHandler(t, s.GetTool(toolNameSend), newCallToolRequest(map[string]any{
+		"message":    testMessage,
+		"recipients": []any{"pager", "lead"},
+	}))
+
+	assertResultText(t, result, strings.Join([]string{
+		"Notification sent to 1 of 2 recipients. Held back by quiet hours: 1.",
+		"pager: Notification sent. Request: req-1.",
+		"lead: Quiet hours until 2026-05-02T07:00:00Z: notification held and scheduled as a1; cancel_scheduled stops it.",
+	}, "\n"))
+
+	structured, _ := result.StructuredContent.(sendResult)
+	if lead := structured.Recipients[1]; lead.Sent || lead.QuietHours == nil || lead.QuietHours.ScheduledID != "a1" {
+		t.Fatalf("lead = %+v", lead)
+	}
+
+	// Held for everyone is not a failure.
+	s = NewServer(testServerName, testServerVersion, &stubExecutor{results: []application.RecipientResult{
+		{Recipient: "lead", Result: domain.SendResult{QuietHours: &domain.QuietHoursOutcome{Until: until, Action: domain.QuietSuppressed}}},
+	}})
+
+	result = callToolHandler(t, s.GetTool(toolNameSend), newCallToolRequest(map[string]any{
+		"message":    testMessage,
+		"recipients": []any{"lead"},
+	}))
+
+	if result.IsError {
+		t.Fatal("result IsError = true, want false when quiet hours held the notification back")
+	}
+}
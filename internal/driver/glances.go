@@ -0,0 +1,91 @@
+package driver
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+const GlanceUpdatedMessage = "Glance updated."
+
+type GlanceExecutor interface {
+	Update(ctx context.Context, glance domain.Glance) error
+}
+
+type glanceArguments struct {
+	Title   *string `json:"title,omitempty"`
+	Text    *string `json:"text,omitempty"`
+	Subtext *string `json:"subtext,omitempty"`
+	Count   *int    `json:"count,omitempty"`
+	Percent *int    `json:"percent,omitempty"`
+	Device  *string `json:"device,omitempty"`
+}
+
+// WithGlances registers the update_glance tool.
+func WithGlances(glances GlanceExecutor) Option {
+	return func(cfg *serverConfig) {
+		cfg.tools = append(cfg.tools, server.ServerTool{
+			Tool:    buildUpdateGlanceTool(),
+			Handler: updateGlanceHandler(glances),
+		})
+	}
+}
+
+func updateGlanceHandler(glances GlanceExecutor) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var args glanceArguments
+		if err := request.BindArguments(&args); err != nil {
+			return mcp.NewToolResultErrorf("invalid tool arguments: %v", err), nil
+		}
+
+		glance := domain.Glance{
+			Title:   deref(args.Title),
+			Text:    deref(args.Text),
+			Subtext: deref(args.Subtext),
+			Count:   args.Count,
+			Percent: args.Percent,
+			Device:  deref(args.Device),
+		}
+
+		if err := glances.Update(ctx, glance); err != nil {
+			return mcp.NewToolResultErrorf("Failed to update glance: %v", err), nil
+		}
+
+		return mcp.NewToolResultText(GlanceUpdatedMessage), nil
+	}
+}
+
+func buildUpdateGlanceTool() mcp.Tool {
+	return mcp.NewTool("update_glance",
+		mcp.WithDescription("Updates watch complications and widgets via Pushover Glances without sending an alert. "+
+			"Prefer it over send for progress updates."),
+		mcp.WithString("title",
+			mcp.Description("Short title"),
+			mcp.MaxLength(100),
+		),
+		mcp.WithString("text",
+			mcp.Description("Main line of text"),
+			mcp.MaxLength(100),
+		),
+		mcp.WithString("subtext",
+			mcp.Description("Second line of text"),
+			mcp.MaxLength(100),
+		),
+		mcp.WithNumber("count",
+			mcp.Description("Integer shown on small complications, e.g. open incidents"),
+		),
+		mcp.WithNumber("percent",
+			mcp.Description("Progress from 0 to 100 shown as a gauge"),
+			mcp.Min(0),
+			mcp.Max(100),
+		),
+		mcp.WithString("device",
+			mcp.Description("Target specific device"),
+		),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithSchemaAdditionalProperties(false),
+	)
+}
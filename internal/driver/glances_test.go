@@ -0,0 +1,63 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/adlandh/pushover-mcp/internal/application"
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+const toolNameUpdateGlance = "update_glance"
+
+type fakeGlanceExecutor struct {
+	err    error
+	glance domain.Glance
+}
+
+func (f *fakeGlanceExecutor) Update(_ context.Context, glance domain.Glance) error {
+	f.glance = glance
+
+	return f.err
+}
+
+func callUpdateGlance(t *testing.T, glances GlanceExecutor, args map[string]any) *mcp.CallToolResult {
+	t.Helper()
+
+	s := NewServer(testServerName, testServerVersion,
+		application.NewSendNotificationUseCase(&fakeNotificationSender{}),
+		WithGlances(glances),
+	)
+
+	tool := s.GetTool(toolNameUpdateGlance)
+	if tool == nil {
+		t.Fatal("update_glance tool was not registered")
+	}
+
+	return callToolHandler(t, tool, mcp.CallToolRequest{Params: mcp.CallToolParams{Name: toolNameUpdateGlance, Arguments: args}})
+}
+
+func TestUpdateGlanceToolHandler(t *testing.T) {
+	glances := &fakeGlanceExecutor{}
+
+	result := callUpdateGlance(t, glances, map[string]any{
+		"title":   "Build",
+		"text":    "73% done",
+		"percent": 73,
+	})
+
+	assertResultText(t, result, GlanceUpdatedMessage)
+
+	if glances.glance.Title != "Build" || glances.glance.Percent == nil || *glances.glance.Percent != 73 || glances.glance.Count != nil {
+		t.Fatalf("glance = %+v", glances.glance)
+	}
+}
+
+func TestUpdateGlanceToolHandler_Error(t *testing.T) {
+	result := callUpdateGlance(t, &fakeGlanceExecutor{err: errors.New("glance is empty")}, map[string]any{})
+
+	assertResultContainsText(t, result, "Failed to update glance")
+}
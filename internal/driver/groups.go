@@ -0,0 +1,200 @@
+package driver
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+type GroupExecutor interface {
+	Get(ctx context.Context) (domain.Group, error)
+	AddUser(ctx context.Context, member domain.GroupMember) error
+	RemoveUser(ctx context.Context, user, device string) error
+	DisableUser(ctx context.Context, user, device string) error
+	EnableUser(ctx context.Context, user, device string) error
+	Rename(ctx context.Context, name string) error
+}
+
+type groupUserArguments struct {
+	Device *string `json:"device,omitempty"`
+	Memo   *string `json:"memo,omitempty"`
+	User   string  `json:"user"`
+}
+
+type renameGroupArguments struct {
+	Name string `json:"name"`
+}
+
+type groupMemberItem struct {
+	User     string `json:"user"`
+	Device   string `json:"device,omitempty"`
+	Memo     string `json:"memo,omitempty"`
+	Disabled bool   `json:"disabled"`
+}
+
+type groupResult struct {
+	Name    string            `json:"name"`
+	Members []groupMemberItem `json:"members"`
+}
+
+func newGroupResult(group domain.Group) groupResult {
+	members := make([]groupMemberItem, 0, len(group.Members))
+	for _, member := range group.Members {
+		members = append(members, groupMemberItem{
+			User:     member.User,
+			Device:   member.Device,
+			Memo:     member.Memo,
+			Disabled: member.Disabled,
+		})
+	}
+
+	return groupResult{Name: group.Name, Members: members}
+}
+
+// WithGroups registers the delivery group tools. They change state, so callers enable them explicitly.
+func WithGroups(groups GroupExecutor) Option {
+	return func(cfg *serverConfig) {
+		cfg.tools = append(cfg.tools,
+			server.ServerTool{Tool: buildGetGroupTool(), Handler: getGroupHandler(groups)},
+			server.ServerTool{Tool: buildAddGroupUserTool(), Handler: addGroupUserHandler(groups)},
+			server.ServerTool{
+				Tool:    buildGroupUserTool("remove_group_user", "Removes a user from the delivery group.", true),
+				Handler: groupUserHandler(groups.RemoveUser, "Removed %s from the group."),
+			},
+			server.ServerTool{
+				Tool:    buildGroupUserTool("disable_group_user", "Temporarily stops group notifications to a user.", false),
+				Handler: groupUserHandler(groups.DisableUser, "Disabled %s in the group."),
+			},
+			server.ServerTool{
+				Tool:    buildGroupUserTool("enable_group_user", "Resumes group notifications to a disabled user.", false),
+				Handler: groupUserHandler(groups.EnableUser, "Enabled %s in the group."),
+			},
+			server.ServerTool{Tool: buildRenameGroupTool(), Handler: renameGroupHandler(groups)},
+		)
+	}
+}
+
+func getGroupHandler(groups GroupExecutor) server.ToolHandlerFunc {
+	return func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		group, err := groups.Get(ctx)
+		if err != nil {
+			return mcp.NewToolResultErrorf("Failed to get group: %v", err), nil
+		}
+
+		return mcp.NewToolResultStructured(
+			newGroupResult(group),
+			fmt.Sprintf("Group %s: %d user(s).", group.Name, len(group.Members)),
+		), nil
+	}
+}
+
+func addGroupUserHandler(groups GroupExecutor) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var args groupUserArguments
+		if err := request.BindArguments(&args); err != nil {
+			return mcp.NewToolResultErrorf("invalid tool arguments: %v", err), nil
+		}
+
+		member := domain.GroupMember{
+			User:   args.User,
+			Device: deref(args.Device),
+			Memo:   deref(args.Memo),
+		}
+
+		if err := groups.AddUser(ctx, member); err != nil {
+			return mcp.NewToolResultErrorf("Failed to update group: %v", err), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Added %s to the group.", args.User)), nil
+	}
+}
+
+func groupUserHandler(change func(ctx context.Context, user, device string) error, done string) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var args groupUserArguments
+		if err := request.BindArguments(&args); err != nil {
+			return mcp.NewToolResultErrorf("invalid tool arguments: %v", err), nil
+		}
+
+		if err := change(ctx, args.User, deref(args.Device)); err != nil {
+			return mcp.NewToolResultErrorf("Failed to update group: %v", err), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf(done, args.User)), nil
+	}
+}
+
+func renameGroupHandler(groups GroupExecutor) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var args renameGroupArguments
+		if err := request.BindArguments(&args); err != nil {
+			return mcp.NewToolResultErrorf("invalid tool arguments: %v", err), nil
+		}
+
+		if err := groups.Rename(ctx, args.Name); err != nil {
+			return mcp.NewToolResultErrorf("Failed to rename group: %v", err), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Renamed the group to %s.", args.Name)), nil
+	}
+}
+
+func buildGetGroupTool() mcp.Tool {
+	return mcp.NewTool("get_group",
+		mcp.WithDescription("Shows the name and members of the configured delivery group."),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithSchemaAdditionalProperties(false),
+		mcp.WithOutputSchema[groupResult](),
+	)
+}
+
+func buildAddGroupUserTool() mcp.Tool {
+	return mcp.NewTool("add_group_user",
+		mcp.WithDescription("Adds a user to the configured delivery group."),
+		mcp.WithString("user",
+			mcp.Required(),
+			mcp.Description("User key to add"),
+		),
+		mcp.WithString("device",
+			mcp.Description("Only deliver to this device of the user"),
+		),
+		mcp.WithString("memo",
+			mcp.Description("Free-text note shown in the group member list"),
+			mcp.MaxLength(200),
+		),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithSchemaAdditionalProperties(false),
+	)
+}
+
+func buildGroupUserTool(name, description string, destructive bool) mcp.Tool {
+	return mcp.NewTool(name,
+		mcp.WithDescription(description),
+		mcp.WithString("user",
+			mcp.Required(),
+			mcp.Description("User key in the group"),
+		),
+		mcp.WithString("device",
+			mcp.Description("Device the user was added with, if any"),
+		),
+		mcp.WithDestructiveHintAnnotation(destructive),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithSchemaAdditionalProperties(false),
+	)
+}
+
+func buildRenameGroupTool() mcp.Tool {
+	return mcp.NewTool("rename_group",
+		mcp.WithDescription("Renames the configured delivery group."),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("New group name"),
+		),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithSchemaAdditionalProperties(false),
+	)
+}
@@ -0,0 +1,163 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/adlandh/pushover-mcp/internal/application"
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+type fakeGroupExecutor struct {
+	err    error
+	last   string
+	member domain.GroupMember
+}
+
+func (f *fakeGroupExecutor) Get(_ context.Context) (domain.Group, error) {
+	return domain.Group{
+		Name:    "On-call",
+		Members: []domain.GroupMember{{User: "user-1", Memo: "primary"}},
+	}, f.err
+}
+
+func (f *fakeGroupExecutor) AddUser(_ context.Context, member domain.GroupMember) error {
+	f.member = member
+
+	return f.err
+}
+
+func (f *fakeGroupExecutor) RemoveUser(_ context.Context, user, device string) error {
+	f.last = "remove:" + user + ":" + device
+
+	return f.err
+}
+
+func (f *fakeGroupExecutor) DisableUser(_ context.Context, user, device string) error {
+	f.last = "disable:" + user + ":" + device
+
+	return f.err
+}
+
+func (f *fakeGroupExecutor) EnableUser(_ context.Context, user, device string) error {
+	f.last = "enable:" + user + ":" + device
+
+	return f.err
+}
+
+func (f *fakeGroupExecutor) Rename(_ context.Context, name string) error {
+	f.last = "rename:" + name
+
+	return f.err
+}
+
+func newServerWithGroups(groups GroupExecutor) *server.MCPServer {
+	return NewServer(testServerName, testServerVersion,
+		application.NewSendNotificationUseCase(&fakeNotificationSender{}),
+		WithGroups(groups),
+	)
+}
+
+func callGroupTool(t *testing.T, s *server.MCPServer, name string, args map[string]any) *mcp.CallToolResult {
+	t.Helper()
+
+	tool := s.GetTool(name)
+	if tool == nil {
+		t.Fatalf("%s tool was not registered", name)
+	}
+
+	return callToolHandler(t, tool, mcp.CallToolRequest{Params: mcp.CallToolParams{Name: name, Arguments: args}})
+}
+
+func TestNewServer_WithoutGroups_NoGroupTools(t *testing.T) {
+	s := NewServer(testServerName, testServerVersion, application.NewSendNotificationUseCase(&fakeNotificationSender{}))
+
+	if s.GetTool("add_group_user") != nil {
+		t.Fatal("group tools registered without WithGroups")
+	}
+}
+
+func TestGetGroupToolHandler(t *testing.T) {
+	result := callGroupTool(t, newServerWithGroups(&fakeGroupExecutor{}), "get_group", nil)
+
+	structured, ok := result.StructuredContent.(groupResult)
+	if !ok || structured.Name != "On-call" || len(structured.Members) != 1 || structured.Members[0].Memo != "primary" {
+		t.Fatalf("structured content = %#v", result.StructuredContent)
+	}
+
+	assertResultText(t, result, "Group On-call: 1 user(s).")
+}
+
+func TestAddGroupUserToolHandler(t *testing.T) {
+	groups := &fakeGroupExecutor{}
+
+	result := callGroupTool(t, newServerWithGroups(groups), "add_group_user", map[string]any{
+		"user":   "user-2",
+		"device": "iphone",
+		"memo":   "backup",
+	})
+
+	assertResultText(t, result, "Added user-2 to the group.")
+
+	if groups.member != (domain.GroupMember{User: "user-2", Device: "iphone", Memo: "backup"}) {
+		t.Fatalf("member = %+v", groups.member)
+	}
+}
+
+func TestGroupUserToolHandlers(t *testing.T) {
+	tests := []struct {
+		tool     string
+		args     map[string]any
+		wantText string
+		wantCall string
+	}{
+		{
+			tool:     "remove_group_user",
+			args:     map[string]any{"user": "user-1", "device": "iphone"},
+			wantText: "Removed user-1 from the group.",
+			wantCall: "remove:user-1:iphone",
+		},
+		{
+			tool:     "disable_group_user",
+			args:     map[string]any{"user": "user-1"},
+			wantText: "Disabled user-1 in the group.",
+			wantCall: "disable:user-1:",
+		},
+		{
+			tool:     "enable_group_user",
+			args:     map[string]any{"user": "user-1"},
+			wantText: "Enabled user-1 in the group.",
+			wantCall: "enable:user-1:",
+		},
+		{
+			tool:     "rename_group",
+			args:     map[string]any{"name": "Weekend"},
+			wantText: "Renamed the group to Weekend.",
+			wantCall: "rename:Weekend",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.tool, func(t *testing.T) {
+			groups := &fakeGroupExecutor{}
+
+			result := callGroupTool(t, newServerWithGroups(groups), tc.tool, tc.args)
+
+			assertResultText(t, result, tc.wantText)
+
+			if groups.last != tc.wantCall {
+				t.Fatalf("call = %q, want %q", groups.last, tc.wantCall)
+			}
+		})
+	}
+}
+
+func TestGroupToolHandler_Error(t *testing.T) {
+	result := callGroupTool(t, newServerWithGroups(&fakeGroupExecutor{err: errors.New("user is required")}), "disable_group_user", map[string]any{"user": "x"})
+
+	assertResultContainsText(t, result, "Failed to update group")
+}
@@ -0,0 +1,109 @@
+package driver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+const limitsResourceURI = "pushover://limits"
+
+// LimitsReader reads the quota of the named application profile; empty is the default one.
+type LimitsReader interface {
+	Get(ctx context.Context, app string) (domain.RateLimit, error)
+}
+
+type limitsArguments struct {
+	App string `json:"app,omitempty"`
+}
+
+type rateLimit struct {
+	Reset      time.Time `json:"reset"`
+	ObservedAt time.Time `json:"observed_at"`
+	Limit      int       `json:"limit"`
+	Remaining  int       `json:"remaining"`
+}
+
+func newRateLimit(limit domain.RateLimit) rateLimit {
+	return rateLimit{
+		Limit:      limit.Limit,
+		Remaining:  limit.Remaining,
+		Reset:      limit.Reset,
+		ObservedAt: limit.ObservedAt,
+	}
+}
+
+func rateLimitText(limit domain.RateLimit) string {
+	return fmt.Sprintf("%d of %d messages left until %s.", limit.Remaining, limit.Limit, limit.Reset.Format(time.RFC3339))
+}
+
+// WithLimits registers the get_limits tool and the pushover://limits resource, which shows the default application.
+func WithLimits(limits LimitsReader) Option {
+	return func(cfg *serverConfig) {
+		cfg.argumentTools = append(cfg.argumentTools, func(cfg serverConfig) []server.ServerTool {
+			return []server.ServerTool{{Tool: buildGetLimitsTool(cfg), Handler: getLimitsHandler(limits)}}
+		})
+
+		cfg.resources = append(cfg.resources, server.ServerResource{
+			Resource: mcp.NewResource(limitsResourceURI, "Pushover limits",
+				mcp.WithResourceDescription("Monthly message quota of the application: limit, remaining and reset time"),
+				mcp.WithMIMEType("application/json"),
+			),
+			Handler: limitsResourceHandler(limits),
+		})
+	}
+}
+
+func getLimitsHandler(limits LimitsReader) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var args limitsArguments
+		if err := request.BindArguments(&args); err != nil {
+			return mcp.NewToolResultErrorf("invalid tool arguments: %v", err), nil
+		}
+
+		limit, err := limits.Get(ctx, args.App)
+		if err != nil {
+			return mcp.NewToolResultErrorf("Failed to get limits: %v", err), nil
+		}
+
+		return mcp.NewToolResultStructured(newRateLimit(limit), rateLimitText(limit)), nil
+	}
+}
+
+func limitsResourceHandler(limits LimitsReader) server.ResourceHandlerFunc {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		limit, err := limits.Get(ctx, "")
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := json.Marshal(newRateLimit(limit))
+		if err != nil {
+			return nil, fmt.Errorf("marshal limits: %w", err)
+		}
+
+		return []mcp.ResourceContents{
+			mcp.TextResourceContents{
+				URI:      request.Params.URI,
+				MIMEType: "application/json",
+				Text:     string(data),
+			},
+		}, nil
+	}
+}
+
+func buildGetLimitsTool(cfg serverConfig) mcp.Tool {
+	return mcp.NewTool("get_limits",
+		mcp.WithDescription("Shows how many messages the application may still send this month and when the quota resets."),
+		appArgument("Pushover application whose quota to show", cfg.apps, cfg.defaultApp),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithSchemaAdditionalProperties(false),
+		mcp.WithOutputSchema[rateLimit](),
+	)
+}
@@ -0,0 +1,108 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/adlandh/pushover-mcp/internal/application"
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+type fakeLimitsReader struct {
+	err   error
+	app   string
+	limit domain.RateLimit
+}
+
+func (f *fakeLimitsReader) Get(_ context.Context, app string) (domain.RateLimit, error) {
+	f.app = app
+
+	return f.limit, f.err
+}
+
+var testLimit = domain.RateLimit{
+	Limit:      10000,
+	Remaining:  7496,
+	Reset:      time.Unix(1393653600, 0).UTC(),
+	ObservedAt: time.Unix(1393000000, 0).UTC(),
+}
+
+func newServerWithLimits(limits LimitsReader) *server.MCPServer {
+	return NewServer(testServerName, testServerVersion,
+		application.NewSendNotificationUseCase(&fakeNotificationSender{}),
+		WithLimits(limits),
+	)
+}
+
+func TestGetLimitsToolHandler(t *testing.T) {
+	tool := newServerWithLimits(&fakeLimitsReader{limit: testLimit}).GetTool("get_limits")
+	if tool == nil {
+		t.Fatal("get_limits tool was not registered")
+	}
+
+	result := callToolHandler(t, tool, mcp.CallToolRequest{Params: mcp.CallToolParams{Name: "get_limits"}})
+
+	assertResultText(t, result, "7496 of 10000 messages left until 2014-03-01T06:00:00Z.")
+
+	structured, ok := result.StructuredContent.(rateLimit)
+	if !ok || structured.Remaining != 7496 || !structured.ObservedAt.Equal(testLimit.ObservedAt) {
+		t.Fatalf("structured content = %#v", result.StructuredContent)
+	}
+}
+
+func TestGetLimitsToolHandler_App(t *testing.T) {
+	limits := &fakeLimitsReader{limit: testLimit}
+	s := NewServer(testServerName, testServerVersion,
+		application.NewSendNotificationUseCase(&fakeNotificationSender{}),
+		WithLimits(limits),
+		WithApps([]string{"alerts", "primary"}, "primary"),
+	)
+
+	tool := s.GetTool("get_limits")
+	if _, ok := tool.Tool.InputSchema.Properties["app"]; !ok {
+		t.Fatal("get_limits has no app argument")
+	}
+
+	callToolHandler(t, tool, mcp.CallToolRequest{Params: mcp.CallToolParams{
+		Name:      "get_limits",
+		Arguments: map[string]any{"app": "alerts"},
+	}})
+
+	if limits.app != "alerts" {
+		t.Fatalf("app = %q, want alerts", limits.app)
+	}
+}
+
+func TestGetLimitsToolHandler_Error(t *testing.T) {
+	tool := newServerWithLimits(&fakeLimitsReader{err: errors.New("offline")}).GetTool("get_limits")
+
+	result := callToolHandler(t, tool, mcp.CallToolRequest{Params: mcp.CallToolParams{Name: "get_limits"}})
+
+	assertResultContainsText(t, result, "Failed to get limits")
+}
+
+func TestLimitsResource(t *testing.T) {
+	resource, ok := newServerWithLimits(&fakeLimitsReader{limit: testLimit}).ListResources()[limitsResourceURI]
+	if !ok {
+		t.Fatal("limits resource was not registered")
+	}
+
+	contents, err := resource.Handler(context.Background(), mcp.ReadResourceRequest{
+		Params: mcp.ReadResourceParams{URI: limitsResourceURI},
+	})
+	if err != nil {
+		t.Fatalf("resource handler error = %v", err)
+	}
+
+	want := `{"reset":"2014-03-01T06:00:00Z","observed_at":"2014-02-21T16:26:40Z","limit":10000,"remaining":7496}`
+
+	text, ok := contents[0].(mcp.TextResourceContents)
+	if !ok || text.Text != want {
+		t.Fatalf("contents = %#v", contents)
+	}
+}
@@ -0,0 +1,101 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/adlandh/pushover-mcp/internal/application"
+)
+
+// rateLimitedTools are the tools that each put notifications on their way. Creating a recurring
+// notification costs one; its runs are limited where they are sent.
+var rateLimitedTools = map[string]bool{
+	"send":                  true,
+	"send_template":         true,
+	"schedule_notification": true,
+	"create_recurring":      true,
+}
+
+type RateLimiter interface {
+	Allow(session string, n int) error
+	Refund(session string, n int)
+}
+
+// rateLimitedResult tells a rejected caller when the same call is allowed.
+type rateLimitedResult struct {
+	RetryAt           time.Time `json:"retry_at"`
+	Scope             string    `json:"scope"`
+	RetryAfterSeconds int       `json:"retry_after_seconds"`
+}
+
+// WithRateLimit limits the notifications sent through the tools, across all clients and per client session.
+func WithRateLimit(limiter RateLimiter) Option {
+	return func(cfg *serverConfig) {
+		cfg.limiter = limiter
+	}
+}
+
+// rateLimited takes a notification per recipient from limiter before the tool runs, and lets the
+// tool take the further parts of a split message. When those are refused, the message is not sent,
+// so its first part is given back.
+func rateLimited(limiter RateLimiter, next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var args struct {
+			Recipients []string `json:"recipients"`
+		}
+
+		// Malformed arguments are left to the tool, which reports them; they cost one notification here.
+		_ = request.BindArguments(&args)
+
+		session := sessionID(ctx)
+		err := limiter.Allow(session, max(1, len(args.Recipients)))
+
+		var limitErr *application.RateLimitError
+
+		switch {
+		case errors.As(err, &limitErr):
+			return rateLimitedToolResult(*limitErr, time.Now()), nil
+		case err != nil:
+			return mcp.NewToolResultErrorf("Rejected by the send rate limit: %v. Send to fewer recipients at once.", err), nil
+		}
+
+		return next(application.WithRateCharge(ctx, func(n int) error {
+			err := limiter.Allow(session, n)
+			if err != nil {
+				limiter.Refund(session, 1)
+			}
+
+			return err
+		}), request)
+	}
+}
+
+func rateLimitedToolResult(limitErr application.RateLimitError, now time.Time) *mcp.CallToolResult {
+	wait := max(0, limitErr.RetryAt.Sub(now).Round(time.Second))
+	out := rateLimitedResult{
+		RetryAt:           limitErr.RetryAt,
+		Scope:             limitErr.Scope,
+		RetryAfterSeconds: int(wait / time.Second),
+	}
+
+	result := mcp.NewToolResultStructured(out, fmt.Sprintf(
+		"Send rate limit (%s) exceeded: sending is allowed again at %s (in %s). Do not retry before then.",
+		limitErr.Scope, limitErr.RetryAt.Format(time.RFC3339), wait))
+	result.IsError = true
+
+	return result
+}
+
+// sessionID identifies the calling client; calls outside a session share one bucket.
+func sessionID(ctx context.Context) string {
+	if session := server.ClientSessionFromContext(ctx); session != nil {
+		return session.SessionID()
+	}
+
+	return ""
+}
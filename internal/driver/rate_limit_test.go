@@ -0,0 +1,198 @@
+package driver
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adlandh/pushover-mcp/internal/application"
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+// fakeRateLimiter allows the first allow calls and fails the rest with err.
+type fakeRateLimiter struct {
+	err      error
+	sessions []string
+	counts   []int
+	refunds  []int
+	allow    int
+}
+
+func (f *fakeRateLimiter) Allow(session string, n int) error {
+	f.sessions = append(f.sessions, session)
+	f.counts = append(f.counts, n)
+
+	if len(f.counts) <= f.allow {
+		return nil
+	}
+
+	return f.err
+}
+
+func (f *fakeRateLimiter) Refund(_ string, n int) {
+	f.refunds = append(f.refunds, n)
+}
+
+func TestRateLimit_AllowsSend(t *testing.T) {
+	sender := &fakeNotificationSender{}
+	limiter := &fakeRateLimiter{}
+	useCase := application.NewSendNotificationUseCase(sender)
+	s := NewServer(testServerName, testServerVersion, useCase, WithRateLimit(limiter))
+
+	result := callToolHandler(t, s.GetTool(toolNameSend), newCallToolRequest(map[string]any{
+		"message":    testMessage,
+		"recipients": []any{"oncall-key", "lead-key"},
+	}))
+
+	if result.IsError || !sender.called {
+		t.Fatalf("result = %+v, want the notification sent", result)
+	}
+
+	if len(limiter.counts) != 1 || limiter.counts[0] != 2 || limiter.sessions[0] != "" {
+		t.Fatalf("limiter asked for %v in sessions %q, want 2 notifications once", limiter.counts, limiter.sessions)
+	}
+}
+
+func TestRateLimit_ChargesSplitParts(t *testing.T) {
+	sender := &fakeNotificationSender{}
+	limiter := &fakeRateLimiter{}
+	useCase := application.NewSendNotificationUseCase(sender, application.WithLengthPolicy(application.LengthSplit))
+	s := NewServer(testServerName, testServerVersion, useCase, WithRateLimit(limiter))
+
+	result := callToolHandler(t, s.GetTool(toolNameSend), newCallToolRequest(map[string]any{
+		"message": strings.Repeat("x", 2*application.MaxMessageLength),
+	}))
+
+	if result.IsError {
+		t.Fatalf("result = %+v, want the message sent", result)
+	}
+
+	if len(limiter.counts) != 2 || limiter.counts[0] != 1 || limiter.counts[1] != 2 {
+		t.Fatalf("limiter asked for %v, want 1 for the call and 2 for the further parts", limiter.counts)
+	}
+}
+
+func TestRateLimit_RejectsSplitParts(t *testing.T) {
+	retryAt := time.Now().Add(time.Minute).Truncate(time.Second)
+	message := strings.Repeat("x", 2*application.MaxMessageLength)
+
+	tests := []struct {
+		args map[string]any
+		name string
+	}{
+		{name: toolNameSend, args: map[string]any{"message": message}},
+		{name: "send_template", args: map[string]any{"template": "report"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			sender := &fakeNotificationSender{}
+			limiter := &fakeRateLimiter{
+				allow: 1,
+				err:   &application.RateLimitError{RetryAt: retryAt, Scope: application.RateScopeGlobal},
+			}
+			send := application.NewSendNotificationUseCase(sender, application.WithLengthPolicy(application.LengthSplit))
+
+			templates, err := application.NewTemplateUseCase(send, []domain.MessageTemplate{{Name: "report", Message: message}})
+			if err != nil {
+				t.Fatalf("NewTemplateUseCase() error = %v", err)
+			}
+
+			s := NewServer(testServerName, testServerVersion, send, WithRateLimit(limiter), WithTemplates(templates))
+
+			result := callToolHandler(t, s.GetTool(tc.name), newCallToolRequest(tc.args))
+
+			if !result.IsError || sender.called {
+				t.Fatalf("result = %+v, sent = %v; want a rejection", result, sender.called)
+			}
+
+			structured, ok := result.StructuredContent.(rateLimitedResult)
+			if !ok || !structured.RetryAt.Equal(retryAt) {
+				t.Fatalf("structured = %#v, want retry_at %v", result.StructuredContent, retryAt)
+			}
+
+			if len(limiter.refunds) != 1 || limiter.refunds[0] != 1 {
+				t.Fatalf("refunds = %v, want the call's notification given back", limiter.refunds)
+			}
+		})
+	}
+}
+
+func TestRateLimit_RejectsSend(t *testing.T) {
+	sender := &fakeNotificationSender{}
+	retryAt := time.Now().Add(90 * time.Second).Truncate(time.Second)
+	limiter := &fakeRateLimiter{err: &application.RateLimitError{RetryAt: retryAt, Scope: application.RateScopeSession}}
+	s := NewServer(testServerName, testServerVersion, application.NewSendNotificationUseCase(sender), WithRateLimit(limiter))
+
+	result := callToolHandler(t, s.GetTool(toolNameSend), newCallToolRequest(map[string]any{"message": testMessage}))
+
+	if !result.IsError || sender.called {
+		t.Fatalf("result = %+v, sent = %v; want a rejection", result, sender.called)
+	}
+
+	assertResultContainsText(t, result, "Send rate limit (session) exceeded: sending is allowed again at "+retryAt.Format(time.RFC3339))
+
+	structured, ok := result.StructuredContent.(rateLimitedResult)
+	if !ok || !structured.RetryAt.Equal(retryAt) || structured.Scope != application.RateScopeSession {
+		t.Fatalf("structured = %#v", result.StructuredContent)
+	}
+
+	if structured.RetryAfterSeconds < 88 || structured.RetryAfterSeconds > 90 {
+		t.Fatalf("retry_after_seconds = %d, want about 90", structured.RetryAfterSeconds)
+	}
+}
+
+func TestRateLimit_OverBurst(t *testing.T) {
+	limiter := &fakeRateLimiter{err: application.ErrRateLimitBurst}
+	s := NewServer(testServerName, testServerVersion, application.NewSendNotificationUseCase(&fakeNotificationSender{}),
+		WithRateLimit(limiter))
+
+	result := callToolHandler(t, s.GetTool(toolNameSend), newCallToolRequest(map[string]any{"message": testMessage}))
+
+	assertResultContainsText(t, result, "Send to fewer recipients at once")
+}
+
+func TestRateLimit_OnlySendingTools(t *testing.T) {
+	sender := &fakeNotificationSender{}
+	limiter := &fakeRateLimiter{err: application.ErrRateLimitBurst}
+
+	templates, err := application.NewTemplateUseCase(application.NewSendNotificationUseCase(sender),
+		[]domain.MessageTemplate{{Name: "deploy", Message: "Deploy finished"}})
+	if err != nil {
+		t.Fatalf("NewTemplateUseCase() error = %v", err)
+	}
+
+	s := NewServer(testServerName, testServerVersion, application.NewSendNotificationUseCase(sender),
+		WithRateLimit(limiter), WithSounds(&fakeSoundLister{}, nil), WithTemplates(templates))
+
+	callToolHandler(t, s.GetTool("list_sounds"), newCallToolRequest(nil))
+
+	if len(limiter.counts) != 0 {
+		t.Fatal("list_sounds was rate limited")
+	}
+
+	result := callToolHandler(t, s.GetTool("send_template"), newCallToolRequest(map[string]any{"template": "deploy"}))
+	assertResultContainsText(t, result, "Rejected by the send rate limit")
+
+	if sender.called {
+		t.Fatal("send_template sent despite the rate limit")
+	}
+}
+
+func TestRateLimit_CreateRecurring(t *testing.T) {
+	recurring := &fakeRecurring{}
+	limiter := &fakeRateLimiter{err: &application.RateLimitError{RetryAt: time.Now().Add(time.Minute), Scope: application.RateScopeGlobal}}
+	s := NewServer(testServerName, testServerVersion, application.NewSendNotificationUseCase(&fakeNotificationSender{}),
+		WithRateLimit(limiter), WithRecurring(recurring))
+
+	result := callToolHandler(t, s.GetTool("create_recurring"), newRecurringRequest("create_recurring", map[string]any{
+		"message": testMessage,
+		"cron":    "* * * * *",
+	}))
+
+	assertResultContainsText(t, result, "Send rate limit (global) exceeded")
+
+	if recurring.cron != "" {
+		t.Fatal("create_recurring created a job despite the rate limit")
+	}
+}
@@ -0,0 +1,182 @@
+package driver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+// ReceiptExecutor polls and cancels emergency notifications of the named application profile; empty is the default one.
+type ReceiptExecutor interface {
+	Get(ctx context.Context, app, receipt string) (domain.Receipt, error)
+	Cancel(ctx context.Context, app, receipt string) error
+	CancelByTag(ctx context.Context, app, tag string) (int, error)
+}
+
+type receiptArguments struct {
+	Receipt string `json:"receipt"`
+	App     string `json:"app,omitempty"`
+}
+
+type tagArguments struct {
+	Tag string `json:"tag"`
+	App string `json:"app,omitempty"`
+}
+
+type cancelByTagResult struct {
+	Canceled int `json:"canceled"`
+}
+
+type receiptResult struct {
+	AcknowledgedAt       *time.Time `json:"acknowledged_at,omitempty"`
+	LastDeliveredAt      *time.Time `json:"last_delivered_at,omitempty"`
+	ExpiresAt            *time.Time `json:"expires_at,omitempty"`
+	CalledBackAt         *time.Time `json:"called_back_at,omitempty"`
+	AcknowledgedBy       string     `json:"acknowledged_by,omitempty"`
+	AcknowledgedByDevice string     `json:"acknowledged_by_device,omitempty"`
+	Acknowledged         bool       `json:"acknowledged"`
+	Expired              bool       `json:"expired"`
+	CalledBack           bool       `json:"called_back"`
+}
+
+func newReceiptResult(receipt domain.Receipt) receiptResult {
+	return receiptResult{
+		Acknowledged:         receipt.Acknowledged,
+		AcknowledgedAt:       timePtr(receipt.AcknowledgedAt),
+		AcknowledgedBy:       receipt.AcknowledgedBy,
+		AcknowledgedByDevice: receipt.AcknowledgedByDevice,
+		LastDeliveredAt:      timePtr(receipt.LastDeliveredAt),
+		Expired:              receipt.Expired,
+		ExpiresAt:            timePtr(receipt.ExpiresAt),
+		CalledBack:           receipt.CalledBack,
+		CalledBackAt:         timePtr(receipt.CalledBackAt),
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
+}
+
+func receiptText(receipt domain.Receipt) string {
+	switch {
+	case receipt.Acknowledged:
+		return fmt.Sprintf("Acknowledged by %s at %s.", receipt.AcknowledgedBy, receipt.AcknowledgedAt.Format(time.RFC3339))
+	case receipt.Expired:
+		return "Expired without acknowledgement."
+	default:
+		return "Not acknowledged yet."
+	}
+}
+
+// WithReceipts registers the get_receipt, cancel_receipt and cancel_by_tag tools.
+func WithReceipts(receipts ReceiptExecutor) Option {
+	return func(cfg *serverConfig) {
+		cfg.argumentTools = append(cfg.argumentTools, func(cfg serverConfig) []server.ServerTool {
+			return []server.ServerTool{
+				{Tool: buildGetReceiptTool(cfg), Handler: getReceiptHandler(receipts)},
+				{Tool: buildCancelReceiptTool(cfg), Handler: cancelReceiptHandler(receipts)},
+				{Tool: buildCancelByTagTool(cfg), Handler: cancelByTagHandler(receipts)},
+			}
+		})
+	}
+}
+
+func getReceiptHandler(receipts ReceiptExecutor) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var args receiptArguments
+		if err := request.BindArguments(&args); err != nil {
+			return mcp.NewToolResultErrorf("invalid tool arguments: %v", err), nil
+		}
+
+		receipt, err := receipts.Get(ctx, args.App, args.Receipt)
+		if err != nil {
+			return mcp.NewToolResultErrorf("Failed to get receipt: %v", err), nil
+		}
+
+		return mcp.NewToolResultStructured(newReceiptResult(receipt), receiptText(receipt)), nil
+	}
+}
+
+func cancelReceiptHandler(receipts ReceiptExecutor) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var args receiptArguments
+		if err := request.BindArguments(&args); err != nil {
+			return mcp.NewToolResultErrorf("invalid tool arguments: %v", err), nil
+		}
+
+		if err := receipts.Cancel(ctx, args.App, args.Receipt); err != nil {
+			return mcp.NewToolResultErrorf("Failed to cancel receipt: %v", err), nil
+		}
+
+		return mcp.NewToolResultText("Emergency notification canceled."), nil
+	}
+}
+
+func cancelByTagHandler(receipts ReceiptExecutor) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var args tagArguments
+		if err := request.BindArguments(&args); err != nil {
+			return mcp.NewToolResultErrorf("invalid tool arguments: %v", err), nil
+		}
+
+		canceled, err := receipts.CancelByTag(ctx, args.App, args.Tag)
+		if err != nil {
+			return mcp.NewToolResultErrorf("Failed to cancel by tag: %v", err), nil
+		}
+
+		return mcp.NewToolResultStructured(
+			cancelByTagResult{Canceled: canceled},
+			fmt.Sprintf("Canceled %d emergency notification(s).", canceled),
+		), nil
+	}
+}
+
+func buildGetReceiptTool(cfg serverConfig) mcp.Tool {
+	return mcp.NewTool("get_receipt",
+		mcp.WithDescription("Reports whether an emergency-priority notification was acknowledged."),
+		mcp.WithString("receipt",
+			mcp.Required(),
+			mcp.Description("Receipt returned by send for priority 2"),
+		),
+		appArgument("Pushover application the notification was sent as", cfg.apps, cfg.defaultApp),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithSchemaAdditionalProperties(false),
+		mcp.WithOutputSchema[receiptResult](),
+	)
+}
+
+func buildCancelReceiptTool(cfg serverConfig) mcp.Tool {
+	return mcp.NewTool("cancel_receipt",
+		mcp.WithDescription("Stops retries of an emergency-priority notification by its receipt."),
+		mcp.WithString("receipt",
+			mcp.Required(),
+			mcp.Description("Receipt returned by send for priority 2"),
+		),
+		appArgument("Pushover application the notification was sent as", cfg.apps, cfg.defaultApp),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithSchemaAdditionalProperties(false),
+	)
+}
+
+func buildCancelByTagTool(cfg serverConfig) mcp.Tool {
+	return mcp.NewTool("cancel_by_tag",
+		mcp.WithDescription("Stops retries of every active emergency-priority notification sent with the given tag."),
+		mcp.WithString("tag",
+			mcp.Required(),
+			mcp.Description("Tag passed to send in tags"),
+		),
+		appArgument("Pushover application the notifications were sent as", cfg.apps, cfg.defaultApp),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithSchemaAdditionalProperties(false),
+		mcp.WithOutputSchema[cancelByTagResult](),
+	)
+}
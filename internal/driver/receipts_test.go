@@ -0,0 +1,168 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/adlandh/pushover-mcp/internal/application"
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+const toolNameGetReceipt = "get_receipt"
+
+type fakeReceiptExecutor struct {
+	err      error
+	receipt  domain.Receipt
+	got      string
+	app      string
+	canceled int
+}
+
+func (f *fakeReceiptExecutor) Get(_ context.Context, app, receipt string) (domain.Receipt, error) {
+	f.app = app
+	f.got = receipt
+
+	return f.receipt, f.err
+}
+
+func (f *fakeReceiptExecutor) Cancel(_ context.Context, app, receipt string) error {
+	f.app = app
+	f.got = receipt
+
+	return f.err
+}
+
+func (f *fakeReceiptExecutor) CancelByTag(_ context.Context, app, tag string) (int, error) {
+	f.app = app
+	f.got = tag
+
+	return f.canceled, f.err
+}
+
+func callReceiptTool(t *testing.T, receipts ReceiptExecutor, name string, args map[string]any) *mcp.CallToolResult {
+	t.Helper()
+
+	s := NewServer(testServerName, testServerVersion, application.NewSendNotificationUseCase(&fakeNotificationSender{}), WithReceipts(receipts))
+
+	tool := s.GetTool(name)
+	if tool == nil {
+		t.Fatalf("%s tool was not registered", name)
+	}
+
+	return callToolHandler(t, tool, mcp.CallToolRequest{Params: mcp.CallToolParams{Name: name, Arguments: args}})
+}
+
+func TestNewServer_WithoutReceipts_NoReceiptTool(t *testing.T) {
+	s := NewServer(testServerName, testServerVersion, application.NewSendNotificationUseCase(&fakeNotificationSender{}))
+
+	if s.GetTool(toolNameGetReceipt) != nil {
+		t.Fatal("get_receipt tool registered without WithReceipts")
+	}
+}
+
+func TestGetReceiptToolHandler_Success(t *testing.T) {
+	ackAt := time.Unix(1360019238, 0).UTC()
+	receipts := &fakeReceiptExecutor{receipt: domain.Receipt{
+		Acknowledged:   true,
+		AcknowledgedAt: ackAt,
+		AcknowledgedBy: "user-1",
+	}}
+	s := NewServer(testServerName, testServerVersion, application.NewSendNotificationUseCase(&fakeNotificationSender{}), WithReceipts(receipts))
+
+	tool := s.GetTool(toolNameGetReceipt)
+	if tool == nil {
+		t.Fatal("get_receipt tool was not registered")
+	}
+
+	result := callToolHandler(t, tool, mcp.CallToolRequest{Params: mcp.CallToolParams{
+		Name:      toolNameGetReceipt,
+		Arguments: map[string]any{"receipt": "rcpt-1"},
+	}})
+
+	assertResultText(t, result, "Acknowledged by user-1 at 2013-02-04T23:07:18Z.")
+
+	if receipts.got != "rcpt-1" {
+		t.Fatalf("receipt = %q, want rcpt-1", receipts.got)
+	}
+
+	structured, ok := result.StructuredContent.(receiptResult)
+	if !ok {
+		t.Fatalf("structured content = %T, want receiptResult", result.StructuredContent)
+	}
+	if !structured.Acknowledged || structured.AcknowledgedAt == nil || structured.LastDeliveredAt != nil {
+		t.Fatalf("structured = %+v", structured)
+	}
+}
+
+func TestGetReceiptToolHandler_Error(t *testing.T) {
+	receipts := &fakeReceiptExecutor{err: errors.New("receipt not found")}
+	s := NewServer(testServerName, testServerVersion, application.NewSendNotificationUseCase(&fakeNotificationSender{}), WithReceipts(receipts))
+
+	result := callToolHandler(t, s.GetTool(toolNameGetReceipt), mcp.CallToolRequest{Params: mcp.CallToolParams{
+		Name:      toolNameGetReceipt,
+		Arguments: map[string]any{"receipt": "rcpt-1"},
+	}})
+
+	assertResultContainsText(t, result, "Failed to get receipt")
+}
+
+func TestCancelReceiptToolHandler(t *testing.T) {
+	receipts := &fakeReceiptExecutor{}
+
+	result := callReceiptTool(t, receipts, "cancel_receipt", map[string]any{"receipt": "rcpt-1"})
+
+	assertResultText(t, result, "Emergency notification canceled.")
+
+	if receipts.got != "rcpt-1" {
+		t.Fatalf("receipt = %q, want rcpt-1", receipts.got)
+	}
+}
+
+func TestCancelByTagToolHandler(t *testing.T) {
+	receipts := &fakeReceiptExecutor{canceled: 2}
+
+	result := callReceiptTool(t, receipts, "cancel_by_tag", map[string]any{"tag": "deploy-42"})
+
+	assertResultText(t, result, "Canceled 2 emergency notification(s).")
+
+	structured, ok := result.StructuredContent.(cancelByTagResult)
+	if !ok || structured.Canceled != 2 {
+		t.Fatalf("structured content = %#v, want canceled 2", result.StructuredContent)
+	}
+}
+
+func TestCancelReceiptToolHandler_App(t *testing.T) {
+	receipts := &fakeReceiptExecutor{}
+	s := NewServer(testServerName, testServerVersion,
+		application.NewSendNotificationUseCase(&fakeNotificationSender{}),
+		WithReceipts(receipts),
+		WithApps([]string{"alerts", "primary"}, "primary"),
+	)
+
+	for _, name := range []string{toolNameGetReceipt, "cancel_receipt", "cancel_by_tag"} {
+		if _, ok := s.GetTool(name).Tool.InputSchema.Properties["app"]; !ok {
+			t.Fatalf("%s has no app argument", name)
+		}
+	}
+
+	result := callToolHandler(t, s.GetTool("cancel_receipt"), mcp.CallToolRequest{Params: mcp.CallToolParams{
+		Name:      "cancel_receipt",
+		Arguments: map[string]any{"receipt": "rcpt-1", "app": "alerts"},
+	}})
+
+	assertResultText(t, result, "Emergency notification canceled.")
+
+	if receipts.app != "alerts" {
+		t.Fatalf("app = %q, want alerts", receipts.app)
+	}
+}
+
+func TestCancelByTagToolHandler_Error(t *testing.T) {
+	result := callReceiptTool(t, &fakeReceiptExecutor{err: errors.New("invalid tag")}, "cancel_by_tag", map[string]any{"tag": "x"})
+
+	assertResultContainsText(t, result, "Failed to cancel by tag")
+}
@@ -0,0 +1,88 @@
+package driver
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+type RecipientValidator interface {
+	Validate(ctx context.Context, user, device string) (domain.Recipient, error)
+}
+
+type validateRecipientArguments struct {
+	User   *string `json:"user,omitempty"`
+	Device *string `json:"device,omitempty"`
+}
+
+type recipientResult struct {
+	Devices  []string `json:"devices"`
+	Licenses []string `json:"licenses,omitempty"`
+	Group    bool     `json:"group"`
+}
+
+func newRecipientResult(recipient domain.Recipient) recipientResult {
+	devices := recipient.Devices
+	if devices == nil {
+		devices = []string{}
+	}
+
+	return recipientResult{
+		Devices:  devices,
+		Licenses: recipient.Licenses,
+		Group:    recipient.Group,
+	}
+}
+
+// WithRecipientValidation registers the validate_recipient tool.
+func WithRecipientValidation(validator RecipientValidator) Option {
+	return func(cfg *serverConfig) {
+		cfg.tools = append(cfg.tools, server.ServerTool{
+			Tool:    buildValidateRecipientTool(),
+			Handler: validateRecipientHandler(validator),
+		})
+	}
+}
+
+func validateRecipientHandler(validator RecipientValidator) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var args validateRecipientArguments
+		if err := request.BindArguments(&args); err != nil {
+			return mcp.NewToolResultErrorf("invalid tool arguments: %v", err), nil
+		}
+
+		recipient, err := validator.Validate(ctx, deref(args.User), deref(args.Device))
+		if err != nil {
+			return mcp.NewToolResultErrorf("Recipient is not valid: %v", err), nil
+		}
+
+		kind := "user"
+		if recipient.Group {
+			kind = "group"
+		}
+
+		return mcp.NewToolResultStructured(
+			newRecipientResult(recipient),
+			fmt.Sprintf("Valid %s with %d active device(s).", kind, len(recipient.Devices)),
+		), nil
+	}
+}
+
+func buildValidateRecipientTool() mcp.Tool {
+	return mcp.NewTool("validate_recipient",
+		mcp.WithDescription("Checks that a Pushover user or group key is valid and lists its active devices."),
+		mcp.WithString("user",
+			mcp.Description("User or group key to check (default: the configured user key)"),
+		),
+		mcp.WithString("device",
+			mcp.Description("Device name that must belong to the user"),
+		),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithSchemaAdditionalProperties(false),
+		mcp.WithOutputSchema[recipientResult](),
+	)
+}
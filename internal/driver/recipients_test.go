@@ -0,0 +1,71 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/adlandh/pushover-mcp/internal/application"
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+const toolNameValidateRecipient = "validate_recipient"
+
+type fakeRecipientValidator struct {
+	err       error
+	recipient domain.Recipient
+	user      string
+}
+
+func (f *fakeRecipientValidator) Validate(_ context.Context, user, _ string) (domain.Recipient, error) {
+	f.user = user
+
+	return f.recipient, f.err
+}
+
+func callValidateRecipient(t *testing.T, validator RecipientValidator, args map[string]any) *mcp.CallToolResult {
+	t.Helper()
+
+	s := NewServer(testServerName, testServerVersion,
+		application.NewSendNotificationUseCase(&fakeNotificationSender{}),
+		WithRecipientValidation(validator),
+	)
+
+	tool := s.GetTool(toolNameValidateRecipient)
+	if tool == nil {
+		t.Fatal("validate_recipient tool was not registered")
+	}
+
+	return callToolHandler(t, tool, mcp.CallToolRequest{Params: mcp.CallToolParams{Name: toolNameValidateRecipient, Arguments: args}})
+}
+
+func TestValidateRecipientToolHandler(t *testing.T) {
+	validator := &fakeRecipientValidator{recipient: domain.Recipient{Devices: []string{"iphone", "desktop"}}}
+
+	result := callValidateRecipient(t, validator, map[string]any{"user": "user-1"})
+
+	assertResultText(t, result, "Valid user with 2 active device(s).")
+
+	if validator.user != "user-1" {
+		t.Fatalf("user = %q, want user-1", validator.user)
+	}
+
+	structured, ok := result.StructuredContent.(recipientResult)
+	if !ok || len(structured.Devices) != 2 || structured.Group {
+		t.Fatalf("structured content = %#v", result.StructuredContent)
+	}
+}
+
+func TestValidateRecipientToolHandler_Group(t *testing.T) {
+	result := callValidateRecipient(t, &fakeRecipientValidator{recipient: domain.Recipient{Group: true}}, nil)
+
+	assertResultText(t, result, "Valid group with 0 active device(s).")
+}
+
+func TestValidateRecipientToolHandler_Invalid(t *testing.T) {
+	result := callValidateRecipient(t, &fakeRecipientValidator{err: errors.New("user key is invalid")}, map[string]any{"user": "bad"})
+
+	assertResultContainsText(t, result, "Recipient is not valid")
+}
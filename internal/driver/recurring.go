@@ -0,0 +1,248 @@
+package driver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+const (
+	recurringStatusActive = "active"
+	recurringStatusPaused = "paused"
+)
+
+type RecurringExecutor interface {
+	Create(ctx context.Context, notification domain.Notification, cron, timeZone string) (domain.RecurringNotification, error)
+	List(ctx context.Context) ([]domain.RecurringNotification, error)
+	Pause(ctx context.Context, id string) (domain.RecurringNotification, error)
+	Resume(ctx context.Context, id string) (domain.RecurringNotification, error)
+	Delete(ctx context.Context, id string) error
+}
+
+type createRecurringArguments struct {
+	TimeZone *string `json:"time_zone,omitempty"`
+	Cron     string  `json:"cron"`
+
+	sendArguments
+}
+
+type recurringIDArguments struct {
+	ID string `json:"id"`
+}
+
+type recurringItem struct {
+	NextRun   *time.Time `json:"next_run,omitempty"`
+	LastRun   *time.Time `json:"last_run,omitempty"`
+	ID        string     `json:"id"`
+	Cron      string     `json:"cron"`
+	TimeZone  string     `json:"time_zone"`
+	Title     string     `json:"title,omitempty"`
+	Message   string     `json:"message"`
+	Recipient string     `json:"recipient,omitempty"`
+	App       string     `json:"app,omitempty"`
+	Status    string     `json:"status"`
+	LastError string     `json:"last_error,omitempty"`
+}
+
+func newRecurringItem(recurring domain.RecurringNotification) recurringItem {
+	status := recurringStatusActive
+	if recurring.Paused {
+		status = recurringStatusPaused
+	}
+
+	return recurringItem{
+		ID:        recurring.ID,
+		Cron:      recurring.Cron,
+		TimeZone:  recurring.TimeZone,
+		NextRun:   timePtr(recurring.NextRun),
+		LastRun:   timePtr(recurring.LastRun),
+		Title:     recurring.Notification.Title,
+		Message:   recurring.Notification.Message,
+		Recipient: recurring.Notification.User,
+		App:       recurring.Notification.App,
+		Status:    status,
+		LastError: recurring.LastError,
+	}
+}
+
+type recurringListResult struct {
+	Recurring []recurringItem `json:"recurring"`
+}
+
+func newRecurringListResult(list []domain.RecurringNotification) recurringListResult {
+	items := make([]recurringItem, 0, len(list))
+	for _, recurring := range list {
+		items = append(items, newRecurringItem(recurring))
+	}
+
+	return recurringListResult{Recurring: items}
+}
+
+// nextRunText is the text fallback after a job was created or resumed.
+func nextRunText(action string, recurring domain.RecurringNotification) string {
+	return fmt.Sprintf("Recurring notification %s %s. Next run: %s.", recurring.ID, action, recurring.NextRun.Format(time.RFC3339))
+}
+
+// WithRecurring registers the create_recurring, list_recurring, pause_recurring, resume_recurring
+// and delete_recurring tools.
+func WithRecurring(recurring RecurringExecutor) Option {
+	return func(cfg *serverConfig) {
+		cfg.argumentTools = append(cfg.argumentTools, func(cfg serverConfig) []server.ServerTool {
+			return []server.ServerTool{
+				{Tool: buildCreateRecurringTool(cfg), Handler: createRecurringHandler(recurring, cfg.attachmentDir)},
+				{Tool: buildListRecurringTool(), Handler: listRecurringHandler(recurring)},
+				{Tool: buildPauseRecurringTool(), Handler: pauseRecurringHandler(recurring)},
+				{Tool: buildResumeRecurringTool(), Handler: resumeRecurringHandler(recurring)},
+				{Tool: buildDeleteRecurringTool(), Handler: deleteRecurringHandler(recurring)},
+			}
+		})
+	}
+}
+
+func createRecurringHandler(recurring RecurringExecutor, attachmentDir string) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var args createRecurringArguments
+		if err := request.BindArguments(&args); err != nil {
+			return mcp.NewToolResultErrorf("invalid tool arguments: %v", err), nil
+		}
+
+		notification, err := args.notification(attachmentDir)
+		if err != nil {
+			return mcp.NewToolResultErrorf("invalid attachment: %v", err), nil
+		}
+
+		created, err := recurring.Create(ctx, notification, args.Cron, deref(args.TimeZone))
+		if err != nil {
+			return mcp.NewToolResultErrorf("Failed to create recurring notification: %v", err), nil
+		}
+
+		return mcp.NewToolResultStructured(newRecurringItem(created), nextRunText("created", created)), nil
+	}
+}
+
+func listRecurringHandler(recurring RecurringExecutor) server.ToolHandlerFunc {
+	return func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		list, err := recurring.List(ctx)
+		if err != nil {
+			return mcp.NewToolResultErrorf("Failed to list recurring notifications: %v", err), nil
+		}
+
+		return mcp.NewToolResultStructured(
+			newRecurringListResult(list),
+			fmt.Sprintf("%d recurring notification(s).", len(list)),
+		), nil
+	}
+}
+
+func pauseRecurringHandler(recurring RecurringExecutor) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var args recurringIDArguments
+		if err := request.BindArguments(&args); err != nil {
+			return mcp.NewToolResultErrorf("invalid tool arguments: %v", err), nil
+		}
+
+		paused, err := recurring.Pause(ctx, args.ID)
+		if err != nil {
+			return mcp.NewToolResultErrorf("Failed to pause recurring notification: %v", err), nil
+		}
+
+		return mcp.NewToolResultStructured(newRecurringItem(paused), fmt.Sprintf("Recurring notification %s paused.", paused.ID)), nil
+	}
+}
+
+func resumeRecurringHandler(recurring RecurringExecutor) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var args recurringIDArguments
+		if err := request.BindArguments(&args); err != nil {
+			return mcp.NewToolResultErrorf("invalid tool arguments: %v", err), nil
+		}
+
+		resumed, err := recurring.Resume(ctx, args.ID)
+		if err != nil {
+			return mcp.NewToolResultErrorf("Failed to resume recurring notification: %v", err), nil
+		}
+
+		return mcp.NewToolResultStructured(newRecurringItem(resumed), nextRunText("resumed", resumed)), nil
+	}
+}
+
+func deleteRecurringHandler(recurring RecurringExecutor) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var args recurringIDArguments
+		if err := request.BindArguments(&args); err != nil {
+			return mcp.NewToolResultErrorf("invalid tool arguments: %v", err), nil
+		}
+
+		if err := recurring.Delete(ctx, args.ID); err != nil {
+			return mcp.NewToolResultErrorf("Failed to delete recurring notification: %v", err), nil
+		}
+
+		return mcp.NewToolResultText("Recurring notification deleted."), nil
+	}
+}
+
+func buildCreateRecurringTool(cfg serverConfig) mcp.Tool {
+	opts := []mcp.ToolOption{
+		mcp.WithDescription("Creates a notification sent repeatedly on a cron schedule, e.g. every weekday at 09:30. " +
+			"Takes the same arguments as send plus the schedule; the notification is validated now and sent as is on every run."),
+		mcp.WithString("cron",
+			mcp.Required(),
+			mcp.Description("Five-field cron expression: minute hour day-of-month month day-of-week, e.g. \"30 9 * * 1-5\" "+
+				"for weekdays at 09:30. Supports lists, ranges, steps, names (mon, jan) and @hourly, @daily, @weekly, @monthly, @yearly"),
+		),
+		mcp.WithString("time_zone",
+			mcp.Description("IANA time zone the expression is evaluated in, e.g. Europe/Berlin (default: UTC)"),
+		),
+		mcp.WithSchemaAdditionalProperties(false),
+		mcp.WithOutputSchema[recurringItem](),
+	}
+
+	return mcp.NewTool("create_recurring", append(opts, notificationArguments(cfg)...)...)
+}
+
+func buildListRecurringTool() mcp.Tool {
+	return mcp.NewTool("list_recurring",
+		mcp.WithDescription("Lists recurring notifications with their schedule, next run and the outcome of the last run."),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithSchemaAdditionalProperties(false),
+		mcp.WithOutputSchema[recurringListResult](),
+	)
+}
+
+func recurringIDArgument() mcp.ToolOption {
+	return mcp.WithString("id",
+		mcp.Required(),
+		mcp.Description("ID returned by create_recurring"),
+	)
+}
+
+func buildPauseRecurringTool() mcp.Tool {
+	return mcp.NewTool("pause_recurring",
+		mcp.WithDescription("Pauses a recurring notification until it is resumed."),
+		recurringIDArgument(),
+		mcp.WithSchemaAdditionalProperties(false),
+		mcp.WithOutputSchema[recurringItem](),
+	)
+}
+
+func buildResumeRecurringTool() mcp.Tool {
+	return mcp.NewTool("resume_recurring",
+		mcp.WithDescription("Resumes a paused recurring notification; runs missed while it was paused are not sent."),
+		recurringIDArgument(),
+		mcp.WithSchemaAdditionalProperties(false),
+		mcp.WithOutputSchema[recurringItem](),
+	)
+}
+
+func buildDeleteRecurringTool() mcp.Tool {
+	return mcp.NewTool("delete_recurring",
+		mcp.WithDescription("Deletes a recurring notification."),
+		recurringIDArgument(),
+		mcp.WithSchemaAdditionalProperties(false),
+	)
+}
@@ -0,0 +1,153 @@
+package driver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/adlandh/pushover-mcp/internal/application"
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+var testNextRun = time.Date(2026, 5, 4, 9, 30, 0, 0, time.UTC)
+
+type fakeRecurring struct {
+	err          error
+	list         []domain.RecurringNotification
+	notification domain.Notification
+	cron         string
+	timeZone     string
+	id           string
+}
+
+func (f *fakeRecurring) Create(
+	_ context.Context,
+	notification domain.Notification,
+	cron, timeZone string,
+) (domain.RecurringNotification, error) {
+	f.notification, f.cron, f.timeZone = notification, cron, timeZone
+
+	return domain.RecurringNotification{ID: "r1", Cron: cron, TimeZone: timeZone, NextRun: testNextRun, Notification: notification}, f.err
+}
+
+func (f *fakeRecurring) List(_ context.Context) ([]domain.RecurringNotification, error) {
+	return f.list, f.err
+}
+
+func (f *fakeRecurring) Pause(_ context.Context, id string) (domain.RecurringNotification, error) {
+	f.id = id
+
+	return domain.RecurringNotification{ID: id, Paused: true}, f.err
+}
+
+func (f *fakeRecurring) Resume(_ context.Context, id string) (domain.RecurringNotification, error) {
+	f.id = id
+
+	return domain.RecurringNotification{ID: id, NextRun: testNextRun}, f.err
+}
+
+func (f *fakeRecurring) Delete(_ context.Context, id string) error {
+	f.id = id
+
+	return f.err
+}
+
+func newServerWithRecurring(recurring RecurringExecutor) *server.MCPServer {
+	return NewServer(testServerName, testServerVersion,
+		application.NewSendNotificationUseCase(&fakeNotificationSender{}),
+		WithRecurring(recurring),
+	)
+}
+
+func newRecurringRequest(name string, args map[string]any) mcp.CallToolRequest {
+	return mcp.CallToolRequest{Params: mcp.CallToolParams{Name: name, Arguments: args}}
+}
+
+func TestWithRecurring_CreateTool(t *testing.T) {
+	recurring := &fakeRecurring{}
+	tool := newServerWithRecurring(recurring).GetTool("create_recurring")
+
+	if tool == nil {
+		t.Fatal("create_recurring tool was not registered")
+	}
+
+	if _, ok := tool.Tool.InputSchema.Properties["message"]; !ok {
+		t.Fatal("message property missing")
+	}
+
+	result := callToolHandler(t, tool, newRecurringRequest("create_recurring", map[string]any{
+		"message":   "Summarize overnight CI",
+		"cron":      "30 9 * * 1-5",
+		"time_zone": "Europe/Berlin",
+		"priority":  -1,
+	}))
+
+	assertResultText(t, result, "Recurring notification r1 created. Next run: 2026-05-04T09:30:00Z.")
+
+	if recurring.cron != "30 9 * * 1-5" || recurring.timeZone != "Europe/Berlin" ||
+		recurring.notification.Message != "Summarize overnight CI" || *recurring.notification.Priority != -1 {
+		t.Fatalf("created %+v with %q in %q", recurring.notification, recurring.cron, recurring.timeZone)
+	}
+
+	structured, ok := result.StructuredContent.(recurringItem)
+	if !ok || structured.Status != recurringStatusActive || structured.NextRun == nil {
+		t.Fatalf("structured content = %#v", result.StructuredContent)
+	}
+}
+
+func TestCreateRecurringHandler_Error(t *testing.T) {
+	tool := newServerWithRecurring(&fakeRecurring{err: application.ErrInvalidCron}).GetTool("create_recurring")
+
+	result := callToolHandler(t, tool, newRecurringRequest("create_recurring", map[string]any{
+		"message": testMessage,
+		"cron":    "every day",
+	}))
+
+	assertResultContainsText(t, result, "Failed to create recurring notification: invalid cron expression")
+}
+
+func TestListRecurringHandler(t *testing.T) {
+	recurring := &fakeRecurring{list: []domain.RecurringNotification{
+		{ID: "r1", Cron: "@daily", TimeZone: "UTC", NextRun: testNextRun, Notification: domain.Notification{Message: "daily"}},
+		{ID: "r2", Cron: "@hourly", TimeZone: "UTC", Paused: true, LastError: "bad user", Notification: domain.Notification{Message: "hourly"}},
+	}}
+	tool := newServerWithRecurring(recurring).GetTool("list_recurring")
+
+	result := callToolHandler(t, tool, newRecurringRequest("list_recurring", nil))
+
+	assertResultText(t, result, "2 recurring notification(s).")
+
+	structured, ok := result.StructuredContent.(recurringListResult)
+	if !ok || len(structured.Recurring) != 2 {
+		t.Fatalf("structured content = %#v", result.StructuredContent)
+	}
+
+	if item := structured.Recurring[1]; item.Status != recurringStatusPaused || item.NextRun != nil || item.LastError != "bad user" {
+		t.Fatalf("paused item = %+v", item)
+	}
+}
+
+func TestPauseResumeDeleteRecurringHandlers(t *testing.T) {
+	recurring := &fakeRecurring{}
+	s := newServerWithRecurring(recurring)
+	args := map[string]any{"id": "r1"}
+
+	assertResultText(t, callToolHandler(t, s.GetTool("pause_recurring"), newRecurringRequest("pause_recurring", args)),
+		"Recurring notification r1 paused.")
+	assertResultText(t, callToolHandler(t, s.GetTool("resume_recurring"), newRecurringRequest("resume_recurring", args)),
+		"Recurring notification r1 resumed. Next run: 2026-05-04T09:30:00Z.")
+	assertResultText(t, callToolHandler(t, s.GetTool("delete_recurring"), newRecurringRequest("delete_recurring", args)),
+		"Recurring notification deleted.")
+
+	if recurring.id != "r1" {
+		t.Fatalf("id = %q, want r1", recurring.id)
+	}
+
+	recurring.err = application.ErrRecurringNotFound
+
+	assertResultContainsText(t, callToolHandler(t, s.GetTool("delete_recurring"), newRecurringRequest("delete_recurring", args)),
+		"recurring notification not found")
+}
@@ -0,0 +1,219 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/adlandh/pushover-mcp/internal/application"
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+const (
+	scheduledStatusPending = "pending"
+	scheduledStatusFailed  = "failed"
+)
+
+type ScheduleExecutor interface {
+	Schedule(ctx context.Context, notification domain.Notification, sendAt time.Time) (domain.ScheduledNotification, error)
+	List(ctx context.Context) ([]domain.ScheduledNotification, error)
+	Cancel(ctx context.Context, id string) error
+}
+
+type scheduleArguments struct {
+	SendAt *string `json:"send_at,omitempty"`
+	Delay  *string `json:"delay,omitempty"`
+
+	sendArguments
+}
+
+type scheduledIDArguments struct {
+	ID string `json:"id"`
+}
+
+type scheduleResult struct {
+	SendAt time.Time `json:"send_at"`
+	ID     string    `json:"id"`
+}
+
+type scheduledItem struct {
+	SendAt    time.Time  `json:"send_at"`
+	RetryAt   *time.Time `json:"retry_at,omitempty"`
+	ID        string     `json:"id"`
+	Title     string     `json:"title,omitempty"`
+	Message   string     `json:"message"`
+	Recipient string     `json:"recipient,omitempty"`
+	App       string     `json:"app,omitempty"`
+	Status    string     `json:"status"`
+	LastError string     `json:"last_error,omitempty"`
+	Attempts  int        `json:"attempts,omitempty"`
+}
+
+type scheduledListResult struct {
+	Scheduled []scheduledItem `json:"scheduled"`
+}
+
+func newScheduledListResult(list []domain.ScheduledNotification) scheduledListResult {
+	items := make([]scheduledItem, 0, len(list))
+	for _, scheduled := range list {
+		status := scheduledStatusPending
+		if scheduled.Failed {
+			status = scheduledStatusFailed
+		}
+
+		items = append(items, scheduledItem{
+			ID:        scheduled.ID,
+			SendAt:    scheduled.SendAt,
+			RetryAt:   timePtr(scheduled.RetryAt),
+			Title:     scheduled.Notification.Title,
+			Message:   scheduled.Notification.Message,
+			Recipient: scheduled.Notification.User,
+			App:       scheduled.Notification.App,
+			Status:    status,
+			LastError: scheduled.LastError,
+			Attempts:  scheduled.Attempts,
+		})
+	}
+
+	return scheduledListResult{Scheduled: items}
+}
+
+// WithScheduler registers the schedule_notification, list_scheduled and cancel_scheduled tools.
+func WithScheduler(scheduler ScheduleExecutor) Option {
+	return func(cfg *serverConfig) {
+		cfg.argumentTools = append(cfg.argumentTools, func(cfg serverConfig) []server.ServerTool {
+			return []server.ServerTool{
+				{Tool: buildScheduleTool(cfg), Handler: scheduleHandler(scheduler, time.Now, cfg.attachmentDir)},
+				{Tool: buildListScheduledTool(), Handler: listScheduledHandler(scheduler)},
+				{Tool: buildCancelScheduledTool(), Handler: cancelScheduledHandler(scheduler)},
+			}
+		})
+	}
+}
+
+func scheduleHandler(scheduler ScheduleExecutor, now func() time.Time, attachmentDir string) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var args scheduleArguments
+		if err := request.BindArguments(&args); err != nil {
+			return mcp.NewToolResultErrorf("invalid tool arguments: %v", err), nil
+		}
+
+		sendAt, err := args.sendAt(now())
+		if err != nil {
+			return mcp.NewToolResultErrorf("invalid tool arguments: %v", err), nil
+		}
+
+		notification, err := args.notification(attachmentDir)
+		if err != nil {
+			return mcp.NewToolResultErrorf("invalid attachment: %v", err), nil
+		}
+
+		scheduled, err := scheduler.Schedule(ctx, notification, sendAt)
+
+		var limitErr *application.RateLimitError
+
+		switch {
+		case errors.As(err, &limitErr):
+			return rateLimitedToolResult(*limitErr, time.Now()), nil
+		case err != nil:
+			return mcp.NewToolResultErrorf("Failed to schedule notification: %v", err), nil
+		}
+
+		return mcp.NewToolResultStructured(
+			scheduleResult{ID: scheduled.ID, SendAt: scheduled.SendAt},
+			fmt.Sprintf("Notification scheduled for %s. ID: %s.", scheduled.SendAt.Format(time.RFC3339), scheduled.ID),
+		), nil
+	}
+}
+
+// sendAt resolves send_at or delay, exactly one of which must be given.
+func (args scheduleArguments) sendAt(now time.Time) (time.Time, error) {
+	switch {
+	case (args.SendAt == nil) == (args.Delay == nil):
+		return time.Time{}, errors.New("exactly one of send_at and delay is required")
+	case args.SendAt != nil:
+		sendAt, err := time.Parse(time.RFC3339, *args.SendAt)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("send_at must be an RFC 3339 time with an offset, e.g. 2026-05-01T17:00:00+02:00: %w", err)
+		}
+
+		return sendAt, nil
+	default:
+		delay, err := time.ParseDuration(*args.Delay)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("delay must be a duration such as 90s, 45m or 2h30m: %w", err)
+		}
+
+		return now.Add(delay), nil
+	}
+}
+
+func listScheduledHandler(scheduler ScheduleExecutor) server.ToolHandlerFunc {
+	return func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		list, err := scheduler.List(ctx)
+		if err != nil {
+			return mcp.NewToolResultErrorf("Failed to list scheduled notifications: %v", err), nil
+		}
+
+		return mcp.NewToolResultStructured(
+			newScheduledListResult(list),
+			fmt.Sprintf("%d scheduled notification(s).", len(list)),
+		), nil
+	}
+}
+
+func cancelScheduledHandler(scheduler ScheduleExecutor) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var args scheduledIDArguments
+		if err := request.BindArguments(&args); err != nil {
+			return mcp.NewToolResultErrorf("invalid tool arguments: %v", err), nil
+		}
+
+		if err := scheduler.Cancel(ctx, args.ID); err != nil {
+			return mcp.NewToolResultErrorf("Failed to cancel scheduled notification: %v", err), nil
+		}
+
+		return mcp.NewToolResultText("Scheduled notification canceled."), nil
+	}
+}
+
+func buildScheduleTool(cfg serverConfig) mcp.Tool {
+	opts := []mcp.ToolOption{
+		mcp.WithDescription("Schedules a notification for later delivery, e.g. a reminder at 17:00 or in 2 hours. " +
+			"Scheduled notifications survive server restarts; ones that came due while the server was stopped are sent when it starts."),
+		mcp.WithString("send_at",
+			mcp.Description("When to send, as an RFC 3339 time with an offset, e.g. 2026-05-01T17:00:00+02:00; not with delay"),
+		),
+		mcp.WithString("delay",
+			mcp.Description("How long from now to send, e.g. 45m or 2h30m; not with send_at"),
+		),
+		mcp.WithSchemaAdditionalProperties(false),
+		mcp.WithOutputSchema[scheduleResult](),
+	}
+
+	return mcp.NewTool("schedule_notification", append(opts, notificationArguments(cfg)...)...)
+}
+
+func buildListScheduledTool() mcp.Tool {
+	return mcp.NewTool("list_scheduled",
+		mcp.WithDescription("Lists scheduled notifications that have not been sent yet, soonest first, including ones whose delivery failed."),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithSchemaAdditionalProperties(false),
+		mcp.WithOutputSchema[scheduledListResult](),
+	)
+}
+
+func buildCancelScheduledTool() mcp.Tool {
+	return mcp.NewTool("cancel_scheduled",
+		mcp.WithDescription("Cancels a scheduled notification, or removes one whose delivery failed."),
+		mcp.WithString("id",
+			mcp.Required(),
+			mcp.Description("ID returned by schedule_notification"),
+		),
+		mcp.WithSchemaAdditionalProperties(false),
+	)
+}
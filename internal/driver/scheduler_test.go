@@ -0,0 +1,204 @@
+package driver
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/adlandh/pushover-mcp/internal/application"
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+type fakeScheduler struct {
+	err          error
+	list         []domain.ScheduledNotification
+	notification domain.Notification
+	sendAt       time.Time
+	canceled     string
+}
+
+func (f *fakeScheduler) Schedule(
+	_ context.Context,
+	notification domain.Notification,
+	sendAt time.Time,
+) (domain.ScheduledNotification, error) {
+	f.notification = notification
+	f.sendAt = sendAt
+
+	return domain.ScheduledNotification{ID: "a1", SendAt: sendAt.UTC(), Notification: notification}, f.err
+}
+
+func (f *fakeScheduler) List(_ context.Context) ([]domain.ScheduledNotification, error) {
+	return f.list, f.err
+}
+
+func (f *fakeScheduler) Cancel(_ context.Context, id string) error {
+	f.canceled = id
+
+	return f.err
+}
+
+func newServerWithScheduler(scheduler ScheduleExecutor) *server.MCPServer {
+	// The alias option comes after WithScheduler on purpose: schedule_notification must still see it.
+	return NewServer(testServerName, testServerVersion,
+		application.NewSendNotificationUseCase(&fakeNotificationSender{}),
+		WithScheduler(scheduler),
+		WithRecipientAliases([]string{"oncall"}),
+	)
+}
+
+func newScheduleRequest(args map[string]any) mcp.CallToolRequest {
+	return mcp.CallToolRequest{Params: mcp.CallToolParams{Name: "schedule_notification", Arguments: args}}
+}
+
+func TestWithScheduler_ScheduleTool(t *testing.T) {
+	scheduler := &fakeScheduler{}
+	tool := newServerWithScheduler(scheduler).GetTool("schedule_notification")
+
+	if tool == nil {
+		t.Fatal("schedule_notification tool was not registered")
+	}
+
+	for _, name := range []string{"send_at", "delay", "message", "recipient"} {
+		if _, ok := tool.Tool.InputSchema.Properties[name]; !ok {
+			t.Fatalf("%s property missing", name)
+		}
+	}
+
+	if _, ok := tool.Tool.InputSchema.Properties["recipients"]; ok {
+		t.Fatal("recipients property registered on schedule_notification")
+	}
+
+	result := callToolHandler(t, tool, newScheduleRequest(map[string]any{
+		"message":   testMessage,
+		"recipient": "oncall",
+		"send_at":   "2026-05-01T17:00:00+02:00",
+	}))
+
+	assertResultText(t, result, "Notification scheduled for 2026-05-01T15:00:00Z. ID: a1.")
+
+	if !scheduler.sendAt.Equal(time.Date(2026, 5, 1, 15, 0, 0, 0, time.UTC)) ||
+		scheduler.notification.Message != testMessage || scheduler.notification.User != "oncall" {
+		t.Fatalf("scheduled %+v at %v", scheduler.notification, scheduler.sendAt)
+	}
+}
+
+func TestScheduleHandler_Delay(t *testing.T) {
+	scheduler := &fakeScheduler{}
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	handler := scheduleHandler(scheduler, func() time.Time { return now }, "")
+
+	result, err := handler(t.Context(), newScheduleRequest(map[string]any{"message": testMessage, "delay": "2h30m"}))
+	if err != nil || result.IsError {
+		t.Fatalf("handler = %+v, %v", result, err)
+	}
+
+	if !scheduler.sendAt.Equal(now.Add(150 * time.Minute)) {
+		t.Fatalf("sendAt = %v, want %v", scheduler.sendAt, now.Add(150*time.Minute))
+	}
+}
+
+func TestScheduleHandler_InvalidTime(t *testing.T) {
+	tests := []struct {
+		name string
+		args map[string]any
+		want string
+	}{
+		{name: "neither", args: map[string]any{}, want: "exactly one of send_at and delay is required"},
+		{name: "both", args: map[string]any{"send_at": "2026-05-01T17:00:00Z", "delay": "1h"}, want: "exactly one"},
+		{name: "no offset", args: map[string]any{"send_at": "2026-05-01T17:00:00"}, want: "send_at must be an RFC 3339 time"},
+		{name: "bad delay", args: map[string]any{"delay": "tomorrow"}, want: "delay must be a duration"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			scheduler := &fakeScheduler{}
+			tool := newServerWithScheduler(scheduler).GetTool("schedule_notification")
+
+			tc.args["message"] = testMessage
+			result := callToolHandler(t, tool, newScheduleRequest(tc.args))
+
+			assertResultContainsText(t, result, tc.want)
+
+			if scheduler.notification.Message != "" {
+				t.Fatal("notification was scheduled")
+			}
+		})
+	}
+}
+
+func TestScheduleHandler_Error(t *testing.T) {
+	tool := newServerWithScheduler(&fakeScheduler{err: application.ErrScheduleInPast}).GetTool("schedule_notification")
+
+	result := callToolHandler(t, tool, newScheduleRequest(map[string]any{"message": testMessage, "delay": "-1h"}))
+
+	assertResultContainsText(t, result, "Failed to schedule notification: scheduled time must be in the future")
+}
+
+func TestListScheduledHandler(t *testing.T) {
+	sendAt := time.Date(2026, 5, 1, 15, 0, 0, 0, time.UTC)
+	scheduler := &fakeScheduler{list: []domain.ScheduledNotification{
+		{ID: "a1", SendAt: sendAt, Notification: domain.Notification{Message: "stand-up", User: "oncall"}},
+		{ID: "b2", SendAt: sendAt, Notification: domain.Notification{Message: "deploy"}, Failed: true, Attempts: 1, LastError: "bad user"},
+	}}
+	tool := newServerWithScheduler(scheduler).GetTool("list_scheduled")
+
+	result := callToolHandler(t, tool, mcp.CallToolRequest{Params: mcp.CallToolParams{Name: "list_scheduled"}})
+
+	assertResultText(t, result, "2 scheduled notification(s).")
+
+	structured, ok := result.StructuredContent.(scheduledListResult)
+	if !ok || len(structured.Scheduled) != 2 {
+		t.Fatalf("structured content = %#v", result.StructuredContent)
+	}
+
+	first, second := structured.Scheduled[0], structured.Scheduled[1]
+	if first.Status != scheduledStatusPending || first.Recipient != "oncall" || first.Message != "stand-up" {
+		t.Fatalf("first = %+v", first)
+	}
+
+	if second.Status != scheduledStatusFailed || second.LastError != "bad user" || second.Attempts != 1 {
+		t.Fatalf("second = %+v", second)
+	}
+}
+
+func TestCancelScheduledHandler(t *testing.T) {
+	scheduler := &fakeScheduler{}
+	tool := newServerWithScheduler(scheduler).GetTool("cancel_scheduled")
+	request := mcp.CallToolRequest{Params: mcp.CallToolParams{Name: "cancel_scheduled", Arguments: map[string]any{"id": "a1"}}}
+
+	assertResultText(t, callToolHandler(t, tool, request), "Scheduled notification canceled.")
+
+	if scheduler.canceled != "a1" {
+		t.Fatalf("canceled = %q, want a1", scheduler.canceled)
+	}
+
+	scheduler.err = application.ErrScheduledNotFound
+	assertResultContainsText(t, callToolHandler(t, tool, request), "scheduled notification not found")
+}
+
+func TestNewServer_NoScheduler_NoScheduleTools(t *testing.T) {
+	s := NewServer(testServerName, testServerVersion, application.NewSendNotificationUseCase(&fakeNotificationSender{}))
+
+	if s.GetTool("schedule_notification") != nil {
+		t.Fatal("schedule_notification registered without a scheduler")
+	}
+}
+
+func TestScheduleTool_RateLimited(t *testing.T) {
+	retryAt := time.Now().Add(time.Minute).Truncate(time.Second)
+	scheduler := &fakeScheduler{err: fmt.Errorf("split parts: %w",
+		&application.RateLimitError{RetryAt: retryAt, Scope: application.RateScopeSession})}
+
+	result := callToolHandler(t, newServerWithScheduler(scheduler).GetTool("schedule_notification"),
+		newScheduleRequest(map[string]any{"message": testMessage, "delay": "1m"}))
+
+	structured, ok := result.StructuredContent.(rateLimitedResult)
+	if !result.IsError || !ok || !structured.RetryAt.Equal(retryAt) || structured.Scope != application.RateScopeSession {
+		t.Fatalf("result = %+v, want a rate limit rejection until %v", result, retryAt)
+	}
+}
@@ -2,17 +2,31 @@ package driver
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 
+	"github.com/adlandh/pushover-mcp/internal/application"
 	"github.com/adlandh/pushover-mcp/internal/domain"
 )
 
 const NotificationSentMessage = "Notification sent."
 
+const (
+	formatText     = "text"
+	formatMarkdown = "markdown"
+)
+
 type NotificationExecutor interface {
-	Execute(ctx context.Context, notification domain.Notification) error
+	Execute(ctx context.Context, notification domain.Notification) (domain.SendResult, error)
+	ExecuteFanOut(
+		ctx context.Context,
+		notification domain.Notification,
+		recipients []string,
+	) ([]application.RecipientResult, error)
 }
 
 type sendArguments struct {
@@ -24,7 +38,172 @@ type sendArguments struct {
 	URL      *string `json:"url,omitempty"`
 	URLTitle *string `json:"url_title,omitempty"`
 	Device   *string `json:"device,omitempty"`
-	Message  string  `json:"message"`
+
+	Recipient      *string `json:"recipient,omitempty"`
+	App            *string `json:"app,omitempty"`
+	IdempotencyKey *string `json:"idempotency_key,omitempty"`
+
+	Format    *string `json:"format,omitempty"`
+	HTML      *bool   `json:"html,omitempty"`
+	Monospace *bool   `json:"monospace,omitempty"`
+	Timestamp *int64  `json:"timestamp,omitempty"`
+	TTL       *int    `json:"ttl,omitempty"`
+
+	AttachmentPath   *string `json:"attachment_path,omitempty"`
+	AttachmentBase64 *string `json:"attachment_base64,omitempty"`
+	AttachmentType   *string `json:"attachment_type,omitempty"`
+
+	Message    string   `json:"message"`
+	Tags       []string `json:"tags,omitempty"`
+	Recipients []string `json:"recipients,omitempty"`
+}
+
+type sendResult struct {
+	RateLimit  *rateLimit            `json:"rate_limit,omitempty"`
+	Request    string                `json:"request,omitempty"`
+	Receipt    string                `json:"receipt,omitempty"`
+	Recipients []recipientSendResult `json:"recipients,omitempty"`
+	Truncated  []string              `json:"truncated,omitempty"`
+	Sanitized  []string              `json:"sanitized,omitempty"`
+	Parts      int                   `json:"parts,omitempty"`
+	QuietHours *quietHoursResult     `json:"quiet_hours,omitempty"`
+	Duplicate  bool                  `json:"duplicate,omitempty"`
+	Digest     *digestResult         `json:"digest,omitempty"`
+}
+
+// digestResult reports a notification collected for a digest: flush_at is when a pending digest
+// goes out, items how many notifications it holds or, once sent, combined.
+type digestResult struct {
+	FlushAt *time.Time `json:"flush_at,omitempty"`
+	Items   int        `json:"items"`
+}
+
+func newDigestResult(outcome *domain.DigestOutcome) *digestResult {
+	if outcome == nil {
+		return nil
+	}
+
+	out := &digestResult{Items: outcome.Items}
+	if !outcome.FlushAt.IsZero() {
+		out.FlushAt = &outcome.FlushAt
+	}
+
+	return out
+}
+
+// quietHoursResult tells what the quiet-hours policy did instead of, or on top of, sending.
+type quietHoursResult struct {
+	Until        time.Time `json:"until"`
+	Action       string    `json:"action"`
+	ScheduledID  string    `json:"scheduled_id,omitempty"`
+	FromPriority *int      `json:"from_priority,omitempty"`
+	ToPriority   *int      `json:"to_priority,omitempty"`
+}
+
+func newQuietHoursResult(outcome *domain.QuietHoursOutcome) *quietHoursResult {
+	if outcome == nil {
+		return nil
+	}
+
+	out := &quietHoursResult{Until: outcome.Until, Action: outcome.Action, ScheduledID: outcome.ScheduledID}
+	if outcome.Action == domain.QuietDowngraded {
+		out.FromPriority, out.ToPriority = &outcome.From, &outcome.To
+	}
+
+	return out
+}
+
+// quietHoursText describes the outcome; held and suppressed notifications were not sent.
+func quietHoursText(outcome domain.QuietHoursOutcome) string {
+	until := outcome.Until.Format(time.RFC3339)
+
+	switch outcome.Action {
+	case domain.QuietDowngraded:
+		return fmt.Sprintf("Quiet hours until %s: priority lowered from %d to %d.", until, outcome.From, outcome.To)
+	case domain.QuietHeld:
+		return fmt.Sprintf("Quiet hours until %s: notification held and scheduled as %s; cancel_scheduled stops it.",
+			until, outcome.ScheduledID)
+	default:
+		return fmt.Sprintf("Quiet hours until %s: notification not sent, only emergency priority (2) is delivered.", until)
+	}
+}
+
+func newSendResult(result domain.SendResult) sendResult {
+	out := sendResult{
+		Request:   result.Request,
+		Receipt:   result.Receipt,
+		Truncated: result.Truncated,
+		Sanitized: result.Sanitized,
+		Parts:     result.Parts,
+		Duplicate: result.Duplicate,
+
+		QuietHours: newQuietHoursResult(result.QuietHours),
+		Digest:     newDigestResult(result.Digest),
+	}
+
+	if result.RateLimit != nil {
+		limit := newRateLimit(*result.RateLimit)
+		out.RateLimit = &limit
+	}
+
+	return out
+}
+
+// sendResultText is the text fallback for clients that ignore structured content.
+func sendResultText(result domain.SendResult) string {
+	var notSent string
+
+	switch {
+	case result.Digest != nil && !result.Digest.FlushAt.IsZero():
+		notSent = fmt.Sprintf("Collected for a digest, not sent yet: %d notifications so far, sent by %s.",
+			result.Digest.Items, result.Digest.FlushAt.Format(time.RFC3339))
+	case result.QuietHours != nil && result.QuietHours.Action != domain.QuietDowngraded:
+		notSent = quietHoursText(*result.QuietHours)
+	}
+
+	if notSent != "" && result.Duplicate {
+		return "Duplicate of an earlier request. " + notSent
+	}
+
+	if notSent != "" {
+		return notSent
+	}
+
+	parts := []string{NotificationSentMessage}
+
+	if result.Duplicate {
+		parts = []string{"Duplicate of an earlier request; not sent again."}
+	}
+
+	if result.Request != "" {
+		parts = append(parts, fmt.Sprintf("Request: %s.", result.Request))
+	}
+
+	if result.Receipt != "" {
+		parts = append(parts, fmt.Sprintf("Receipt: %s.", result.Receipt))
+	}
+
+	if result.Parts > 1 {
+		parts = append(parts, fmt.Sprintf("Split into %d messages.", result.Parts))
+	}
+
+	if len(result.Sanitized) > 0 {
+		parts = append(parts, fmt.Sprintf("Removed unsupported HTML: %s.", strings.Join(result.Sanitized, ", ")))
+	}
+
+	if len(result.Truncated) > 0 {
+		parts = append(parts, fmt.Sprintf("Truncated to fit Pushover limits: %s.", strings.Join(result.Truncated, ", ")))
+	}
+
+	if result.Digest != nil {
+		parts = append(parts, fmt.Sprintf("Sent in a digest of %d notifications.", result.Digest.Items))
+	}
+
+	if result.QuietHours != nil {
+		parts = append(parts, quietHoursText(*result.QuietHours))
+	}
+
+	return strings.Join(parts, " ")
 }
 
 func deref(p *string) string {
@@ -35,46 +214,167 @@ func deref(p *string) string {
 	return *p
 }
 
-func NewServer(name, version string, useCase NotificationExecutor) *server.MCPServer {
-	s := server.NewMCPServer(
-		name,
-		version,
+func derefBool(p *bool) bool {
+	return p != nil && *p
+}
+
+// serverConfig collects what options contribute before the server is built.
+type serverConfig struct {
+	tools      []server.ServerTool
+	resources  []server.ServerResource
+	sounds     []string
+	aliases    []string
+	apps       []string
+	defaultApp string
+	limiter    RateLimiter
+	// attachmentDir is the only directory attachment_path may read from; empty disables the argument.
+	attachmentDir string
+	// argumentTools build tools that take the send tool's arguments or the app argument, once every option is applied.
+	argumentTools []func(cfg serverConfig) []server.ServerTool
+}
+
+// Option adds optional tools and resources on top of the always-present send tool.
+type Option func(cfg *serverConfig)
+
+func NewServer(name, version string, useCase NotificationExecutor, opts ...Option) *server.MCPServer {
+	var cfg serverConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	serverOpts := []server.ServerOption{
 		server.WithToolCapabilities(false),
 		server.WithInputSchemaValidation(),
 		server.WithRecovery(),
-	)
+	}
 
-	s.AddTool(buildSendTool(), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if len(cfg.resources) > 0 {
+		serverOpts = append(serverOpts, server.WithResourceCapabilities(false, false))
+	}
+
+	s := server.NewMCPServer(name, version, serverOpts...)
+
+	tools := append([]server.ServerTool{{Tool: buildSendTool(cfg), Handler: sendHandler(useCase, cfg.attachmentDir)}}, cfg.tools...)
+	for _, build := range cfg.argumentTools {
+		tools = append(tools, build(cfg)...)
+	}
+
+	if cfg.limiter != nil {
+		for i, tool := range tools {
+			if rateLimitedTools[tool.Tool.Name] {
+				tools[i].Handler = rateLimited(cfg.limiter, tool.Handler)
+			}
+		}
+	}
+
+	s.AddTools(tools...)
+
+	s.AddResources(cfg.resources...)
+
+	return s
+}
+
+func sendHandler(useCase NotificationExecutor, attachmentDir string) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		var args sendArguments
 		if err := request.BindArguments(&args); err != nil {
 			return mcp.NewToolResultErrorf("invalid tool arguments: %v", err), nil
 		}
 
-		notification := domain.Notification{
-			Message:  args.Message,
-			Title:    deref(args.Title),
-			Priority: args.Priority,
-			Retry:    args.Retry,
-			Expire:   args.Expire,
-			Sound:    deref(args.Sound),
-			URL:      deref(args.URL),
-			URLTitle: deref(args.URLTitle),
-			Device:   deref(args.Device),
+		notification, err := args.notification(attachmentDir)
+		if err != nil {
+			return mcp.NewToolResultErrorf("invalid attachment: %v", err), nil
 		}
 
-		if err := useCase.Execute(ctx, notification); err != nil {
-			return mcp.NewToolResultErrorf("Failed to send notification: %v", err), nil
+		if args.Recipient != nil && len(args.Recipients) > 0 {
+			return mcp.NewToolResultError("invalid tool arguments: recipient and recipients are mutually exclusive"), nil
 		}
 
-		return mcp.NewToolResultText(NotificationSentMessage), nil
-	})
+		if len(args.Recipients) > 0 {
+			return fanOut(ctx, useCase, notification, args.Recipients), nil
+		}
 
-	return s
+		result, err := useCase.Execute(ctx, notification)
+		if err != nil {
+			return toolErrorWithRetryHint("Failed to send notification", err), nil
+		}
+
+		return mcp.NewToolResultStructured(newSendResult(result), sendResultText(result)), nil
+	}
 }
 
-func buildSendTool() mcp.Tool {
-	return mcp.NewTool("send",
+// notification builds the domain notification; the only error is an unusable attachment.
+// attachment_path is read from inside attachmentDir.
+func (args sendArguments) notification(attachmentDir string) (domain.Notification, error) {
+	attachment, err := application.ResolveAttachment(
+		attachmentDir,
+		deref(args.AttachmentPath),
+		deref(args.AttachmentBase64),
+		deref(args.AttachmentType),
+	)
+	if err != nil {
+		return domain.Notification{}, err
+	}
+
+	return domain.Notification{
+		Message:  args.Message,
+		Title:    deref(args.Title),
+		Priority: args.Priority,
+		Retry:    args.Retry,
+		Expire:   args.Expire,
+		Sound:    deref(args.Sound),
+		URL:      deref(args.URL),
+		URLTitle: deref(args.URLTitle),
+		Device:   deref(args.Device),
+		Tags:     args.Tags,
+		User:     deref(args.Recipient),
+		App:      deref(args.App),
+
+		HTML:      derefBool(args.HTML),
+		Markdown:  deref(args.Format) == formatMarkdown,
+		Monospace: derefBool(args.Monospace),
+		Timestamp: args.Timestamp,
+		TTL:       args.TTL,
+
+		Attachment: attachment,
+
+		IdempotencyKey: deref(args.IdempotencyKey),
+	}, nil
+}
+
+func buildSendTool(cfg serverConfig) mcp.Tool {
+	recipientsDescription := "User or group keys to send to instead of the configured user key; the result reports each recipient"
+	if len(cfg.aliases) > 0 {
+		recipientsDescription = "Recipient aliases (" + strings.Join(cfg.aliases, ", ") +
+			") or user/group keys to send to; the result reports each recipient"
+	}
+
+	opts := []mcp.ToolOption{
 		mcp.WithDescription("Sends a notification via Pushover."),
+		mcp.WithArray("recipients",
+			mcp.Description(recipientsDescription),
+			mcp.WithStringItems(),
+			mcp.MaxItems(application.MaxRecipients),
+		),
+		mcp.WithString("idempotency_key",
+			mcp.Description("Sends the notification once per key: repeating a call with the same key and content "+
+				"returns the first result instead of sending again"),
+		),
+		mcp.WithSchemaAdditionalProperties(false),
+		mcp.WithOutputSchema[sendResult](),
+	}
+
+	return mcp.NewTool("send", append(opts, notificationArguments(cfg)...)...)
+}
+
+// notificationArguments describes the notification fields shared by the send and schedule_notification tools.
+func notificationArguments(cfg serverConfig) []mcp.ToolOption {
+	soundOpts := []mcp.PropertyOption{mcp.Description("Notification sound")}
+	if len(cfg.sounds) > 0 {
+		soundOpts = append(soundOpts, mcp.Enum(cfg.sounds...))
+	}
+
+	return []mcp.ToolOption{
 		mcp.WithString("message",
 			mcp.Required(),
 			mcp.Description("The message to send"),
@@ -96,9 +396,7 @@ func buildSendTool() mcp.Tool {
 			mcp.Min(30),
 			mcp.Max(10800),
 		),
-		mcp.WithString("sound",
-			mcp.Description("Notification sound"),
-		),
+		mcp.WithString("sound", soundOpts...),
 		mcp.WithString("url",
 			mcp.Description("URL to include"),
 		),
@@ -108,6 +406,93 @@ func buildSendTool() mcp.Tool {
 		mcp.WithString("device",
 			mcp.Description("Target specific device"),
 		),
-		mcp.WithSchemaAdditionalProperties(false),
+		recipientArgument(cfg.aliases),
+		appArgument("Pushover application to send as; each has its own icon and quota", cfg.apps, cfg.defaultApp),
+		mcp.WithString("format",
+			mcp.Description("Message format: text, or markdown to convert Markdown to Pushover HTML (tables, headings and code become plain text)"),
+			mcp.Enum(formatText, formatMarkdown),
+		),
+		mcp.WithBoolean("html",
+			mcp.Description("Render message as Pushover HTML (<b>, <i>, <u>, <font color>, <a href>); not with monospace"),
+		),
+		mcp.WithBoolean("monospace",
+			mcp.Description("Render message in a monospace font, e.g. for stack traces; not with html"),
+		),
+		mcp.WithNumber("timestamp",
+			mcp.Description("Unix timestamp shown as the message time instead of the time Pushover received it"),
+			mcp.Min(1),
+		),
+		mcp.WithNumber("ttl",
+			mcp.Description("Seconds before the message is deleted from devices (ignored for emergency priority 2)"),
+			mcp.Min(1),
+		),
+		mcp.WithArray("tags",
+			mcp.Description("Tags for emergency priority 2, used later to cancel with cancel_by_tag"),
+			mcp.WithStringItems(),
+		),
+		attachmentPathArgument(cfg.attachmentDir),
+		mcp.WithString("attachment_base64",
+			mcp.Description("Base64-encoded image or data URI to attach (max 5 MB decoded)"),
+		),
+		mcp.WithString("attachment_type",
+			mcp.Description("MIME type of attachment_base64, e.g. image/png (detected from content when omitted)"),
+		),
+	}
+}
+
+// attachmentPathArgument adds the attachment_path argument when an attachment directory is configured.
+func attachmentPathArgument(dir string) mcp.ToolOption {
+	if dir == "" {
+		return func(*mcp.Tool) {}
+	}
+
+	return mcp.WithString("attachment_path",
+		mcp.Description("Image file to attach (max 5 MB), relative to or inside "+dir+"; do not combine with attachment_base64"),
 	)
 }
+
+// recipientArgument adds the recipient argument, restricted to the configured alias names, when there are any.
+func recipientArgument(aliases []string) mcp.ToolOption {
+	if len(aliases) == 0 {
+		return func(*mcp.Tool) {}
+	}
+
+	return mcp.WithString("recipient",
+		mcp.Description("Configured recipient to send to instead of the default user; uses its default device, sound and priority"),
+		mcp.Enum(aliases...),
+	)
+}
+
+// WithRecipientAliases exposes the configured alias names as the enum of the send tool's recipient argument.
+func WithRecipientAliases(names []string) Option {
+	return func(cfg *serverConfig) {
+		cfg.aliases = append(cfg.aliases, names...)
+	}
+}
+
+// appArgument adds the app argument, described by description, when more than one application profile is configured.
+func appArgument(description string, apps []string, defaultApp string) mcp.ToolOption {
+	if len(apps) == 0 {
+		return func(*mcp.Tool) {}
+	}
+
+	return mcp.WithString("app",
+		mcp.Description(fmt.Sprintf("%s (default: %s)", description, defaultApp)),
+		mcp.Enum(apps...),
+	)
+}
+
+// WithApps exposes the application profile names as the enum of the app argument of every tool that takes one.
+func WithApps(names []string, defaultApp string) Option {
+	return func(cfg *serverConfig) {
+		cfg.apps = append(cfg.apps, names...)
+		cfg.defaultApp = defaultApp
+	}
+}
+
+// WithAttachmentDir offers the attachment_path argument, restricted to files inside dir.
+func WithAttachmentDir(dir string) Option {
+	return func(cfg *serverConfig) {
+		cfg.attachmentDir = dir
+	}
+}
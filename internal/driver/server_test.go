@@ -4,8 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -23,18 +28,19 @@ const (
 
 type fakeNotificationSender struct {
 	err          error
+	result       domain.SendResult
 	called       bool
 	notification domain.Notification
 }
 
-func (f *fakeNotificationSender) Send(_ context.Context, notification domain.Notification) error {
+func (f *fakeNotificationSender) Send(_ context.Context, notification domain.Notification) (domain.SendResult, error) {
 	f.called = true
 	f.notification = notification
 
-	return f.err
+	return f.result, f.err
 }
 
-func setupServerWithTool(t *testing.T, sender *fakeNotificationSender) *server.ServerTool {
+func setupServerWithTool(t *testing.T, sender domain.NotificationSender) *server.ServerTool {
 	t.Helper()
 
 	useCase := application.NewSendNotificationUseCase(sender)
@@ -166,6 +172,7 @@ func TestSendToolHandler_Success(t *testing.T) {
 		"message":  testMessage,
 		"priority": priority,
 		"url":      "https://example.com",
+		"tags":     []any{"deploy", "release"},
 	})
 
 	result := callToolHandler(t, tool, request)
@@ -182,10 +189,41 @@ func TestSendToolHandler_Success(t *testing.T) {
 	if fakeSender.notification.URL != "https://example.com" {
 		t.Fatalf("url = %q, want https://example.com", fakeSender.notification.URL)
 	}
+	if len(fakeSender.notification.Tags) != 2 || fakeSender.notification.Tags[1] != "release" {
+		t.Fatalf("tags = %v, want [deploy release]", fakeSender.notification.Tags)
+	}
 
 	assertResultText(t, result, NotificationSentMessage)
 }
 
+func TestSendToolHandler_StructuredResult(t *testing.T) {
+	fakeSender := &fakeNotificationSender{result: domain.SendResult{
+		Request: "req-1",
+		Receipt: "rcpt-1",
+		RateLimit: &domain.RateLimit{
+			Limit:     10000,
+			Remaining: 9999,
+			Reset:     time.Unix(1700000000, 0).UTC(),
+		},
+	}}
+	tool := setupServerWithTool(t, fakeSender)
+
+	result := callToolHandler(t, tool, newCallToolRequest(map[string]any{"message": testMessage}))
+
+	assertResultText(t, result, NotificationSentMessage+" Request: req-1. Receipt: rcpt-1.")
+
+	structured, ok := result.StructuredContent.(sendResult)
+	if !ok {
+		t.Fatalf("structured content = %T, want sendResult", result.StructuredContent)
+	}
+	if structured.Request != "req-1" || structured.Receipt != "rcpt-1" {
+		t.Fatalf("structured = %+v", structured)
+	}
+	if structured.RateLimit == nil || structured.RateLimit.Remaining != 9999 {
+		t.Fatalf("rate limit = %+v, want remaining 9999", structured.RateLimit)
+	}
+}
+
 func TestSendToolHandler_InvalidArguments(t *testing.T) {
 	tool := setupServerWithTool(t, &fakeNotificationSender{})
 
@@ -242,3 +280,350 @@ func TestSendToolHandler_UseCaseError(t *testing.T) {
 
 	assertResultContainsText(t, result, "Failed to send notification")
 }
+
+func TestSendToolHandler_Base64Attachment(t *testing.T) {
+	fakeSender := &fakeNotificationSender{}
+	tool := setupServerWithTool(t, fakeSender)
+
+	result := callToolHandler(t, tool, newCallToolRequest(map[string]any{
+		"message":           testMessage,
+		"attachment_base64": "iVBORw0KGgoAAAANSUhEUg==",
+	}))
+
+	assertResultText(t, result, NotificationSentMessage)
+
+	attachment := fakeSender.notification.Attachment
+	if attachment == nil || attachment.MIMEType != "image/png" {
+		t.Fatalf("attachment = %+v, want image/png", attachment)
+	}
+}
+
+func TestSendToolHandler_InvalidAttachment(t *testing.T) {
+	fakeSender := &fakeNotificationSender{}
+	tool := setupServerWithTool(t, fakeSender)
+
+	result := callToolHandler(t, tool, newCallToolRequest(map[string]any{
+		"message":         testMessage,
+		"attachment_path": "/does/not/exist.png",
+	}))
+
+	assertResultContainsText(t, result, "invalid attachment")
+
+	if fakeSender.called {
+		t.Fatal("sender.Send was called")
+	}
+}
+
+func TestSendToolHandler_AttachmentDir(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "chart.png"), []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"), 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	if _, ok := setupServerWithTool(t, &fakeNotificationSender{}).Tool.InputSchema.Properties["attachment_path"]; ok {
+		t.Fatal("attachment_path offered without an attachment directory")
+	}
+
+	sender := &fakeNotificationSender{}
+	s := NewServer(testServerName, testServerVersion, application.NewSendNotificationUseCase(sender), WithAttachmentDir(dir))
+	tool := s.GetTool(toolNameSend)
+
+	if _, ok := tool.Tool.InputSchema.Properties["attachment_path"]; !ok {
+		t.Fatal("attachment_path not offered")
+	}
+
+	result := callToolHandler(t, tool, newCallToolRequest(map[string]any{"message": testMessage, "attachment_path": "chart.png"}))
+	assertResultText(t, result, NotificationSentMessage)
+
+	if sender.notification.Attachment == nil || sender.notification.Attachment.Filename != "chart.png" {
+		t.Fatalf("attachment = %+v, want chart.png", sender.notification.Attachment)
+	}
+
+	result = callToolHandler(t, tool, newCallToolRequest(map[string]any{"message": testMessage, "attachment_path": "/etc/passwd"}))
+	assertResultContainsText(t, result, "inside the attachment directory")
+}
+
+func TestSendToolHandler_FormattingAndTiming(t *testing.T) {
+	fakeSender := &fakeNotificationSender{}
+	tool := setupServerWithTool(t, fakeSender)
+
+	result := callToolHandler(t, tool, newCallToolRequest(map[string]any{
+		"message":   testMessage,
+		"html":      true,
+		"timestamp": 1331249662,
+		"ttl":       60,
+	}))
+
+	assertResultText(t, result, NotificationSentMessage)
+
+	n := fakeSender.notification
+	if !n.HTML || n.Monospace {
+		t.Fatalf("html = %v monospace = %v, want true false", n.HTML, n.Monospace)
+	}
+	if n.Timestamp == nil || *n.Timestamp != 1331249662 {
+		t.Fatalf("timestamp = %v, want 1331249662", n.Timestamp)
+	}
+	if n.TTL == nil || *n.TTL != 60 {
+		t.Fatalf("ttl = %v, want 60", n.TTL)
+	}
+}
+
+func TestSendToolHandler_HTMLAndMonospace(t *testing.T) {
+	fakeSender := &fakeNotificationSender{}
+	tool := setupServerWithTool(t, fakeSender)
+
+	result := callToolHandler(t, tool, newCallToolRequest(map[string]any{
+		"message":   testMessage,
+		"html":      true,
+		"monospace": true,
+	}))
+
+	assertResultContainsText(t, result, "mutually exclusive")
+}
+
+func TestSendToolHandler_RetryHint(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "retryable",
+			err:  &domain.APIError{Kind: domain.ErrUpstreamUnavailable, Status: "503 Service Unavailable"},
+			want: "retrying later may succeed",
+		},
+		{
+			name: "quota",
+			err:  &domain.APIError{Kind: domain.ErrQuotaExceeded, Status: "429 Too Many Requests"},
+			want: "Do not retry before the monthly quota resets",
+		},
+		{
+			name: "unknown outcome",
+			err:  fmt.Errorf("request pushover: %w: timeout", domain.ErrDeliveryUnknown),
+			want: "a retry could repeat it",
+		},
+		{
+			name: "permanent",
+			err:  &domain.APIError{Kind: domain.ErrInvalidRecipient, Status: "400 Bad Request"},
+			want: "Retrying the same request will not help",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tool := setupServerWithTool(t, &fakeNotificationSender{err: tc.err})
+
+			result := callToolHandler(t, tool, newCallToolRequest(map[string]any{"message": testMessage}))
+
+			assertResultContainsText(t, result, tc.want)
+		})
+	}
+}
+
+func TestWithApps_AppArgument(t *testing.T) {
+	sender := &fakeNotificationSender{}
+	s := NewServer(testServerName, testServerVersion, application.NewSendNotificationUseCase(sender,
+		application.WithApps(map[string]domain.NotificationSender{"ci": sender, "default": sender}),
+	), WithApps([]string{"ci", "default"}, "default"))
+	tool := s.GetTool(toolNameSend)
+
+	property, ok := tool.Tool.InputSchema.Properties["app"].(map[string]any)
+	if !ok {
+		t.Fatal("app property missing")
+	}
+
+	if enum, ok := property["enum"].([]string); !ok || !slices.Equal(enum, []string{"ci", "default"}) {
+		t.Fatalf("app enum = %v, want [ci default]", property["enum"])
+	}
+
+	result := callToolHandler(t, tool, newCallToolRequest(map[string]any{"message": testMessage, "app": "ci"}))
+	assertResultText(t, result, NotificationSentMessage)
+
+	if sender.notification.App != "ci" {
+		t.Fatalf("App = %q, want ci", sender.notification.App)
+	}
+}
+
+func TestNewServer_NoApps_NoAppArgument(t *testing.T) {
+	tool := setupServerWithTool(t, &fakeNotificationSender{})
+
+	if _, ok := tool.Tool.InputSchema.Properties["app"]; ok {
+		t.Fatal("app property registered without app profiles")
+	}
+}
+
+func TestSendToolHandler_ReportsLengthPolicy(t *testing.T) {
+	sender := &fakeNotificationSender{result: domain.SendResult{Request: "req-1"}}
+	useCase := application.NewSendNotificationUseCase(sender, application.WithLengthPolicy(application.LengthSplit))
+	tool := NewServer(testServerName, testServerVersion, useCase).GetTool(toolNameSend)
+
+	result := callToolHandler(t, tool, newCallToolRequest(map[string]any{
+		"message": strings.Repeat("x", 2*application.MaxMessageLength),
+		"title":   strings.Repeat("t", application.MaxTitleLength+1),
+	}))
+
+	assertResultText(t, result,
+		NotificationSentMessage+" Request: req-1. Split into 3 messages. Truncated to fit Pushover limits: title.")
+
+	structured, ok := result.StructuredContent.(sendResult)
+	if !ok || structured.Parts != 3 || len(structured.Truncated) != 1 {
+		t.Fatalf("structured = %+v", result.StructuredContent)
+	}
+}
+
+func TestSendToolHandler_MarkdownFormat(t *testing.T) {
+	sender := &fakeNotificationSender{}
+	tool := setupServerWithTool(t, sender)
+
+	result := callToolHandler(t, tool, newCallToolRequest(map[string]any{
+		"message": "# Deploy\n- `api` is **up**",
+		"format":  "markdown",
+	}))
+
+	assertResultText(t, result, NotificationSentMessage)
+
+	if sender.notification.Message != "<b>Deploy</b>\n• api is <b>up</b>" || !sender.notification.HTML {
+		t.Fatalf("notification = %+v, want rendered HTML", sender.notification)
+	}
+}
+
+func TestSendToolHandler_ReportsSanitizedHTML(t *testing.T) {
+	tool := setupServerWithTool(t, &fakeNotificationSender{})
+
+	result := callToolHandler(t, tool, newCallToolRequest(map[string]any{
+		"message": `<b>ok</b><a href="javascript:void(0)">x</a>`,
+		"html":    true,
+	}))
+
+	assertResultText(t, result, NotificationSentMessage+` Removed unsupported HTML: link to "javascript:void(0)".`)
+
+	structured, ok := result.StructuredContent.(sendResult)
+	if !ok || len(structured.Sanitized) != 1 {
+		t.Fatalf("structured = %+v", result.StructuredContent)
+	}
+}
+
+// stubExecutor returns fixed results, standing in for policies that wrap the send use case.
+type stubExecutor struct {
+	result  domain.SendResult
+	results []application.RecipientResult
+}
+
+func (s *stubExecutor) Execute(_ context.Context, _ domain.Notification) (domain.SendResult, error) {
+	return s.result, nil
+}
+
+func (s *stubExecutor) ExecuteFanOut(_ context.Context, _ domain.Notification, _ []string) ([]application.RecipientResult, error) {
+	return s.results, nil
+}
+
+func TestSendToolHandler_QuietHours(t *testing.T) {
+	until := time.Date(2026, 5, 2, 7, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		result domain.SendResult
+		want   string
+	}{
+		{
+			name: "downgraded",
+			result: domain.SendResult{Request: "req-1", QuietHours: &domain.QuietHoursOutcome{
+				Until: until, Action: domain.QuietDowngraded, From: 1, To: -1,
+			}},
+			want: NotificationSentMessage + " Request: req-1. Quiet hours until 2026-05-02T07:00:00Z: priority lowered from 1 to -1.",
+		},
+		{
+			name: "held",
+			result: domain.SendResult{QuietHours: &domain.QuietHoursOutcome{
+				Until: until, Action: domain.QuietHeld, ScheduledID: "a1",
+			}},
+			want: "Quiet hours until 2026-05-02T07:00:00Z: notification held and scheduled as a1; cancel_scheduled stops it.",
+		},
+		{
+			name: "held before",
+			result: domain.SendResult{Duplicate: true, QuietHours: &domain.QuietHoursOutcome{
+				Until: until, Action: domain.QuietHeld, ScheduledID: "a1",
+			}},
+			want: "Duplicate of an earlier request. Quiet hours until 2026-05-02T07:00:00Z: " +
+				"notification held and scheduled as a1; cancel_scheduled stops it.",
+		},
+		{
+			name:   "suppressed",
+			result: domain.SendResult{QuietHours: &domain.QuietHoursOutcome{Until: until, Action: domain.QuietSuppressed}},
+			want:   "Quiet hours until 2026-05-02T07:00:00Z: notification not sent, only emergency priority (2) is delivered.",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := NewServer(testServerName, testServerVersion, &stubExecutor{result: tc.result})
+
+			result := callToolHandler(t, s.GetTool(toolNameSend), newCallToolRequest(map[string]any{"message": testMessage}))
+
+			assertResultText(t, result, tc.want)
+
+			structured, ok := result.StructuredContent.(sendResult)
+			if !ok || structured.QuietHours == nil || structured.QuietHours.Action != tc.result.QuietHours.Action {
+				t.Fatalf("structured content = %#v", result.StructuredContent)
+			}
+		})
+	}
+}
+
+func TestSendToolHandler_IdempotencyKey(t *testing.T) {
+	sender := &fakeNotificationSender{result: domain.SendResult{Request: "req-1", Duplicate: true}}
+	tool := setupServerWithTool(t, sender)
+
+	result := callToolHandler(t, tool, newCallToolRequest(map[string]any{
+		"message":         testMessage,
+		"idempotency_key": "deploy-42",
+	}))
+
+	assertResultText(t, result, "Duplicate of an earlier request; not sent again. Request: req-1.")
+
+	if sender.notification.IdempotencyKey != "deploy-42" {
+		t.Fatalf("idempotency key = %q, want deploy-42", sender.notification.IdempotencyKey)
+	}
+
+	structured, ok := result.StructuredContent.(sendResult)
+	if !ok || !structured.Duplicate {
+		t.Fatalf("structured = %+v, want duplicate", result.StructuredContent)
+	}
+}
+
+func TestSendToolHandler_Digest(t *testing.T) {
+	flushAt := time.Date(2026, 5, 1, 12, 10, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		result domain.SendResult
+		want   string
+	}{
+		{
+			name:   "collected",
+			result: domain.SendResult{Digest: &domain.DigestOutcome{FlushAt: flushAt, Items: 2}},
+			want:   "Collected for a digest, not sent yet: 2 notifications so far, sent by 2026-05-01T12:10:00Z.",
+		},
+		{
+			name:   "sent",
+			result: domain.SendResult{Request: "req-1", Digest: &domain.DigestOutcome{Items: 10}},
+			want:   NotificationSentMessage + " Request: req-1. Sent in a digest of 10 notifications.",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := NewServer(testServerName, testServerVersion, &stubExecutor{result: tc.result})
+
+			result := callToolHandler(t, s.GetTool(toolNameSend), newCallToolRequest(map[string]any{"message": testMessage}))
+
+			assertResultText(t, result, tc.want)
+
+			structured, ok := result.StructuredContent.(sendResult)
+			if !ok || structured.Digest == nil || structured.Digest.Items != tc.result.Digest.Items ||
+				(structured.Digest.FlushAt != nil) != !tc.result.Digest.FlushAt.IsZero() {
+				t.Fatalf("structured content = %#v", result.StructuredContent)
+			}
+		})
+	}
+}
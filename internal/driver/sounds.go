@@ -0,0 +1,101 @@
+package driver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+const soundsResourceURI = "pushover://sounds"
+
+type SoundLister interface {
+	List(ctx context.Context) ([]domain.Sound, error)
+}
+
+type soundItem struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type soundsResult struct {
+	Sounds []soundItem `json:"sounds"`
+}
+
+func newSoundsResult(sounds []domain.Sound) soundsResult {
+	items := make([]soundItem, 0, len(sounds))
+	for _, sound := range sounds {
+		items = append(items, soundItem{Name: sound.Name, Description: sound.Description})
+	}
+
+	return soundsResult{Sounds: items}
+}
+
+// WithSounds registers the list_sounds tool and the pushover://sounds resource.
+// known is the catalog loaded at startup; when non-empty it becomes the enum of the send tool's sound argument.
+func WithSounds(sounds SoundLister, known []domain.Sound) Option {
+	return func(cfg *serverConfig) {
+		for _, sound := range known {
+			cfg.sounds = append(cfg.sounds, sound.Name)
+		}
+
+		cfg.tools = append(cfg.tools, server.ServerTool{
+			Tool:    buildListSoundsTool(),
+			Handler: listSoundsHandler(sounds),
+		})
+
+		cfg.resources = append(cfg.resources, server.ServerResource{
+			Resource: mcp.NewResource(soundsResourceURI, "Pushover sounds",
+				mcp.WithResourceDescription("Notification sounds accepted by send, including custom account sounds"),
+				mcp.WithMIMEType("application/json"),
+			),
+			Handler: soundsResourceHandler(sounds),
+		})
+	}
+}
+
+func listSoundsHandler(sounds SoundLister) server.ToolHandlerFunc {
+	return func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		list, err := sounds.List(ctx)
+		if err != nil {
+			return mcp.NewToolResultErrorf("Failed to list sounds: %v", err), nil
+		}
+
+		return mcp.NewToolResultStructured(newSoundsResult(list), fmt.Sprintf("%d sound(s).", len(list))), nil
+	}
+}
+
+func soundsResourceHandler(sounds SoundLister) server.ResourceHandlerFunc {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		list, err := sounds.List(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := json.Marshal(newSoundsResult(list))
+		if err != nil {
+			return nil, fmt.Errorf("marshal sounds: %w", err)
+		}
+
+		return []mcp.ResourceContents{
+			mcp.TextResourceContents{
+				URI:      request.Params.URI,
+				MIMEType: "application/json",
+				Text:     string(data),
+			},
+		}, nil
+	}
+}
+
+func buildListSoundsTool() mcp.Tool {
+	return mcp.NewTool("list_sounds",
+		mcp.WithDescription("Lists the notification sounds accepted by send, including custom account sounds."),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithSchemaAdditionalProperties(false),
+		mcp.WithOutputSchema[soundsResult](),
+	)
+}
@@ -0,0 +1,105 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/adlandh/pushover-mcp/internal/application"
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+type fakeSoundLister struct {
+	err    error
+	sounds []domain.Sound
+}
+
+func (f *fakeSoundLister) List(_ context.Context) ([]domain.Sound, error) {
+	return f.sounds, f.err
+}
+
+var testSounds = []domain.Sound{
+	{Name: "bike", Description: "Bike"},
+	{Name: "pushover", Description: "Pushover (default)"},
+}
+
+func newServerWithSounds(lister SoundLister, known []domain.Sound) *server.MCPServer {
+	return NewServer(testServerName, testServerVersion,
+		application.NewSendNotificationUseCase(&fakeNotificationSender{}),
+		WithSounds(lister, known),
+	)
+}
+
+func TestWithSounds_SendSoundEnum(t *testing.T) {
+	s := newServerWithSounds(&fakeSoundLister{}, testSounds)
+
+	property, ok := s.GetTool(toolNameSend).Tool.InputSchema.Properties["sound"].(map[string]any)
+	if !ok {
+		t.Fatal("sound property missing")
+	}
+
+	enum, ok := property["enum"].([]string)
+	if !ok || !slices.Equal(enum, []string{"bike", "pushover"}) {
+		t.Fatalf("sound enum = %v, want [bike pushover]", property["enum"])
+	}
+}
+
+func TestWithSounds_NoKnownSounds_NoEnum(t *testing.T) {
+	s := newServerWithSounds(&fakeSoundLister{}, nil)
+
+	property, _ := s.GetTool(toolNameSend).Tool.InputSchema.Properties["sound"].(map[string]any)
+	if _, ok := property["enum"]; ok {
+		t.Fatalf("sound enum = %v, want none", property["enum"])
+	}
+}
+
+func TestListSoundsToolHandler(t *testing.T) {
+	s := newServerWithSounds(&fakeSoundLister{sounds: testSounds}, nil)
+
+	tool := s.GetTool("list_sounds")
+	if tool == nil {
+		t.Fatal("list_sounds tool was not registered")
+	}
+
+	result := callToolHandler(t, tool, mcp.CallToolRequest{Params: mcp.CallToolParams{Name: "list_sounds"}})
+
+	structured, ok := result.StructuredContent.(soundsResult)
+	if !ok || len(structured.Sounds) != 2 || structured.Sounds[0].Name != "bike" {
+		t.Fatalf("structured content = %#v", result.StructuredContent)
+	}
+
+	assertResultText(t, result, "2 sound(s).")
+}
+
+func TestListSoundsToolHandler_Error(t *testing.T) {
+	s := newServerWithSounds(&fakeSoundLister{err: errors.New("offline")}, nil)
+
+	result := callToolHandler(t, s.GetTool("list_sounds"), mcp.CallToolRequest{Params: mcp.CallToolParams{Name: "list_sounds"}})
+
+	assertResultContainsText(t, result, "Failed to list sounds")
+}
+
+func TestSoundsResource(t *testing.T) {
+	s := newServerWithSounds(&fakeSoundLister{sounds: testSounds}, nil)
+
+	resource, ok := s.ListResources()[soundsResourceURI]
+	if !ok {
+		t.Fatal("sounds resource was not registered")
+	}
+
+	contents, err := resource.Handler(context.Background(), mcp.ReadResourceRequest{
+		Params: mcp.ReadResourceParams{URI: soundsResourceURI},
+	})
+	if err != nil {
+		t.Fatalf("resource handler error = %v", err)
+	}
+
+	text, ok := contents[0].(mcp.TextResourceContents)
+	if !ok || text.Text != `{"sounds":[{"name":"bike","description":"Bike"},{"name":"pushover","description":"Pushover (default)"}]}` {
+		t.Fatalf("contents = %#v", contents)
+	}
+}
@@ -0,0 +1,125 @@
+package driver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/adlandh/pushover-mcp/internal/application"
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+const templatesResourceURI = "pushover://templates"
+
+type TemplateExecutor interface {
+	List() []application.TemplateInfo
+	Send(ctx context.Context, name string, data map[string]any) (domain.SendResult, error)
+}
+
+type sendTemplateArguments struct {
+	Data     map[string]any `json:"data,omitempty"`
+	Template string         `json:"template"`
+}
+
+type templateItem struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Variables   []string `json:"variables"`
+}
+
+type templatesResult struct {
+	Templates []templateItem `json:"templates"`
+}
+
+func newTemplatesResult(infos []application.TemplateInfo) templatesResult {
+	items := make([]templateItem, 0, len(infos))
+	for _, info := range infos {
+		items = append(items, templateItem{
+			Name:        info.Name,
+			Description: info.Description,
+			Variables:   append([]string{}, info.Variables...),
+		})
+	}
+
+	return templatesResult{Templates: items}
+}
+
+// WithTemplates registers the send_template tool and the pushover://templates resource.
+func WithTemplates(templates TemplateExecutor) Option {
+	return func(cfg *serverConfig) {
+		cfg.tools = append(cfg.tools, server.ServerTool{
+			Tool:    buildSendTemplateTool(templates.List()),
+			Handler: sendTemplateHandler(templates),
+		})
+
+		cfg.resources = append(cfg.resources, server.ServerResource{
+			Resource: mcp.NewResource(templatesResourceURI, "Pushover message templates",
+				mcp.WithResourceDescription("Templates accepted by send_template and the data variables each one uses"),
+				mcp.WithMIMEType("application/json"),
+			),
+			Handler: templatesResourceHandler(templates),
+		})
+	}
+}
+
+func sendTemplateHandler(templates TemplateExecutor) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var args sendTemplateArguments
+		if err := request.BindArguments(&args); err != nil {
+			return mcp.NewToolResultErrorf("invalid tool arguments: %v", err), nil
+		}
+
+		result, err := templates.Send(ctx, args.Template, args.Data)
+		if err != nil {
+			return toolErrorWithRetryHint("Failed to send template", err), nil
+		}
+
+		return mcp.NewToolResultStructured(newSendResult(result), sendResultText(result)), nil
+	}
+}
+
+func templatesResourceHandler(templates TemplateExecutor) server.ResourceHandlerFunc {
+	return func(_ context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		data, err := json.Marshal(newTemplatesResult(templates.List()))
+		if err != nil {
+			return nil, fmt.Errorf("marshal templates: %w", err)
+		}
+
+		return []mcp.ResourceContents{
+			mcp.TextResourceContents{
+				URI:      request.Params.URI,
+				MIMEType: "application/json",
+				Text:     string(data),
+			},
+		}, nil
+	}
+}
+
+func buildSendTemplateTool(infos []application.TemplateInfo) mcp.Tool {
+	names := make([]string, 0, len(infos))
+	usage := make([]string, 0, len(infos))
+
+	for _, info := range infos {
+		names = append(names, info.Name)
+		usage = append(usage, fmt.Sprintf("%s (%s)", info.Name, strings.Join(info.Variables, ", ")))
+	}
+
+	return mcp.NewTool("send_template",
+		mcp.WithDescription("Sends a notification rendered from a server-side template. "+
+			"Templates and their variables: "+strings.Join(usage, "; ")+". See "+templatesResourceURI+" for details."),
+		mcp.WithString("template",
+			mcp.Required(),
+			mcp.Description("Template name"),
+			mcp.Enum(names...),
+		),
+		mcp.WithObject("data",
+			mcp.Description("Template variables, e.g. {\"service\": \"api\", \"env\": \"prod\"}"),
+		),
+		mcp.WithSchemaAdditionalProperties(false),
+		mcp.WithOutputSchema[sendResult](),
+	)
+}
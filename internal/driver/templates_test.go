@@ -0,0 +1,98 @@
+package driver
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/adlandh/pushover-mcp/internal/application"
+	"github.com/adlandh/pushover-mcp/internal/domain"
+)
+
+func newServerWithTemplates(t *testing.T, sender *fakeNotificationSender) *server.MCPServer {
+	t.Helper()
+
+	templates, err := application.NewTemplateUseCase(application.NewSendNotificationUseCase(sender),
+		[]domain.MessageTemplate{{
+			Name:        "deploy",
+			Description: "Deployment finished",
+			Message:     "Deploy {{.service}} to {{.env}} finished",
+			Priority:    "{{.priority}}",
+		}},
+	)
+	if err != nil {
+		t.Fatalf("NewTemplateUseCase() error = %v", err)
+	}
+
+	return NewServer(testServerName, testServerVersion, application.NewSendNotificationUseCase(sender),
+		WithTemplates(templates))
+}
+
+func TestWithTemplates_SendTemplateTool(t *testing.T) {
+	sender := &fakeNotificationSender{result: domain.SendResult{Request: "req-1"}}
+	tool := newServerWithTemplates(t, sender).GetTool("send_template")
+
+	if tool == nil {
+		t.Fatal("send_template tool was not registered")
+	}
+
+	if !strings.Contains(tool.Tool.Description, "deploy (env, priority, service)") {
+		t.Fatalf("description = %q, want template usage", tool.Tool.Description)
+	}
+
+	request := mcp.CallToolRequest{Params: mcp.CallToolParams{
+		Name: "send_template",
+		Arguments: map[string]any{
+			"template": "deploy",
+			"data":     map[string]any{"service": "api", "env": "prod", "priority": 1},
+		},
+	}}
+
+	result := callToolHandler(t, tool, request)
+
+	assertResultText(t, result, NotificationSentMessage+" Request: req-1.")
+
+	if sender.notification.Message != "Deploy api to prod finished" || *sender.notification.Priority != 1 {
+		t.Fatalf("notification = %+v", sender.notification)
+	}
+}
+
+func TestWithTemplates_MissingVariable(t *testing.T) {
+	sender := &fakeNotificationSender{}
+	tool := newServerWithTemplates(t, sender).GetTool("send_template")
+
+	result := callToolHandler(t, tool, mcp.CallToolRequest{Params: mcp.CallToolParams{
+		Name:      "send_template",
+		Arguments: map[string]any{"template": "deploy", "data": map[string]any{"service": "api"}},
+	}})
+
+	assertResultContainsText(t, result, "template data does not fit the template")
+	assertResultContainsText(t, result, "Retrying the same request will not help")
+
+	if sender.called {
+		t.Fatal("sender was called")
+	}
+}
+
+func TestWithTemplates_Resource(t *testing.T) {
+	s := newServerWithTemplates(t, &fakeNotificationSender{})
+
+	response := s.HandleMessage(context.Background(), []byte(
+		`{"jsonrpc":"2.0","id":1,"method":"resources/read","params":{"uri":"pushover://templates"}}`,
+	))
+
+	data, err := json.Marshal(response)
+	if err != nil {
+		t.Fatalf("marshal response: %v", err)
+	}
+
+	want := `{\"templates\":[{\"name\":\"deploy\",\"description\":\"Deployment finished\",` +
+		`\"variables\":[\"env\",\"priority\",\"service\"]}]}`
+	if !strings.Contains(string(data), want) {
+		t.Fatalf("response = %s, want %s", data, want)
+	}
+}
@@ -1,12 +1,18 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"maps"
 	"net/http"
+	"path/filepath"
+	"slices"
+	"sync"
 
 	"github.com/adlandh/pushover-mcp/internal/application"
 	"github.com/adlandh/pushover-mcp/internal/config"
+	"github.com/adlandh/pushover-mcp/internal/domain"
 	"github.com/adlandh/pushover-mcp/internal/driven"
 	"github.com/adlandh/pushover-mcp/internal/driver"
 	"github.com/mark3labs/mcp-go/server"
@@ -15,9 +21,18 @@ import (
 const (
 	serverName    = "pushover-mcp"
 	serverVersion = "1.0.0"
+	scheduleFile  = "scheduled.json"
 )
 
-func buildServer(env config.EnvConfig) (*server.MCPServer, error) {
+// notificationExecutor is what both the tools and the application's own senders need from the send use case.
+type notificationExecutor interface {
+	driver.NotificationExecutor
+	application.NotificationExecutor
+}
+
+// buildServer wires the server; ctx bounds the startup checks and the background runners, which
+// are added to runners so that shutdown can wait for them to finish.
+func buildServer(ctx context.Context, env config.EnvConfig, runners *sync.WaitGroup) (*server.MCPServer, error) {
 	httpClient := &http.Client{Timeout: env.Timeout}
 
 	sender, err := driven.NewPushoverClient(env.Pushover, httpClient)
@@ -25,9 +40,188 @@ func buildServer(env config.EnvConfig) (*server.MCPServer, error) {
 		return nil, fmt.Errorf("error creating sender: %w", err)
 	}
 
-	useCase := application.NewSendNotificationUseCase(sender)
+	recipientUseCase := application.NewRecipientUseCase(sender)
+
+	if env.ValidateOnStart {
+		if _, err := recipientUseCase.Validate(ctx, "", ""); err != nil {
+			return nil, fmt.Errorf("startup self-check: %w", err)
+		}
+	}
+
+	clients, err := buildClients(env, sender, httpClient)
+	if err != nil {
+		return nil, err
+	}
+
+	defaultApp := env.DefaultApp
+	if defaultApp == "" {
+		defaultApp = config.PrimaryApp
+	}
+
+	// Every sender suppresses duplicates on its own, as the same notification through two apps is not a repeat.
+	apps := make(map[string]domain.NotificationSender, len(clients))
+	receipts := make(map[string]domain.ReceiptService, len(clients))
+	limits := make(map[string]domain.RateLimitReader, len(clients))
+	catalogs := make(map[string]application.AppCatalog, len(clients))
+
+	for name, client := range clients {
+		apps[name] = application.NewDedupSender(client, env.DedupWindow, env.IdempotencyTTL)
+		receipts[name] = client
+		limits[name] = client
+		catalogs[name] = client
+	}
+
+	useCase := application.NewSendNotificationUseCase(apps[defaultApp],
+		application.WithSoundCatalog(clients[defaultApp]),
+		application.WithDeviceCatalog(clients[defaultApp]),
+		application.WithAppCatalogs(catalogs),
+		application.WithRecipientAliases(env.Recipients),
+		application.WithApps(apps),
+		application.WithLengthPolicy(env.LengthPolicy),
+	)
+	soundUseCase := application.NewSoundUseCase(sender)
+
+	// The sound enum is a hint for the model; an unreachable API must not prevent startup.
+	sounds, err := soundUseCase.List(ctx)
+	if err != nil {
+		log.Printf("warning: %v; sound will not be restricted in the send schema", err)
+	}
+
+	opts := []driver.Option{
+		driver.WithReceipts(application.NewReceiptUseCase(clients[defaultApp], receipts)),
+		driver.WithSounds(soundUseCase, sounds),
+		driver.WithRecipientValidation(recipientUseCase),
+		driver.WithGlances(application.NewGlanceUseCase(sender)),
+		driver.WithLimits(application.NewLimitsUseCase(clients[defaultApp], limits)),
+		driver.WithRecipientAliases(slices.Sorted(maps.Keys(env.Recipients))),
+	}
+
+	if env.AttachmentDir != "" {
+		opts = append(opts, driver.WithAttachmentDir(env.AttachmentDir))
+	}
+
+	var limiter *application.RateLimiter
+
+	if env.RateLimit.Burst > 0 || env.SessionLimit.Burst > 0 {
+		limiter = application.NewRateLimiter(env.RateLimit, env.SessionLimit)
+		opts = append(opts, driver.WithRateLimit(limiter))
+	}
+
+	if len(apps) > 1 {
+		opts = append(opts, driver.WithApps(slices.Sorted(maps.Keys(apps)), defaultApp))
+	}
+
+	var (
+		store     *driven.FileScheduleStore
+		scheduler *application.SchedulerUseCase
+	)
+
+	if env.StateDir != "" {
+		store, err = driven.NewFileScheduleStore(filepath.Join(env.StateDir, scheduleFile))
+		if err != nil {
+			return nil, fmt.Errorf("error opening schedule store: %w", err)
+		}
+
+		scheduler = application.NewSchedulerUseCase(store, useCase)
+
+		runners.Go(func() {
+			scheduler.Run(ctx, func(err error) {
+				log.Printf("scheduler: %v", err)
+			})
+		})
+
+		opts = append(opts, driver.WithScheduler(scheduler))
+	}
+
+	// Scheduled notifications go out at the time they were asked for; everything else may be collected
+	// into a digest and passes the quiet-hours policy.
+	var executor notificationExecutor = useCase
+
+	if env.QuietHours != nil {
+		quiet, err := application.NewQuietHoursUseCase(useCase, *env.QuietHours, scheduler)
+		if err != nil {
+			return nil, fmt.Errorf("error applying quiet hours: %w", err)
+		}
+
+		executor = quiet
+	}
+
+	if env.Digest != nil {
+		digest, err := application.NewDigestUseCase(executor, useCase, *env.Digest)
+		if err != nil {
+			return nil, fmt.Errorf("error creating digest: %w", err)
+		}
+
+		runners.Go(func() {
+			digest.Run(ctx, func(err error) {
+				log.Printf("digest: %v", err)
+			})
+		})
+
+		executor = digest
+	}
+
+	// Repeats are answered before quiet hours and digests act, so that a notification held or collected
+	// once is not held or collected again; the senders only see notifications that go out.
+	if env.QuietHours != nil || env.Digest != nil {
+		executor = application.NewDedupExecutor(executor, env.DedupWindow, env.IdempotencyTTL)
+	}
+
+	if len(env.Templates) > 0 {
+		templates, err := application.NewTemplateUseCase(executor, env.Templates)
+		if err != nil {
+			return nil, fmt.Errorf("error loading templates: %w", err)
+		}
+
+		opts = append(opts, driver.WithTemplates(templates))
+	}
+
+	if store != nil {
+		// Recurring runs count against the rate limits, in a session of their own.
+		recurring := application.NewRecurringUseCase(store, executor, application.RecurringLimits{
+			Runs:    limiter,
+			MaxJobs: env.MaxRecurring,
+		})
 
-	return driver.NewServer(serverName, serverVersion, useCase), nil
+		runners.Go(func() {
+			recurring.Run(ctx, func(err error) {
+				log.Printf("recurring: %v", err)
+			})
+		})
+
+		opts = append(opts, driver.WithRecurring(recurring))
+	}
+
+	if env.Group != nil {
+		groups, err := driven.NewGroupClient(*env.Group, httpClient)
+		if err != nil {
+			return nil, fmt.Errorf("error creating group client: %w", err)
+		}
+
+		opts = append(opts, driver.WithGroups(application.NewGroupUseCase(groups)))
+	}
+
+	return driver.NewServer(serverName, serverVersion, executor, opts...), nil
+}
+
+// buildClients returns a client per application profile, reusing primary for config.PrimaryApp.
+func buildClients(
+	env config.EnvConfig,
+	primary *driven.PushoverClient,
+	httpClient *http.Client,
+) (map[string]*driven.PushoverClient, error) {
+	clients := map[string]*driven.PushoverClient{config.PrimaryApp: primary}
+
+	for name, cfg := range env.Apps {
+		client, err := driven.NewPushoverClient(cfg, httpClient)
+		if err != nil {
+			return nil, fmt.Errorf("error creating sender for app %q: %w", name, err)
+		}
+
+		clients[name] = client
+	}
+
+	return clients, nil
 }
 
 func run() error {
@@ -36,12 +230,23 @@ func run() error {
 		return fmt.Errorf("configuration error: %w", err)
 	}
 
-	mcpServer, err := buildServer(env)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var runners sync.WaitGroup
+
+	mcpServer, err := buildServer(ctx, env, &runners)
 	if err != nil {
 		return err
 	}
 
-	if err := server.ServeStdio(mcpServer); err != nil {
+	err = server.ServeStdio(mcpServer)
+
+	// Stopping the runners sends the pending digests; wait for that before exiting.
+	cancel()
+	runners.Wait()
+
+	if err != nil {
 		return fmt.Errorf("error starting server: %w", err)
 	}
 
@@ -2,15 +2,19 @@ package main
 
 import (
 	"context"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 
+	"github.com/adlandh/pushover-mcp/internal/application"
 	"github.com/adlandh/pushover-mcp/internal/config"
 	"github.com/adlandh/pushover-mcp/internal/driven"
 )
@@ -53,7 +57,7 @@ func TestBuildServer_SendTool_HappyPath(t *testing.T) {
 		Timeout: 5 * time.Second,
 	}
 
-	s, err := buildServer(env)
+	s, err := buildServer(t.Context(), env, &sync.WaitGroup{})
 	if err != nil {
 		t.Fatalf("buildServer() error = %v", err)
 	}
@@ -80,3 +84,207 @@ func TestBuildServer_SendTool_HappyPath(t *testing.T) {
 		t.Fatalf("unexpected request: token=%q user=%q message=%q", gotToken, gotUser, gotMessage)
 	}
 }
+
+func TestBuildServer_ValidateOnStart_InvalidKey(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"status":0,"user":"invalid","errors":["user key is invalid"]}`))
+	}))
+	defer ts.Close()
+
+	env := config.EnvConfig{
+		Pushover: driven.Config{
+			APIToken: "tok",
+			UserKey:  "bad",
+			APIURL:   ts.URL,
+		},
+		Timeout:         5 * time.Second,
+		ValidateOnStart: true,
+	}
+
+	_, err := buildServer(t.Context(), env, &sync.WaitGroup{})
+	if err == nil || !strings.Contains(err.Error(), "startup self-check") {
+		t.Fatalf("buildServer() error = %v, want startup self-check error", err)
+	}
+}
+
+func TestBuildServer_SendTool_App(t *testing.T) {
+	var gotTokens []string
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		form, _ := url.ParseQuery(string(body))
+
+		if r.Method == http.MethodPost {
+			gotTokens = append(gotTokens, form.Get("token"))
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"status":1}`))
+	}))
+	defer ts.Close()
+
+	primary := driven.Config{APIToken: "tok", UserKey: "usr", APIURL: ts.URL}
+	ci := primary
+	ci.APIToken = "tok-ci"
+
+	env := config.EnvConfig{
+		Pushover:   primary,
+		Apps:       map[string]driven.Config{"ci": ci},
+		DefaultApp: "ci",
+		Timeout:    5 * time.Second,
+	}
+
+	s, err := buildServer(t.Context(), env, &sync.WaitGroup{})
+	if err != nil {
+		t.Fatalf("buildServer() error = %v", err)
+	}
+
+	tool := s.GetTool("send")
+
+	for _, args := range []map[string]any{
+		{"message": "hello"},
+		{"message": "hello", "app": config.PrimaryApp},
+	} {
+		result, err := tool.Handler(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Name: "send", Arguments: args},
+		})
+		if err != nil || result.IsError {
+			t.Fatalf("handler error = %v, result = %+v", err, result)
+		}
+	}
+
+	if len(gotTokens) != 2 || gotTokens[0] != "tok-ci" || gotTokens[1] != "tok" {
+		t.Fatalf("tokens = %q, want [tok-ci tok]", gotTokens)
+	}
+}
+
+func TestBuildServer_ScheduleTools(t *testing.T) {
+	env := config.EnvConfig{
+		Pushover: driven.Config{APIToken: "tok", UserKey: "usr", APIURL: "http://127.0.0.1:1"},
+		Timeout:  time.Second,
+	}
+
+	s, err := buildServer(t.Context(), env, &sync.WaitGroup{})
+	if err != nil {
+		t.Fatalf("buildServer() error = %v", err)
+	}
+
+	if s.GetTool("schedule_notification") != nil {
+		t.Fatal("schedule_notification registered without a state dir")
+	}
+
+	env.StateDir = t.TempDir()
+
+	s, err = buildServer(t.Context(), env, &sync.WaitGroup{})
+	if err != nil {
+		t.Fatalf("buildServer() error = %v", err)
+	}
+
+	for _, name := range []string{
+		"schedule_notification", "list_scheduled", "cancel_scheduled",
+		"create_recurring", "list_recurring", "pause_recurring", "resume_recurring", "delete_recurring",
+	} {
+		if s.GetTool(name) == nil {
+			t.Fatalf("%s tool not registered", name)
+		}
+	}
+}
+
+func TestBuildServer_QuietHours(t *testing.T) {
+	var gotPriority string
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			body, _ := io.ReadAll(r.Body)
+			form, _ := url.ParseQuery(string(body))
+			gotPriority = form.Get("priority")
+		}
+
+		_, _ = w.Write([]byte(`{"status":1}`))
+	}))
+	defer ts.Close()
+
+	// A window from midnight to midnight is quiet all day.
+	quiet := application.QuietHours{
+		Location: time.UTC,
+		Action:   application.QuietDowngrade,
+		Priority: -2,
+		Windows:  []application.QuietWindow{{Start: 0, End: 0}},
+	}
+
+	env := config.EnvConfig{
+		Pushover:   driven.Config{APIToken: "tok", UserKey: "usr", APIURL: ts.URL},
+		Timeout:    5 * time.Second,
+		QuietHours: &quiet,
+	}
+
+	s, err := buildServer(t.Context(), env, &sync.WaitGroup{})
+	if err != nil {
+		t.Fatalf("buildServer() error = %v", err)
+	}
+
+	result, err := s.GetTool("send").Handler(t.Context(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Name: "send", Arguments: map[string]any{"message": "hello", "priority": 1}},
+	})
+	if err != nil || result.IsError {
+		t.Fatalf("handler error = %v, result = %+v", err, result)
+	}
+
+	if gotPriority != "-2" || !strings.Contains(mcp.GetTextFromContent(result.Content[0]), "priority lowered from 1 to -2") {
+		t.Fatalf("priority = %q, text = %q", gotPriority, mcp.GetTextFromContent(result.Content[0]))
+	}
+
+	quiet.Action = application.QuietHold
+
+	if _, err := buildServer(t.Context(), env, &sync.WaitGroup{}); !errors.Is(err, application.ErrQuietHoldUnavailable) {
+		t.Fatalf("buildServer() error = %v, want %v without a state dir", err, application.ErrQuietHoldUnavailable)
+	}
+}
+
+func TestBuildServer_Digest(t *testing.T) {
+	var titles, messages []string
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			body, _ := io.ReadAll(r.Body)
+			form, _ := url.ParseQuery(string(body))
+			titles = append(titles, form.Get("title"))
+			messages = append(messages, form.Get("message"))
+		}
+
+		_, _ = w.Write([]byte(`{"status":1,"request":"req-1"}`))
+	}))
+	defer ts.Close()
+
+	env := config.EnvConfig{
+		Pushover: driven.Config{APIToken: "tok", UserKey: "usr", APIURL: ts.URL},
+		Timeout:  5 * time.Second,
+		Digest:   &application.DigestPolicy{Priority: 0, Window: time.Hour, MaxItems: 2},
+
+		IdempotencyTTL: time.Hour,
+	}
+
+	s, err := buildServer(t.Context(), env, &sync.WaitGroup{})
+	if err != nil {
+		t.Fatalf("buildServer() error = %v", err)
+	}
+
+	// The repeated request is answered from the first one and does not fill the digest.
+	for _, args := range []map[string]any{
+		{"message": "one", "idempotency_key": "k"},
+		{"message": "one", "idempotency_key": "k"},
+		{"message": "two"},
+	} {
+		result, err := s.GetTool("send").Handler(t.Context(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Name: "send", Arguments: args},
+		})
+		if err != nil || result.IsError {
+			t.Fatalf("handler error = %v, result = %+v", err, result)
+		}
+	}
+
+	if len(titles) != 1 || titles[0] != "Digest: 2 notifications" || !strings.Contains(messages[0], "two") {
+		t.Fatalf("sent titles = %q, messages = %q, want one digest of one and two", titles, messages)
+	}
+}